@@ -43,6 +43,15 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			outputMode, err := github.ParseOutputMode(viper.GetString("output_mode"))
+			if err != nil {
+				return err
+			}
+			outputFormat, err := github.ParseOutputFormat(viper.GetString("output_format"))
+			if err != nil {
+				return err
+			}
+
 			stdioServerConfig := ghmcp.StdioServerConfig{
 				Version:              version,
 				Host:                 viper.GetString("host"),
@@ -50,6 +59,9 @@ var (
 				EnabledToolsets:      enabledToolsets,
 				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
 				ReadOnly:             viper.GetBool("read-only"),
+				OutputMode:           outputMode,
+				OutputFormat:         outputFormat,
+				MaxResponseSize:      viper.GetInt("max_response_size"),
 				ExportTranslations:   viper.GetBool("export-translations"),
 				EnableCommandLogging: viper.GetBool("enable-command-logging"),
 				LogFilePath:          viper.GetString("log-file"),
@@ -74,12 +86,24 @@ var (
 				return fmt.Errorf("failed to unmarshal toolsets: %w", err)
 			}
 
+			outputMode, err := github.ParseOutputMode(viper.GetString("output_mode"))
+			if err != nil {
+				return err
+			}
+			outputFormat, err := github.ParseOutputFormat(viper.GetString("output_format"))
+			if err != nil {
+				return err
+			}
+
 			multiUserConfig := ghmcp.MultiUserHTTPServerConfig{
 				Version:         version,
 				Host:            viper.GetString("host"),
 				EnabledToolsets: enabledToolsets,
 				DynamicToolsets: viper.GetBool("dynamic_toolsets"),
 				ReadOnly:        viper.GetBool("read-only"),
+				OutputMode:      outputMode,
+				OutputFormat:    outputFormat,
+				MaxResponseSize: viper.GetInt("max_response_size"),
 				Port:            port,
 			}
 			return ghmcp.RunMultiUserHTTPServer(multiUserConfig)
@@ -96,6 +120,9 @@ func init() {
 	rootCmd.PersistentFlags().StringSlice("toolsets", github.DefaultTools, "An optional comma separated list of groups of tools to allow, defaults to enabling all")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().String("output-mode", string(github.OutputModeFull), "Output mode of tool results: full API objects, or compact objects without URL fields and other rarely useful fields")
+	rootCmd.PersistentFlags().String("output-format", string(github.OutputFormatJSON), "Default format of tool results: json, or markdown tables and summaries for clients showing results to people")
+	rootCmd.PersistentFlags().Int("max-response-size", github.DefaultMaxResponseSize, "Size limit of tool results in bytes, above which they are truncated (0 for no limit)")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
@@ -106,6 +133,9 @@ func init() {
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("output_mode", rootCmd.PersistentFlags().Lookup("output-mode"))
+	_ = viper.BindPFlag("output_format", rootCmd.PersistentFlags().Lookup("output-format"))
+	_ = viper.BindPFlag("max_response_size", rootCmd.PersistentFlags().Lookup("max-response-size"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
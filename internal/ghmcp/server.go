@@ -44,6 +44,15 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// OutputMode selects between full API objects and compact cleaned objects in tool results
+	OutputMode github.OutputMode
+
+	// OutputFormat is the default format of tool results, which a call can override
+	OutputFormat github.OutputFormat
+
+	// MaxResponseSize is the size limit of tool results in bytes, above which they are truncated
+	MaxResponseSize int
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 }
@@ -92,7 +101,7 @@ func NewMCPServer(cfg MCPServerConfig) (*server.MCPServer, error) {
 		OnBeforeInitialize: []server.OnBeforeInitializeFunc{beforeInit},
 	}
 
-	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks))
+	ghServer := github.NewServer(cfg.Version, server.WithHooks(hooks), github.WithOutputMode(cfg.OutputMode), github.WithOutputFormat(cfg.OutputFormat), github.WithMaxResponseSize(cfg.MaxResponseSize))
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
@@ -157,6 +166,15 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// OutputMode selects between full API objects and compact cleaned objects in tool results
+	OutputMode github.OutputMode
+
+	// OutputFormat is the default format of tool results, which a call can override
+	OutputFormat github.OutputFormat
+
+	// MaxResponseSize is the size limit of tool results in bytes, above which they are truncated
+	MaxResponseSize int
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
@@ -183,6 +201,9 @@ func RunStdioServer(cfg StdioServerConfig) error {
 		EnabledToolsets: cfg.EnabledToolsets,
 		DynamicToolsets: cfg.DynamicToolsets,
 		ReadOnly:        cfg.ReadOnly,
+		OutputMode:      cfg.OutputMode,
+		OutputFormat:    cfg.OutputFormat,
+		MaxResponseSize: cfg.MaxResponseSize,
 		Translator:      t,
 	})
 	if err != nil {
@@ -384,6 +405,9 @@ type MultiUserHTTPServerConfig struct {
 	EnabledToolsets []string
 	DynamicToolsets bool
 	ReadOnly        bool
+	OutputMode      github.OutputMode
+	OutputFormat    github.OutputFormat
+	MaxResponseSize int
 	Port            int
 }
 
@@ -430,7 +454,7 @@ func RunMultiUserHTTPServer(cfg MultiUserHTTPServerConfig) error {
 	}
 
 	// Create MCP server once with token-aware client factories
-	ghServer := github.NewServer(cfg.Version)
+	ghServer := github.NewServer(cfg.Version, github.WithOutputMode(cfg.OutputMode), github.WithOutputFormat(cfg.OutputFormat), github.WithMaxResponseSize(cfg.MaxResponseSize))
 
 	enabledToolsets := cfg.EnabledToolsets
 	if cfg.DynamicToolsets {
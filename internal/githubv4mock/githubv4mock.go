@@ -149,9 +149,10 @@ func githubv4InputStructToMap(s any) (map[string]any, error) {
 // This client does not currently provide a mechanism for out-of-band errors e.g. returning a 500,
 // and errors are constrained to GQL errors returned in the response body with a 200 status code.
 func NewMockedHTTPClient(ms ...Matcher) *http.Client {
-	matchers := make(map[string]Matcher, len(ms))
+	// Several matchers may share a query, differing only in their variables.
+	matchers := make(map[string][]Matcher, len(ms))
 	for _, m := range ms {
-		matchers[m.Request] = m
+		matchers[m.Request] = append(matchers[m.Request], m)
 	}
 
 	mux := http.NewServeMux()
@@ -168,25 +169,24 @@ func NewMockedHTTPClient(ms ...Matcher) *http.Client {
 		}
 		defer func() { _ = r.Body.Close() }()
 
-		matcher, ok := matchers[gqlRequest.Query]
+		candidates, ok := matchers[gqlRequest.Query]
 		if !ok {
 			http.Error(w, fmt.Sprintf("no matcher found for query %s", gqlRequest.Query), http.StatusNotFound)
 			return
 		}
 
-		if len(gqlRequest.Variables) > 0 {
-			if len(gqlRequest.Variables) != len(matcher.Variables) {
-				http.Error(w, "variables do not have the same length", http.StatusBadRequest)
-				return
-			}
-
-			for k, v := range matcher.Variables {
-				if !objectsAreEqualValues(v, gqlRequest.Variables[k]) {
-					http.Error(w, "variable does not match", http.StatusBadRequest)
-					return
-				}
+		var matcher *Matcher
+		mismatch := ""
+		for i := range candidates {
+			if mismatch = variablesMismatch(candidates[i].Variables, gqlRequest.Variables); mismatch == "" {
+				matcher = &candidates[i]
+				break
 			}
 		}
+		if matcher == nil {
+			http.Error(w, mismatch, http.StatusBadRequest)
+			return
+		}
 
 		responseBody, err := json.Marshal(matcher.Response)
 		if err != nil {
@@ -204,6 +204,23 @@ func NewMockedHTTPClient(ms ...Matcher) *http.Client {
 	}}
 }
 
+// variablesMismatch describes how the variables of a request differ from those expected by a matcher, or returns
+// an empty string if they match.
+func variablesMismatch(expected, actual map[string]any) string {
+	if len(actual) == 0 {
+		return ""
+	}
+	if len(actual) != len(expected) {
+		return "variables do not have the same length"
+	}
+	for k, v := range expected {
+		if !objectsAreEqualValues(v, actual[k]) {
+			return "variable does not match"
+		}
+	}
+	return ""
+}
+
 type gqlRequest struct {
 	Query     string         `json:"query"`
 	Variables map[string]any `json:"variables,omitempty"`
@@ -3,6 +3,9 @@
 //
 // There is a modification in objectsAreEqual to check that typed nils are equal, even if their types are different.
 //
+// There is a modification in objectsAreEqualValues to compare the value behind a non-nil expected pointer, since
+// optional variables are provided as pointers but are decoded from the request without one.
+//
 // The original license, copied from https://github.com/stretchr/testify/blob/016e2e9c269209287f33ec203f340a9a723fe22c/LICENSE
 //
 // MIT License
@@ -44,6 +47,10 @@ func objectsAreEqualValues(expected, actual any) bool {
 		return false
 	}
 
+	if expectedValue.Kind() == reflect.Pointer && !expectedValue.IsNil() {
+		return objectsAreEqualValues(expectedValue.Elem().Interface(), actual)
+	}
+
 	expectedType := expectedValue.Type()
 	actualType := actualValue.Type()
 	if !expectedType.ConvertibleTo(actualType) {
@@ -59,6 +59,8 @@ func TestObjectsAreEqualValues(t *testing.T) {
 		{complex64(1e+10 + 1e+10i), complex128(1e+10 + 1e+10i), true},
 		{(*string)(nil), nil, true},         // typed nil vs untyped nil
 		{(*string)(nil), (*int)(nil), true}, // different typed nils
+		{Ptr("cursor"), "cursor", true},     // pointer vs decoded value
+		{Ptr("cursor"), "other", false},
 	}
 
 	for _, c := range cases {
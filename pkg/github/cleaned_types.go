@@ -1,6 +1,9 @@
 package github
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/google/go-github/v72/github"
 )
 
@@ -84,8 +87,8 @@ func cleanRepositoryContentSlice(contents []*github.RepositoryContent) ([]*Clean
 	return cleaned, nil
 }
 
-// cleanRepositoryForSearch keeps URL fields for Repository in search results
-func cleanRepositoryForSearch(repo *github.Repository) *CleanedRepository {
+// cleanRepository keeps the identifying fields and html_url of a Repository
+func cleanRepository(repo *github.Repository) *CleanedRepository {
 	if repo == nil {
 		return nil
 	}
@@ -99,13 +102,697 @@ func cleanRepositoryForSearch(repo *github.Repository) *CleanedRepository {
 		HTMLURL:  repo.HTMLURL,
 	}
 
-	if repo.Owner != nil {
-		cleaned.Owner = &CleanedUser{
-			Login: repo.Owner.Login,
-			ID:    repo.Owner.ID,
-			Type:  repo.Owner.Type,
+	cleaned.Owner = cleanUser(repo.Owner)
+
+	return cleaned
+}
+
+// cleanRepositories cleans a slice of Repository
+func cleanRepositories(repos []*github.Repository) []*CleanedRepository {
+	if repos == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedRepository, len(repos))
+	for i, repo := range repos {
+		cleaned[i] = cleanRepository(repo)
+	}
+	return cleaned
+}
+
+// cleanUser keeps the identifying fields of a User
+func cleanUser(user *github.User) *CleanedUser {
+	if user == nil {
+		return nil
+	}
+
+	return &CleanedUser{
+		Login: user.Login,
+		ID:    user.ID,
+		Type:  user.Type,
+	}
+}
+
+// cleanUsers cleans a slice of User
+func cleanUsers(users []*github.User) []*CleanedUser {
+	if users == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedUser, len(users))
+	for i, user := range users {
+		cleaned[i] = cleanUser(user)
+	}
+	return cleaned
+}
+
+// labelNames returns the names of labels, which is all a model needs to tell them apart
+func labelNames(labels []*github.Label) []string {
+	if labels == nil {
+		return nil
+	}
+
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.GetName()
+	}
+	return names
+}
+
+// CleanedIssue represents a cleaned version of Issue keeping only html_url
+type CleanedIssue struct {
+	Number      *int              `json:"number,omitempty"`
+	Title       *string           `json:"title,omitempty"`
+	State       *string           `json:"state,omitempty"`
+	StateReason *string           `json:"state_reason,omitempty"`
+	Body        *string           `json:"body,omitempty"`
+	User        *CleanedUser      `json:"user,omitempty"`
+	Assignees   []*CleanedUser    `json:"assignees,omitempty"`
+	Labels      []string          `json:"labels,omitempty"`
+	Milestone   *string           `json:"milestone,omitempty"`
+	Comments    *int              `json:"comments,omitempty"`
+	PullRequest bool              `json:"pull_request,omitempty"`
+	CreatedAt   *github.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt   *github.Timestamp `json:"updated_at,omitempty"`
+	ClosedAt    *github.Timestamp `json:"closed_at,omitempty"`
+	HTMLURL     *string           `json:"html_url,omitempty"`
+}
+
+// cleanIssue keeps the fields of an Issue useful to a model, with labels and milestone reduced to their names
+func cleanIssue(issue *github.Issue) *CleanedIssue {
+	if issue == nil {
+		return nil
+	}
+
+	cleaned := &CleanedIssue{
+		Number:      issue.Number,
+		Title:       issue.Title,
+		State:       issue.State,
+		StateReason: issue.StateReason,
+		Body:        issue.Body,
+		User:        cleanUser(issue.User),
+		Assignees:   cleanUsers(issue.Assignees),
+		Labels:      labelNames(issue.Labels),
+		Comments:    issue.Comments,
+		PullRequest: issue.IsPullRequest(),
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		ClosedAt:    issue.ClosedAt,
+		HTMLURL:     issue.HTMLURL,
+	}
+	if issue.Milestone != nil {
+		cleaned.Milestone = issue.Milestone.Title
+	}
+
+	return cleaned
+}
+
+// cleanIssues cleans a slice of Issue
+func cleanIssues(issues []*github.Issue) []*CleanedIssue {
+	if issues == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedIssue, len(issues))
+	for i, issue := range issues {
+		cleaned[i] = cleanIssue(issue)
+	}
+	return cleaned
+}
+
+// CleanedIssuesSearchResult represents a cleaned version of IssuesSearchResult
+type CleanedIssuesSearchResult struct {
+	Total             *int            `json:"total_count,omitempty"`
+	IncompleteResults *bool           `json:"incomplete_results,omitempty"`
+	Issues            []*CleanedIssue `json:"items,omitempty"`
+}
+
+// cleanIssuesSearchResult cleans the issues of an IssuesSearchResult
+func cleanIssuesSearchResult(result *github.IssuesSearchResult) *CleanedIssuesSearchResult {
+	if result == nil {
+		return nil
+	}
+
+	return &CleanedIssuesSearchResult{
+		Total:             result.Total,
+		IncompleteResults: result.IncompleteResults,
+		Issues:            cleanIssues(result.Issues),
+	}
+}
+
+// CleanedIssueComment represents a cleaned version of IssueComment keeping only html_url
+type CleanedIssueComment struct {
+	ID                *int64            `json:"id,omitempty"`
+	Body              *string           `json:"body,omitempty"`
+	User              *CleanedUser      `json:"user,omitempty"`
+	AuthorAssociation *string           `json:"author_association,omitempty"`
+	CreatedAt         *github.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt         *github.Timestamp `json:"updated_at,omitempty"`
+	HTMLURL           *string           `json:"html_url,omitempty"`
+}
+
+// cleanIssueComments cleans a slice of IssueComment
+func cleanIssueComments(comments []*github.IssueComment) []*CleanedIssueComment {
+	if comments == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedIssueComment, len(comments))
+	for i, comment := range comments {
+		cleaned[i] = &CleanedIssueComment{
+			ID:                comment.ID,
+			Body:              comment.Body,
+			User:              cleanUser(comment.User),
+			AuthorAssociation: comment.AuthorAssociation,
+			CreatedAt:         comment.CreatedAt,
+			UpdatedAt:         comment.UpdatedAt,
+			HTMLURL:           comment.HTMLURL,
 		}
 	}
+	return cleaned
+}
 
+// CleanedPullRequestBranch represents a cleaned version of PullRequestBranch
+type CleanedPullRequestBranch struct {
+	Label *string `json:"label,omitempty"`
+	Ref   *string `json:"ref,omitempty"`
+	SHA   *string `json:"sha,omitempty"`
+}
+
+// cleanPullRequestBranch keeps the ref and SHA of a PullRequestBranch, dropping its repository
+func cleanPullRequestBranch(branch *github.PullRequestBranch) *CleanedPullRequestBranch {
+	if branch == nil {
+		return nil
+	}
+
+	return &CleanedPullRequestBranch{
+		Label: branch.Label,
+		Ref:   branch.Ref,
+		SHA:   branch.SHA,
+	}
+}
+
+// CleanedPullRequest represents a cleaned version of PullRequest keeping only html_url
+type CleanedPullRequest struct {
+	Number             *int                      `json:"number,omitempty"`
+	Title              *string                   `json:"title,omitempty"`
+	State              *string                   `json:"state,omitempty"`
+	Draft              *bool                     `json:"draft,omitempty"`
+	Merged             *bool                     `json:"merged,omitempty"`
+	Mergeable          *bool                     `json:"mergeable,omitempty"`
+	MergeableState     *string                   `json:"mergeable_state,omitempty"`
+	Body               *string                   `json:"body,omitempty"`
+	User               *CleanedUser              `json:"user,omitempty"`
+	Assignees          []*CleanedUser            `json:"assignees,omitempty"`
+	RequestedReviewers []*CleanedUser            `json:"requested_reviewers,omitempty"`
+	Labels             []string                  `json:"labels,omitempty"`
+	Milestone          *string                   `json:"milestone,omitempty"`
+	Head               *CleanedPullRequestBranch `json:"head,omitempty"`
+	Base               *CleanedPullRequestBranch `json:"base,omitempty"`
+	Comments           *int                      `json:"comments,omitempty"`
+	ReviewComments     *int                      `json:"review_comments,omitempty"`
+	Commits            *int                      `json:"commits,omitempty"`
+	Additions          *int                      `json:"additions,omitempty"`
+	Deletions          *int                      `json:"deletions,omitempty"`
+	ChangedFiles       *int                      `json:"changed_files,omitempty"`
+	CreatedAt          *github.Timestamp         `json:"created_at,omitempty"`
+	UpdatedAt          *github.Timestamp         `json:"updated_at,omitempty"`
+	ClosedAt           *github.Timestamp         `json:"closed_at,omitempty"`
+	MergedAt           *github.Timestamp         `json:"merged_at,omitempty"`
+	HTMLURL            *string                   `json:"html_url,omitempty"`
+}
+
+// cleanPullRequest keeps the fields of a PullRequest useful to a model, with labels and milestone reduced to their names
+func cleanPullRequest(pr *github.PullRequest) *CleanedPullRequest {
+	if pr == nil {
+		return nil
+	}
+
+	cleaned := &CleanedPullRequest{
+		Number:             pr.Number,
+		Title:              pr.Title,
+		State:              pr.State,
+		Draft:              pr.Draft,
+		Merged:             pr.Merged,
+		Mergeable:          pr.Mergeable,
+		MergeableState:     pr.MergeableState,
+		Body:               pr.Body,
+		User:               cleanUser(pr.User),
+		Assignees:          cleanUsers(pr.Assignees),
+		RequestedReviewers: cleanUsers(pr.RequestedReviewers),
+		Labels:             labelNames(pr.Labels),
+		Head:               cleanPullRequestBranch(pr.Head),
+		Base:               cleanPullRequestBranch(pr.Base),
+		Comments:           pr.Comments,
+		ReviewComments:     pr.ReviewComments,
+		Commits:            pr.Commits,
+		Additions:          pr.Additions,
+		Deletions:          pr.Deletions,
+		ChangedFiles:       pr.ChangedFiles,
+		CreatedAt:          pr.CreatedAt,
+		UpdatedAt:          pr.UpdatedAt,
+		ClosedAt:           pr.ClosedAt,
+		MergedAt:           pr.MergedAt,
+		HTMLURL:            pr.HTMLURL,
+	}
+	if pr.Milestone != nil {
+		cleaned.Milestone = pr.Milestone.Title
+	}
+
+	return cleaned
+}
+
+// cleanPullRequests cleans a slice of PullRequest
+func cleanPullRequests(prs []*github.PullRequest) []*CleanedPullRequest {
+	if prs == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedPullRequest, len(prs))
+	for i, pr := range prs {
+		cleaned[i] = cleanPullRequest(pr)
+	}
+	return cleaned
+}
+
+// CleanedPullRequestReview represents a cleaned version of PullRequestReview keeping only html_url
+type CleanedPullRequestReview struct {
+	ID                *int64            `json:"id,omitempty"`
+	User              *CleanedUser      `json:"user,omitempty"`
+	Body              *string           `json:"body,omitempty"`
+	State             *string           `json:"state,omitempty"`
+	CommitID          *string           `json:"commit_id,omitempty"`
+	AuthorAssociation *string           `json:"author_association,omitempty"`
+	SubmittedAt       *github.Timestamp `json:"submitted_at,omitempty"`
+	HTMLURL           *string           `json:"html_url,omitempty"`
+}
+
+// cleanPullRequestReviews cleans a slice of PullRequestReview
+func cleanPullRequestReviews(reviews []*github.PullRequestReview) []*CleanedPullRequestReview {
+	if reviews == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedPullRequestReview, len(reviews))
+	for i, review := range reviews {
+		cleaned[i] = &CleanedPullRequestReview{
+			ID:                review.ID,
+			User:              cleanUser(review.User),
+			Body:              review.Body,
+			State:             review.State,
+			CommitID:          review.CommitID,
+			AuthorAssociation: review.AuthorAssociation,
+			SubmittedAt:       review.SubmittedAt,
+			HTMLURL:           review.HTMLURL,
+		}
+	}
+	return cleaned
+}
+
+// CleanedPullRequestComment represents a cleaned version of a PullRequestComment on a diff, keeping only
+// html_url and dropping its diff hunk
+type CleanedPullRequestComment struct {
+	ID                  *int64            `json:"id,omitempty"`
+	InReplyTo           *int64            `json:"in_reply_to_id,omitempty"`
+	PullRequestReviewID *int64            `json:"pull_request_review_id,omitempty"`
+	Path                *string           `json:"path,omitempty"`
+	CommitID            *string           `json:"commit_id,omitempty"`
+	SubjectType         *string           `json:"subject_type,omitempty"`
+	StartLine           *int              `json:"start_line,omitempty"`
+	Line                *int              `json:"line,omitempty"`
+	Side                *string           `json:"side,omitempty"`
+	Body                *string           `json:"body,omitempty"`
+	User                *CleanedUser      `json:"user,omitempty"`
+	AuthorAssociation   *string           `json:"author_association,omitempty"`
+	CreatedAt           *github.Timestamp `json:"created_at,omitempty"`
+	UpdatedAt           *github.Timestamp `json:"updated_at,omitempty"`
+	HTMLURL             *string           `json:"html_url,omitempty"`
+}
+
+// cleanPullRequestComments cleans a slice of PullRequestComment
+func cleanPullRequestComments(comments []*github.PullRequestComment) []*CleanedPullRequestComment {
+	if comments == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedPullRequestComment, len(comments))
+	for i, comment := range comments {
+		cleaned[i] = &CleanedPullRequestComment{
+			ID:                  comment.ID,
+			InReplyTo:           comment.InReplyTo,
+			PullRequestReviewID: comment.PullRequestReviewID,
+			Path:                comment.Path,
+			CommitID:            comment.CommitID,
+			SubjectType:         comment.SubjectType,
+			StartLine:           comment.StartLine,
+			Line:                comment.Line,
+			Side:                comment.Side,
+			Body:                comment.Body,
+			User:                cleanUser(comment.User),
+			AuthorAssociation:   comment.AuthorAssociation,
+			CreatedAt:           comment.CreatedAt,
+			UpdatedAt:           comment.UpdatedAt,
+			HTMLURL:             comment.HTMLURL,
+		}
+	}
+	return cleaned
+}
+
+// CleanedCommitFile represents a cleaned version of CommitFile without URL fields
+type CleanedCommitFile struct {
+	Filename         *string `json:"filename,omitempty"`
+	PreviousFilename *string `json:"previous_filename,omitempty"`
+	Status           *string `json:"status,omitempty"`
+	Additions        *int    `json:"additions,omitempty"`
+	Deletions        *int    `json:"deletions,omitempty"`
+	Changes          *int    `json:"changes,omitempty"`
+	Patch            *string `json:"patch,omitempty"`
+}
+
+// CleanedCommit represents a cleaned version of RepositoryCommit keeping only html_url, with the git commit
+// flattened into it and its parents reduced to their SHAs
+type CleanedCommit struct {
+	SHA       *string              `json:"sha,omitempty"`
+	Message   *string              `json:"message,omitempty"`
+	Author    *CleanedCommitAuthor `json:"author,omitempty"`
+	Committer *CleanedCommitAuthor `json:"committer,omitempty"`
+	Parents   []string             `json:"parents,omitempty"`
+	Stats     *github.CommitStats  `json:"stats,omitempty"`
+	Files     []*CleanedCommitFile `json:"files,omitempty"`
+	HTMLURL   *string              `json:"html_url,omitempty"`
+}
+
+// cleanCommit keeps the fields of a RepositoryCommit and its files useful to a model
+func cleanCommit(commit *github.RepositoryCommit) *CleanedCommit {
+	if commit == nil {
+		return nil
+	}
+
+	cleaned := &CleanedCommit{
+		SHA:     commit.SHA,
+		Stats:   commit.Stats,
+		HTMLURL: commit.HTMLURL,
+	}
+	if commit.Commit != nil {
+		cleaned.Message = commit.Commit.Message
+		cleaned.Author = cleanCommitAuthor(commit.Commit.Author, commit.Author)
+		cleaned.Committer = cleanCommitAuthor(commit.Commit.Committer, commit.Committer)
+	}
+	for _, parent := range commit.Parents {
+		cleaned.Parents = append(cleaned.Parents, parent.GetSHA())
+	}
+	if commit.Files != nil {
+		cleaned.Files = make([]*CleanedCommitFile, len(commit.Files))
+		for i, file := range commit.Files {
+			cleaned.Files[i] = &CleanedCommitFile{
+				Filename:         file.Filename,
+				PreviousFilename: file.PreviousFilename,
+				Status:           file.Status,
+				Additions:        file.Additions,
+				Deletions:        file.Deletions,
+				Changes:          file.Changes,
+				Patch:            file.Patch,
+			}
+		}
+	}
+
+	return cleaned
+}
+
+// cleanCommits cleans a slice of RepositoryCommit
+func cleanCommits(commits []*github.RepositoryCommit) []*CleanedCommit {
+	if commits == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedCommit, len(commits))
+	for i, commit := range commits {
+		cleaned[i] = cleanCommit(commit)
+	}
+	return cleaned
+}
+
+// CleanedReleaseAsset represents a cleaned version of ReleaseAsset keeping only browser_download_url
+type CleanedReleaseAsset struct {
+	Name               *string `json:"name,omitempty"`
+	ContentType        *string `json:"content_type,omitempty"`
+	Size               *int    `json:"size,omitempty"`
+	DownloadCount      *int    `json:"download_count,omitempty"`
+	BrowserDownloadURL *string `json:"browser_download_url,omitempty"`
+}
+
+// CleanedRelease represents a cleaned version of RepositoryRelease keeping only html_url
+type CleanedRelease struct {
+	ID              *int64                 `json:"id,omitempty"`
+	TagName         *string                `json:"tag_name,omitempty"`
+	TargetCommitish *string                `json:"target_commitish,omitempty"`
+	Name            *string                `json:"name,omitempty"`
+	Body            *string                `json:"body,omitempty"`
+	Draft           *bool                  `json:"draft,omitempty"`
+	Prerelease      *bool                  `json:"prerelease,omitempty"`
+	Author          *CleanedUser           `json:"author,omitempty"`
+	Assets          []*CleanedReleaseAsset `json:"assets,omitempty"`
+	CreatedAt       *github.Timestamp      `json:"created_at,omitempty"`
+	PublishedAt     *github.Timestamp      `json:"published_at,omitempty"`
+	HTMLURL         *string                `json:"html_url,omitempty"`
+}
+
+// cleanRelease keeps the fields of a RepositoryRelease and its assets useful to a model
+func cleanRelease(release *github.RepositoryRelease) *CleanedRelease {
+	if release == nil {
+		return nil
+	}
+
+	cleaned := &CleanedRelease{
+		ID:              release.ID,
+		TagName:         release.TagName,
+		TargetCommitish: release.TargetCommitish,
+		Name:            release.Name,
+		Body:            release.Body,
+		Draft:           release.Draft,
+		Prerelease:      release.Prerelease,
+		Author:          cleanUser(release.Author),
+		CreatedAt:       release.CreatedAt,
+		PublishedAt:     release.PublishedAt,
+		HTMLURL:         release.HTMLURL,
+	}
+	if release.Assets != nil {
+		cleaned.Assets = make([]*CleanedReleaseAsset, len(release.Assets))
+		for i, asset := range release.Assets {
+			cleaned.Assets[i] = &CleanedReleaseAsset{
+				Name:               asset.Name,
+				ContentType:        asset.ContentType,
+				Size:               asset.Size,
+				DownloadCount:      asset.DownloadCount,
+				BrowserDownloadURL: asset.BrowserDownloadURL,
+			}
+		}
+	}
+
+	return cleaned
+}
+
+// cleanReleases cleans a slice of RepositoryRelease
+func cleanReleases(releases []*github.RepositoryRelease) []*CleanedRelease {
+	if releases == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedRelease, len(releases))
+	for i, release := range releases {
+		cleaned[i] = cleanRelease(release)
+	}
+	return cleaned
+}
+
+// CleanedNotification represents a cleaned version of Notification keeping only the subject URL
+type CleanedNotification struct {
+	ID           *string `json:"id,omitempty"`
+	Repository   *string `json:"repository,omitempty"`
+	SubjectTitle *string `json:"subject_title,omitempty"`
+	SubjectType  *string `json:"subject_type,omitempty"`
+	// SubjectNumber is the number of the issue or pull request the notification is about, if any.
+	SubjectNumber *int `json:"subject_number,omitempty"`
+	// SubjectURL is the API URL of the subject, such as an issue, pull request, commit or release.
+	SubjectURL *string           `json:"subject_url,omitempty"`
+	Reason     *string           `json:"reason,omitempty"`
+	Unread     *bool             `json:"unread,omitempty"`
+	UpdatedAt  *github.Timestamp `json:"updated_at,omitempty"`
+	LastReadAt *github.Timestamp `json:"last_read_at,omitempty"`
+}
+
+// cleanNotification flattens a Notification, reducing its repository to its full name
+func cleanNotification(notification *github.Notification) *CleanedNotification {
+	if notification == nil {
+		return nil
+	}
+
+	cleaned := &CleanedNotification{
+		ID:         notification.ID,
+		Reason:     notification.Reason,
+		Unread:     notification.Unread,
+		UpdatedAt:  notification.UpdatedAt,
+		LastReadAt: notification.LastReadAt,
+	}
+	if notification.Repository != nil {
+		cleaned.Repository = notification.Repository.FullName
+	}
+	if subject := notification.Subject; subject != nil {
+		cleaned.SubjectTitle = subject.Title
+		cleaned.SubjectType = subject.Type
+		cleaned.SubjectURL = subject.URL
+		cleaned.SubjectNumber = subjectNumber(subject)
+	}
+
+	return cleaned
+}
+
+// subjectNumber returns the number of the issue or pull request a notification subject is, from the end
+// of its API URL, or nil for other subjects.
+func subjectNumber(subject *github.NotificationSubject) *int {
+	if subject.GetType() != "Issue" && subject.GetType() != "PullRequest" {
+		return nil
+	}
+	url := subject.GetURL()
+	number, err := strconv.Atoi(url[strings.LastIndexByte(url, '/')+1:])
+	if err != nil {
+		return nil
+	}
+	return &number
+}
+
+// cleanNotifications cleans a slice of Notification
+func cleanNotifications(notifications []*github.Notification) []*CleanedNotification {
+	if notifications == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedNotification, len(notifications))
+	for i, notification := range notifications {
+		cleaned[i] = cleanNotification(notification)
+	}
+	return cleaned
+}
+
+// CleanedCodeScanningAlert represents a cleaned version of a code scanning Alert keeping only html_url
+type CleanedCodeScanningAlert struct {
+	Number                *int              `json:"number,omitempty"`
+	State                 *string           `json:"state,omitempty"`
+	RuleID                *string           `json:"rule_id,omitempty"`
+	RuleDescription       *string           `json:"rule_description,omitempty"`
+	Severity              *string           `json:"severity,omitempty"`
+	SecuritySeverityLevel *string           `json:"security_severity_level,omitempty"`
+	Tool                  *string           `json:"tool,omitempty"`
+	Ref                   *string           `json:"ref,omitempty"`
+	Path                  *string           `json:"path,omitempty"`
+	StartLine             *int              `json:"start_line,omitempty"`
+	EndLine               *int              `json:"end_line,omitempty"`
+	Message               *string           `json:"message,omitempty"`
+	DismissedReason       *string           `json:"dismissed_reason,omitempty"`
+	CreatedAt             *github.Timestamp `json:"created_at,omitempty"`
+	FixedAt               *github.Timestamp `json:"fixed_at,omitempty"`
+	HTMLURL               *string           `json:"html_url,omitempty"`
+}
+
+// cleanCodeScanningAlert flattens a code scanning Alert along with its rule and most recent instance
+func cleanCodeScanningAlert(alert *github.Alert) *CleanedCodeScanningAlert {
+	if alert == nil {
+		return nil
+	}
+
+	cleaned := &CleanedCodeScanningAlert{
+		Number:          alert.Number,
+		State:           alert.State,
+		DismissedReason: alert.DismissedReason,
+		CreatedAt:       alert.CreatedAt,
+		FixedAt:         alert.FixedAt,
+		HTMLURL:         alert.HTMLURL,
+	}
+	if alert.Rule != nil {
+		cleaned.RuleID = alert.Rule.ID
+		cleaned.RuleDescription = alert.Rule.Description
+		cleaned.Severity = alert.Rule.Severity
+		cleaned.SecuritySeverityLevel = alert.Rule.SecuritySeverityLevel
+	}
+	if alert.Tool != nil {
+		cleaned.Tool = alert.Tool.Name
+	}
+	if instance := alert.MostRecentInstance; instance != nil {
+		cleaned.Ref = instance.Ref
+		if instance.Location != nil {
+			cleaned.Path = instance.Location.Path
+			cleaned.StartLine = instance.Location.StartLine
+			cleaned.EndLine = instance.Location.EndLine
+		}
+		if instance.Message != nil {
+			cleaned.Message = instance.Message.Text
+		}
+	}
+
+	return cleaned
+}
+
+// cleanCodeScanningAlerts cleans a slice of code scanning Alert
+func cleanCodeScanningAlerts(alerts []*github.Alert) []*CleanedCodeScanningAlert {
+	if alerts == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedCodeScanningAlert, len(alerts))
+	for i, alert := range alerts {
+		cleaned[i] = cleanCodeScanningAlert(alert)
+	}
+	return cleaned
+}
+
+// CleanedSecretScanningAlert represents a cleaned version of SecretScanningAlert keeping only html_url.
+// The secret itself is left out.
+type CleanedSecretScanningAlert struct {
+	Number                 *int              `json:"number,omitempty"`
+	State                  *string           `json:"state,omitempty"`
+	SecretType             *string           `json:"secret_type,omitempty"`
+	SecretTypeDisplayName  *string           `json:"secret_type_display_name,omitempty"`
+	Validity               *string           `json:"validity,omitempty"`
+	Resolution             *string           `json:"resolution,omitempty"`
+	ResolutionComment      *string           `json:"resolution_comment,omitempty"`
+	PushProtectionBypassed *bool             `json:"push_protection_bypassed,omitempty"`
+	PubliclyLeaked         *bool             `json:"publicly_leaked,omitempty"`
+	CreatedAt              *github.Timestamp `json:"created_at,omitempty"`
+	ResolvedAt             *github.Timestamp `json:"resolved_at,omitempty"`
+	HTMLURL                *string           `json:"html_url,omitempty"`
+}
+
+// cleanSecretScanningAlert keeps the fields of a SecretScanningAlert useful to a model
+func cleanSecretScanningAlert(alert *github.SecretScanningAlert) *CleanedSecretScanningAlert {
+	if alert == nil {
+		return nil
+	}
+
+	return &CleanedSecretScanningAlert{
+		Number:                 alert.Number,
+		State:                  alert.State,
+		SecretType:             alert.SecretType,
+		SecretTypeDisplayName:  alert.SecretTypeDisplayName,
+		Validity:               alert.Validity,
+		Resolution:             alert.Resolution,
+		ResolutionComment:      alert.ResolutionComment,
+		PushProtectionBypassed: alert.PushProtectionBypassed,
+		PubliclyLeaked:         alert.PubliclyLeaked,
+		CreatedAt:              alert.CreatedAt,
+		ResolvedAt:             alert.ResolvedAt,
+		HTMLURL:                alert.HTMLURL,
+	}
+}
+
+// cleanSecretScanningAlerts cleans a slice of SecretScanningAlert
+func cleanSecretScanningAlerts(alerts []*github.SecretScanningAlert) []*CleanedSecretScanningAlert {
+	if alerts == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedSecretScanningAlert, len(alerts))
+	for i, alert := range alerts {
+		cleaned[i] = cleanSecretScanningAlert(alert)
+	}
 	return cleaned
 }
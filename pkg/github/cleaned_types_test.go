@@ -0,0 +1,274 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CleanPullRequest(t *testing.T) {
+	pr := &github.PullRequest{
+		Number:   github.Ptr(42),
+		Title:    github.Ptr("Add feature"),
+		State:    github.Ptr("open"),
+		Draft:    github.Ptr(false),
+		URL:      github.Ptr("https://api.github.com/repos/owner/repo/pulls/42"),
+		HTMLURL:  github.Ptr("https://github.com/owner/repo/pull/42"),
+		DiffURL:  github.Ptr("https://github.com/owner/repo/pull/42.diff"),
+		User:     &github.User{Login: github.Ptr("octocat"), HTMLURL: github.Ptr("https://github.com/octocat")},
+		Labels:   []*github.Label{{Name: github.Ptr("enhancement")}},
+		Head:     &github.PullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("abc123"), Repo: &github.Repository{Name: github.Ptr("repo")}},
+		Base:     &github.PullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("def456")},
+		Commits:  github.Ptr(3),
+		MergedAt: nil,
+	}
+
+	assert.Equal(t, &CleanedPullRequest{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Add feature"),
+		State:   github.Ptr("open"),
+		Draft:   github.Ptr(false),
+		User:    &CleanedUser{Login: github.Ptr("octocat")},
+		Labels:  []string{"enhancement"},
+		Head:    &CleanedPullRequestBranch{Ref: github.Ptr("feature"), SHA: github.Ptr("abc123")},
+		Base:    &CleanedPullRequestBranch{Ref: github.Ptr("main"), SHA: github.Ptr("def456")},
+		Commits: github.Ptr(3),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42"),
+	}, cleanPullRequest(pr))
+	assert.Nil(t, cleanPullRequest(nil))
+}
+
+func Test_CleanRelease(t *testing.T) {
+	published := &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	release := &github.RepositoryRelease{
+		ID:          github.Ptr(int64(1)),
+		TagName:     github.Ptr("v1.0.0"),
+		Name:        github.Ptr("First release"),
+		PublishedAt: published,
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+		UploadURL:   github.Ptr("https://uploads.github.com/repos/owner/repo/releases/1/assets{?name,label}"),
+		TarballURL:  github.Ptr("https://api.github.com/repos/owner/repo/tarball/v1.0.0"),
+		Assets: []*github.ReleaseAsset{
+			{
+				Name:               github.Ptr("tool.tar.gz"),
+				Size:               github.Ptr(1024),
+				URL:                github.Ptr("https://api.github.com/repos/owner/repo/releases/assets/1"),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/tool.tar.gz"),
+			},
+		},
+	}
+
+	assert.Equal(t, &CleanedRelease{
+		ID:          github.Ptr(int64(1)),
+		TagName:     github.Ptr("v1.0.0"),
+		Name:        github.Ptr("First release"),
+		PublishedAt: published,
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+		Assets: []*CleanedReleaseAsset{
+			{
+				Name:               github.Ptr("tool.tar.gz"),
+				Size:               github.Ptr(1024),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/tool.tar.gz"),
+			},
+		},
+	}, cleanRelease(release))
+}
+
+func Test_CleanNotification(t *testing.T) {
+	notification := &github.Notification{
+		ID:         github.Ptr("1"),
+		Repository: &github.Repository{FullName: github.Ptr("owner/repo"), URL: github.Ptr("https://api.github.com/repos/owner/repo")},
+		Subject: &github.NotificationSubject{
+			Title: github.Ptr("Fix bug"),
+			Type:  github.Ptr("PullRequest"),
+			URL:   github.Ptr("https://api.github.com/repos/owner/repo/pulls/1"),
+		},
+		Reason: github.Ptr("review_requested"),
+		Unread: github.Ptr(true),
+		URL:    github.Ptr("https://api.github.com/notifications/threads/1"),
+	}
+
+	assert.Equal(t, &CleanedNotification{
+		ID:            github.Ptr("1"),
+		Repository:    github.Ptr("owner/repo"),
+		SubjectTitle:  github.Ptr("Fix bug"),
+		SubjectType:   github.Ptr("PullRequest"),
+		SubjectNumber: github.Ptr(1),
+		SubjectURL:    github.Ptr("https://api.github.com/repos/owner/repo/pulls/1"),
+		Reason:        github.Ptr("review_requested"),
+		Unread:        github.Ptr(true),
+	}, cleanNotification(notification))
+
+	// Only issues and pull requests have a number.
+	notification.Subject = &github.NotificationSubject{
+		Title: github.Ptr("v1.0.0"),
+		Type:  github.Ptr("Release"),
+		URL:   github.Ptr("https://api.github.com/repos/owner/repo/releases/123"),
+	}
+	cleaned := cleanNotification(notification)
+	assert.Nil(t, cleaned.SubjectNumber)
+	assert.Equal(t, github.Ptr("https://api.github.com/repos/owner/repo/releases/123"), cleaned.SubjectURL)
+}
+
+func Test_CleanCommit(t *testing.T) {
+	date := &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	commit := &github.RepositoryCommit{
+		SHA:     github.Ptr("abc123"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+		URL:     github.Ptr("https://api.github.com/repos/owner/repo/commits/abc123"),
+		Commit: &github.Commit{
+			Message:   github.Ptr("Fix crash on startup"),
+			Author:    &github.CommitAuthor{Name: github.Ptr("Mona"), Email: github.Ptr("mona@example.com"), Date: date},
+			Committer: &github.CommitAuthor{Name: github.Ptr("GitHub"), Email: github.Ptr("noreply@github.com"), Date: date},
+		},
+		Author:  &github.User{Login: github.Ptr("monalisa"), URL: github.Ptr("https://api.github.com/users/monalisa")},
+		Parents: []*github.Commit{{SHA: github.Ptr("def456"), URL: github.Ptr("https://api.github.com/repos/owner/repo/commits/def456")}},
+		Stats:   &github.CommitStats{Additions: github.Ptr(3), Deletions: github.Ptr(1), Total: github.Ptr(4)},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("main.go"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(3),
+				Deletions: github.Ptr(1),
+				Changes:   github.Ptr(4),
+				Patch:     github.Ptr("@@ -1 +1 @@"),
+				BlobURL:   github.Ptr("https://github.com/owner/repo/blob/abc123/main.go"),
+			},
+		},
+	}
+
+	assert.Equal(t, &CleanedCommit{
+		SHA:       github.Ptr("abc123"),
+		Message:   github.Ptr("Fix crash on startup"),
+		Author:    &CleanedCommitAuthor{Name: github.Ptr("Mona"), Email: github.Ptr("mona@example.com"), Login: github.Ptr("monalisa"), Date: date},
+		Committer: &CleanedCommitAuthor{Name: github.Ptr("GitHub"), Email: github.Ptr("noreply@github.com"), Date: date},
+		Parents:   []string{"def456"},
+		Stats:     &github.CommitStats{Additions: github.Ptr(3), Deletions: github.Ptr(1), Total: github.Ptr(4)},
+		Files: []*CleanedCommitFile{
+			{Filename: github.Ptr("main.go"), Status: github.Ptr("modified"), Additions: github.Ptr(3), Deletions: github.Ptr(1), Changes: github.Ptr(4), Patch: github.Ptr("@@ -1 +1 @@")},
+		},
+		HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+	}, cleanCommit(commit))
+	assert.Nil(t, cleanCommit(nil))
+}
+
+func Test_CleanPullRequestReviews(t *testing.T) {
+	submitted := &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	reviews := []*github.PullRequestReview{
+		{
+			ID:             github.Ptr(int64(80)),
+			User:           &github.User{Login: github.Ptr("reviewer"), AvatarURL: github.Ptr("https://avatars.githubusercontent.com/u/1")},
+			Body:           github.Ptr("Looks good"),
+			State:          github.Ptr("APPROVED"),
+			CommitID:       github.Ptr("abc123"),
+			SubmittedAt:    submitted,
+			HTMLURL:        github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-80"),
+			PullRequestURL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42"),
+		},
+	}
+
+	assert.Equal(t, []*CleanedPullRequestReview{
+		{
+			ID:          github.Ptr(int64(80)),
+			User:        &CleanedUser{Login: github.Ptr("reviewer")},
+			Body:        github.Ptr("Looks good"),
+			State:       github.Ptr("APPROVED"),
+			CommitID:    github.Ptr("abc123"),
+			SubmittedAt: submitted,
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/42#pullrequestreview-80"),
+		},
+	}, cleanPullRequestReviews(reviews))
+}
+
+func Test_CleanPullRequestComments(t *testing.T) {
+	comments := []*github.PullRequestComment{
+		{
+			ID:                  github.Ptr(int64(10)),
+			InReplyTo:           github.Ptr(int64(9)),
+			PullRequestReviewID: github.Ptr(int64(80)),
+			Path:                github.Ptr("main.go"),
+			CommitID:            github.Ptr("abc123"),
+			Line:                github.Ptr(12),
+			Side:                github.Ptr("RIGHT"),
+			DiffHunk:            github.Ptr("@@ -10,3 +10,4 @@"),
+			Body:                github.Ptr("Can this be nil?"),
+			User:                &github.User{Login: github.Ptr("reviewer")},
+			HTMLURL:             github.Ptr("https://github.com/owner/repo/pull/42#discussion_r10"),
+			URL:                 github.Ptr("https://api.github.com/repos/owner/repo/pulls/comments/10"),
+		},
+	}
+
+	assert.Equal(t, []*CleanedPullRequestComment{
+		{
+			ID:                  github.Ptr(int64(10)),
+			InReplyTo:           github.Ptr(int64(9)),
+			PullRequestReviewID: github.Ptr(int64(80)),
+			Path:                github.Ptr("main.go"),
+			CommitID:            github.Ptr("abc123"),
+			Line:                github.Ptr(12),
+			Side:                github.Ptr("RIGHT"),
+			Body:                github.Ptr("Can this be nil?"),
+			User:                &CleanedUser{Login: github.Ptr("reviewer")},
+			HTMLURL:             github.Ptr("https://github.com/owner/repo/pull/42#discussion_r10"),
+		},
+	}, cleanPullRequestComments(comments))
+}
+
+func Test_CleanCodeScanningAlert(t *testing.T) {
+	alert := &github.Alert{
+		Number: github.Ptr(7),
+		State:  github.Ptr("open"),
+		Rule: &github.Rule{
+			ID:                    github.Ptr("go/sql-injection"),
+			Severity:              github.Ptr("error"),
+			SecuritySeverityLevel: github.Ptr("high"),
+			Description:           github.Ptr("Database query built from user-controlled sources"),
+			Help:                  github.Ptr("A very long help text"),
+		},
+		Tool: &github.Tool{Name: github.Ptr("CodeQL"), GUID: github.Ptr("guid")},
+		MostRecentInstance: &github.MostRecentInstance{
+			Ref:      github.Ptr("refs/heads/main"),
+			Message:  &github.Message{Text: github.Ptr("This query depends on a user-provided value.")},
+			Location: &github.Location{Path: github.Ptr("db/query.go"), StartLine: github.Ptr(12), EndLine: github.Ptr(12)},
+		},
+		URL:          github.Ptr("https://api.github.com/repos/owner/repo/code-scanning/alerts/7"),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/security/code-scanning/7"),
+		InstancesURL: github.Ptr("https://api.github.com/repos/owner/repo/code-scanning/alerts/7/instances"),
+	}
+
+	assert.Equal(t, &CleanedCodeScanningAlert{
+		Number:                github.Ptr(7),
+		State:                 github.Ptr("open"),
+		RuleID:                github.Ptr("go/sql-injection"),
+		RuleDescription:       github.Ptr("Database query built from user-controlled sources"),
+		Severity:              github.Ptr("error"),
+		SecuritySeverityLevel: github.Ptr("high"),
+		Tool:                  github.Ptr("CodeQL"),
+		Ref:                   github.Ptr("refs/heads/main"),
+		Path:                  github.Ptr("db/query.go"),
+		StartLine:             github.Ptr(12),
+		EndLine:               github.Ptr(12),
+		Message:               github.Ptr("This query depends on a user-provided value."),
+		HTMLURL:               github.Ptr("https://github.com/owner/repo/security/code-scanning/7"),
+	}, cleanCodeScanningAlert(alert))
+}
+
+func Test_CleanSecretScanningAlert(t *testing.T) {
+	alert := &github.SecretScanningAlert{
+		Number:     github.Ptr(3),
+		State:      github.Ptr("open"),
+		SecretType: github.Ptr("github_personal_access_token"),
+		Secret:     github.Ptr("ghp_secret"),
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/security/secret-scanning/3"),
+	}
+
+	cleaned := cleanSecretScanningAlert(alert)
+	assert.Equal(t, &CleanedSecretScanningAlert{
+		Number:     github.Ptr(3),
+		State:      github.Ptr("open"),
+		SecretType: github.Ptr("github_personal_access_token"),
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/security/secret-scanning/3"),
+	}, cleaned)
+}
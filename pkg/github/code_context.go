@@ -0,0 +1,308 @@
+package github
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v72/github"
+)
+
+const (
+	// maxCodeContextLines is the most lines of context search_code returns on each side of a match.
+	maxCodeContextLines = 50
+	// codeContextWorkers is how many matched files search_code fetches at a time.
+	codeContextWorkers = 4
+	// maxCodeContextFileSize is the size of the largest file search_code fetches context from, in bytes.
+	maxCodeContextFileSize = 1 << 20
+	// maxCodeContextResults is how many results of a call search_code returns context for, bounding the
+	// files a single call fetches.
+	maxCodeContextResults = 30
+)
+
+// codeSearchBlobs caches the contents of the files code search results were fetched from, by blob SHA.
+// Blobs never change, so cached contents never go stale.
+var codeSearchBlobs = newLRUCache[[]byte](64 << 20)
+
+// CodeContext is a range of lines around one or more matches of a code search result.
+type CodeContext struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+	// MatchLines are the numbers of the lines that matched.
+	MatchLines []int `json:"match_lines"`
+	// Content is the lines of the range, each prefixed with its number and a tab.
+	Content string `json:"content"`
+}
+
+// lruCache is a cache of values bounded by their total size in bytes, evicting the least recently used
+// first. It is safe for concurrent use.
+type lruCache[V any] struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	// order holds the entries, the most recently used first.
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruCacheEntry[V any] struct {
+	key   string
+	value V
+	size  int
+}
+
+// newLRUCache returns a cache holding up to maxBytes of values.
+func newLRUCache[V any](maxBytes int) *lruCache[V] {
+	return &lruCache[V]{maxBytes: maxBytes, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the value cached for key, if any.
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*lruCacheEntry[V]).value, true
+}
+
+// add caches value, of the given size in bytes, for key, evicting the least recently used values until it
+// fits. Values larger than the cache aren't cached.
+func (c *lruCache[V]) add(key string, value V, size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if size > c.maxBytes {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		c.size -= element.Value.(*lruCacheEntry[V]).size
+		c.order.Remove(element)
+	}
+	c.entries[key] = c.order.PushFront(&lruCacheEntry[V]{key: key, value: value, size: size})
+	c.size += size
+	for c.size > c.maxBytes {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*lruCacheEntry[V])
+		c.order.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.size -= entry.size
+	}
+}
+
+// fetchedBlob is the content of a matched file, or why it couldn't be fetched.
+type fetchedBlob struct {
+	content []byte
+	err     error
+}
+
+// fetchCodeResultBlobs fetches the files of code search results at the blob SHA they were indexed at, so
+// matches line up with the fragments GitHub returned. Files are fetched once per SHA, by a bounded number of
+// workers, from the cache when possible.
+func fetchCodeResultBlobs(ctx context.Context, client *github.Client, results []*github.CodeResult) map[string]fetchedBlob {
+	fetched := make(map[string]fetchedBlob)
+	var pending []*github.CodeResult
+	for _, result := range results {
+		sha := result.GetSHA()
+		if sha == "" {
+			continue
+		}
+		if _, ok := fetched[sha]; ok {
+			continue
+		}
+		if content, ok := codeSearchBlobs.get(sha); ok {
+			fetched[sha] = fetchedBlob{content: content}
+			continue
+		}
+		fetched[sha] = fetchedBlob{}
+		pending = append(pending, result)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan *github.CodeResult)
+	for range min(codeContextWorkers, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for result := range jobs {
+				content, err := fetchCodeResultBlob(ctx, client, result)
+				if err == nil {
+					codeSearchBlobs.add(result.GetSHA(), content, len(content))
+				}
+				mu.Lock()
+				fetched[result.GetSHA()] = fetchedBlob{content: content, err: err}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, result := range pending {
+		jobs <- result
+	}
+	close(jobs)
+	wg.Wait()
+
+	return fetched
+}
+
+// fetchCodeResultBlob fetches the file of a code search result at the blob SHA it was indexed at. Files
+// larger than maxCodeContextFileSize are refused without reading them.
+func fetchCodeResultBlob(ctx context.Context, client *github.Client, result *github.CodeResult) ([]byte, error) {
+	repo := result.GetRepository()
+	u := fmt.Sprintf("repos/%s/%s/git/blobs/%s", repo.GetOwner().GetLogin(), repo.GetName(), result.GetSHA())
+	req, err := client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3.raw")
+
+	resp, err := client.BareDo(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.ContentLength > maxCodeContextFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxCodeContextFileSize)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxCodeContextFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if len(content) > maxCodeContextFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxCodeContextFileSize)
+	}
+	if !isTextContent(content) {
+		return nil, fmt.Errorf("file is binary")
+	}
+	return content, nil
+}
+
+// addCodeContexts sets the lines around the matches of the first maxCodeContextResults code search results,
+// in place of their text matches, or why they couldn't be returned.
+func addCodeContexts(ctx context.Context, client *github.Client, results []*github.CodeResult, cleaned []*CleanedCodeResult, contextLines int) {
+	for i := maxCodeContextResults; i < len(results); i++ {
+		cleaned[i].ContextError = fmt.Sprintf("context is only returned for the first %d results of a call, fetch fewer results at a time to get it", maxCodeContextResults)
+	}
+	results = results[:min(len(results), maxCodeContextResults)]
+
+	fetched := fetchCodeResultBlobs(ctx, client, results)
+	for i, result := range results {
+		blob, ok := fetched[result.GetSHA()]
+		switch {
+		case !ok:
+			cleaned[i].ContextError = "the result has no blob SHA"
+		case blob.err != nil:
+			cleaned[i].ContextError = blob.err.Error()
+		default:
+			cleaned[i].Context = codeContexts(string(blob.content), result.TextMatches, contextLines)
+			if len(cleaned[i].Context) == 0 {
+				cleaned[i].ContextError = "the matches weren't found in the file"
+				continue
+			}
+			cleaned[i].TextMatches = nil
+		}
+	}
+}
+
+// codeContexts returns the lines around the matches of a code search result in the file content, merging
+// ranges that overlap or touch. Matches are located from the text match fragments GitHub returned; those
+// whose fragment isn't found in the file are located by their text instead.
+func codeContexts(content string, textMatches []*github.TextMatch, contextLines int) []*CodeContext {
+	lineStarts := []int{0}
+	for i, c := range content {
+		if c == '\n' && i+1 < len(content) {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	lineOf := func(offset int) int {
+		return sort.SearchInts(lineStarts, offset+1)
+	}
+
+	matched := make(map[int]bool)
+	for _, textMatch := range textMatches {
+		if textMatch.GetProperty() != "content" {
+			continue
+		}
+		fragment := textMatch.GetFragment()
+		if start := strings.Index(content, fragment); fragment != "" && start >= 0 {
+			for _, match := range textMatch.Matches {
+				offset := strings.Index(fragment, match.GetText())
+				if len(match.Indices) == 2 {
+					offset = byteOffset(fragment, match.Indices[0])
+				}
+				matched[lineOf(start+max(offset, 0))] = true
+			}
+			if len(textMatch.Matches) == 0 {
+				matched[lineOf(start)] = true
+			}
+			continue
+		}
+		for _, match := range textMatch.Matches {
+			text := match.GetText()
+			if text == "" {
+				continue
+			}
+			for offset := 0; ; {
+				i := strings.Index(content[offset:], text)
+				if i < 0 {
+					break
+				}
+				matched[lineOf(offset+i)] = true
+				offset += i + len(text)
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	matchLines := make([]int, 0, len(matched))
+	for line := range matched {
+		matchLines = append(matchLines, line)
+	}
+	sort.Ints(matchLines)
+
+	return lineContexts(strings.Split(strings.TrimSuffix(content, "\n"), "\n"), matchLines, contextLines)
+}
+
+// lineContexts returns the contextLines lines around each of the sorted matchLines of a file, merging ranges
+// that overlap or touch.
+func lineContexts(lines []string, matchLines []int, contextLines int) []*CodeContext {
+	var contexts []*CodeContext
+	for _, line := range matchLines {
+		start, end := max(line-contextLines, 1), min(line+contextLines, len(lines))
+		if last := len(contexts) - 1; last >= 0 && start <= contexts[last].EndLine+1 {
+			contexts[last].EndLine = max(contexts[last].EndLine, end)
+			contexts[last].MatchLines = append(contexts[last].MatchLines, line)
+			continue
+		}
+		contexts = append(contexts, &CodeContext{StartLine: start, EndLine: end, MatchLines: []int{line}})
+	}
+	for _, c := range contexts {
+		var b strings.Builder
+		for n := c.StartLine; n <= c.EndLine; n++ {
+			fmt.Fprintf(&b, "%d\t%s\n", n, lines[n-1])
+		}
+		c.Content = b.String()
+	}
+	return contexts
+}
+
+// byteOffset returns the byte offset of the character at index i of s, as text match indices count characters.
+func byteOffset(s string, i int) int {
+	for offset := range s {
+		if i == 0 {
+			return offset
+		}
+		i--
+	}
+	return len(s)
+}
@@ -0,0 +1,244 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CodeContexts(t *testing.T) {
+	content := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"héllo\")\n\tfmt.Println(\"world\")\n}\n\nfunc other() {\n\tfmt.Println(\"world\")\n}\n"
+
+	tests := []struct {
+		name         string
+		textMatches  []*github.TextMatch
+		contextLines int
+		expected     []*CodeContext
+	}{
+		{
+			name: "match from fragment",
+			textMatches: []*github.TextMatch{{
+				Property: github.Ptr("content"),
+				Fragment: github.Ptr("\tfmt.Println(\"héllo\")\n\tfmt.Println(\"world\")"),
+				Matches:  []*github.Match{{Text: github.Ptr("world"), Indices: []int{36, 41}}},
+			}},
+			contextLines: 1,
+			expected: []*CodeContext{
+				{StartLine: 6, EndLine: 8, MatchLines: []int{7}, Content: "6\t\tfmt.Println(\"héllo\")\n7\t\tfmt.Println(\"world\")\n8\t}\n"},
+			},
+		},
+		{
+			name: "matches by text when the fragment is not in the file",
+			textMatches: []*github.TextMatch{{
+				Property: github.Ptr("content"),
+				Fragment: github.Ptr("fmt.Println(\"world\") // stale"),
+				Matches:  []*github.Match{{Text: github.Ptr("world"), Indices: []int{13, 18}}},
+			}},
+			contextLines: 0,
+			expected: []*CodeContext{
+				{StartLine: 7, EndLine: 7, MatchLines: []int{7}, Content: "7\t\tfmt.Println(\"world\")\n"},
+				{StartLine: 11, EndLine: 11, MatchLines: []int{11}, Content: "11\t\tfmt.Println(\"world\")\n"},
+			},
+		},
+		{
+			name: "overlapping ranges are merged",
+			textMatches: []*github.TextMatch{{
+				Property: github.Ptr("content"),
+				Fragment: github.Ptr("Println(\"world\")\n}\n"),
+			}, {
+				Property: github.Ptr("content"),
+				Fragment: github.Ptr("other() {\n\tfmt.Println(\"world\")"),
+				Matches:  []*github.Match{{Text: github.Ptr("world")}},
+			}},
+			contextLines: 2,
+			expected: []*CodeContext{
+				{StartLine: 5, EndLine: 12, MatchLines: []int{7, 11}, Content: "5\tfunc main() {\n6\t\tfmt.Println(\"héllo\")\n7\t\tfmt.Println(\"world\")\n8\t}\n9\t\n10\tfunc other() {\n11\t\tfmt.Println(\"world\")\n12\t}\n"},
+			},
+		},
+		{
+			name: "path matches are ignored",
+			textMatches: []*github.TextMatch{{
+				Property: github.Ptr("path"),
+				Fragment: github.Ptr("main.go"),
+				Matches:  []*github.Match{{Text: github.Ptr("main"), Indices: []int{0, 4}}},
+			}},
+			contextLines: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, codeContexts(content, tc.textMatches, tc.contextLines))
+		})
+	}
+}
+
+func Test_LRUCache(t *testing.T) {
+	cache := newLRUCache[[]byte](10)
+	cache.add("a", []byte("aaaa"), 4)
+	cache.add("b", []byte("bbbb"), 4)
+
+	// Using a makes b the least recently used.
+	_, ok := cache.get("a")
+	require.True(t, ok)
+	cache.add("c", []byte("cccc"), 4)
+
+	_, ok = cache.get("b")
+	assert.False(t, ok)
+	value, ok := cache.get("a")
+	require.True(t, ok)
+	assert.Equal(t, "aaaa", string(value))
+	_, ok = cache.get("c")
+	assert.True(t, ok)
+
+	// Contents larger than the cache are not cached.
+	cache.add("d", []byte("ddddddddddd"), 11)
+	_, ok = cache.get("d")
+	assert.False(t, ok)
+}
+
+func Test_SearchCodeContext(t *testing.T) {
+	codeSearchBlobs = newLRUCache[[]byte](1 << 20)
+	t.Cleanup(func() { codeSearchBlobs = newLRUCache[[]byte](64 << 20) })
+
+	repository := &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo"), Owner: &github.User{Login: github.Ptr("owner")}}
+	textMatches := []*github.TextMatch{{
+		Property: github.Ptr("content"),
+		Fragment: github.Ptr("func handler() {"),
+		Matches:  []*github.Match{{Text: github.Ptr("handler"), Indices: []int{5, 12}}},
+	}}
+	mockSearchResult := &github.CodeSearchResult{
+		Total:             github.Ptr(3),
+		IncompleteResults: github.Ptr(false),
+		CodeResults: []*github.CodeResult{
+			{Name: github.Ptr("a.go"), Path: github.Ptr("a.go"), SHA: github.Ptr("blob1"), Repository: repository, TextMatches: textMatches},
+			{Name: github.Ptr("copy.go"), Path: github.Ptr("vendor/a.go"), SHA: github.Ptr("blob1"), Repository: repository, TextMatches: textMatches},
+			{Name: github.Ptr("b.bin"), Path: github.Ptr("b.bin"), SHA: github.Ptr("blob2"), Repository: repository, TextMatches: textMatches},
+		},
+	}
+
+	var fetches atomic.Int32
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchCode,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Contains(t, r.Header.Get("Accept"), "text-match")
+				mockResponse(t, http.StatusOK, mockSearchResult)(w, r)
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fetches.Add(1)
+				if strings.HasSuffix(r.URL.Path, "/blob2") {
+					_, _ = w.Write([]byte{0, 1, 2})
+					return
+				}
+				_, _ = w.Write([]byte("package a\n\n// handler handles requests.\nfunc handler() {\n\treturn\n}\n"))
+			}),
+		),
+	))
+	_, handler := SearchCode(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	for range 2 {
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{
+			"q":             "handler",
+			"context_lines": float64(1),
+		}))
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var returnedResult CleanedCodeSearchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+		require.Len(t, returnedResult.CodeResults, 3)
+		for _, codeResult := range returnedResult.CodeResults[:2] {
+			assert.Empty(t, codeResult.ContextError)
+			assert.Nil(t, codeResult.TextMatches)
+			assert.Equal(t, []*CodeContext{
+				{StartLine: 3, EndLine: 5, MatchLines: []int{4}, Content: "3\t// handler handles requests.\n4\tfunc handler() {\n5\t\treturn\n"},
+			}, codeResult.Context)
+		}
+		assert.Equal(t, "file is binary", returnedResult.CodeResults[2].ContextError)
+		assert.NotNil(t, returnedResult.CodeResults[2].TextMatches)
+	}
+
+	// Identical files are fetched once, and then from the cache. Binary files aren't cached.
+	assert.Equal(t, int32(3), fetches.Load())
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"q":             "handler",
+		"context_lines": float64(maxCodeContextLines + 1),
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "context_lines must be between 0 and 50")
+}
+
+func Test_SearchCodeContextLimits(t *testing.T) {
+	codeSearchBlobs = newLRUCache[[]byte](1 << 20)
+	t.Cleanup(func() { codeSearchBlobs = newLRUCache[[]byte](64 << 20) })
+
+	repository := &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo"), Owner: &github.User{Login: github.Ptr("owner")}}
+	textMatches := []*github.TextMatch{{
+		Property: github.Ptr("content"),
+		Fragment: github.Ptr("func handler() {"),
+		Matches:  []*github.Match{{Text: github.Ptr("handler"), Indices: []int{5, 12}}},
+	}}
+	codeResults := []*github.CodeResult{
+		{Name: github.Ptr("big.go"), Path: github.Ptr("big.go"), SHA: github.Ptr("big"), Repository: repository, TextMatches: textMatches},
+	}
+	for i := range maxCodeContextResults {
+		codeResults = append(codeResults, &github.CodeResult{Name: github.Ptr("a.go"), Path: github.Ptr(fmt.Sprintf("a%d.go", i)), SHA: github.Ptr(fmt.Sprintf("blob%d", i)), Repository: repository, TextMatches: textMatches})
+	}
+
+	var fetches atomic.Int32
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetSearchCode,
+			&github.CodeSearchResult{Total: github.Ptr(len(codeResults)), CodeResults: codeResults},
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fetches.Add(1)
+				if strings.HasSuffix(r.URL.Path, "/big") {
+					_, _ = w.Write(bytes.Repeat([]byte("a\n"), maxCodeContextFileSize))
+					return
+				}
+				_, _ = w.Write([]byte("package a\n\nfunc handler() {\n}\n"))
+			}),
+		),
+	))
+	_, handler := SearchCode(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"q":             "handler",
+		"context_lines": float64(1),
+		"perPage":       float64(100),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returnedResult CleanedCodeSearchResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+	require.Len(t, returnedResult.CodeResults, maxCodeContextResults+1)
+
+	// Files over the size limit are refused, and results past the limit aren't fetched.
+	assert.Equal(t, "file is larger than 1048576 bytes", returnedResult.CodeResults[0].ContextError)
+	assert.NotEmpty(t, returnedResult.CodeResults[1].Context)
+	last := returnedResult.CodeResults[maxCodeContextResults]
+	assert.Contains(t, last.ContextError, "context is only returned for the first 30 results")
+	assert.NotNil(t, last.TextMatches)
+	assert.Equal(t, int32(maxCodeContextResults), fetches.Load())
+}
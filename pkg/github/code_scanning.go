@@ -4,8 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -32,6 +33,9 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("The number of the alert."),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedCodeScanningAlert](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -47,6 +51,11 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -59,19 +68,24 @@ func GetCodeScanningAlert(getClient GetClientFn, t translations.TranslationHelpe
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get alert"), nil
+			}
+
+			structured := cleanCodeScanningAlert(alert)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderCodeScanningAlert(alert)), structured), nil
 			}
 
-			r, err := json.Marshal(alert)
+			projected, err := projectResult(request, compactOr(ctx, alert, cleanCodeScanningAlert))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal alert: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -105,6 +119,10 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			mcp.WithString("tool_name",
 				mcp.Description("The name of the tool used for code scanning."),
 			),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedCodeScanningAlert]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -119,15 +137,83 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			state, err := OptionalParam[string](request, "state")
+			opts, pagination, err := codeScanningAlertListOptions(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts.Ref = ref
+
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Alert, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list alerts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list alerts"), nil
+			}
+
+			structured := listResult(cleanCodeScanningAlerts(alerts), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderCodeScanningAlerts(alerts)), next), structured), nil
+			}
+
+			return projectedListResult(request, compactOr(ctx, alerts, cleanCodeScanningAlerts), structured, next), nil
+		}
+}
+
+// ListOrgCodeScanningAlerts creates a tool to list code scanning alerts across all repositories in an organization.
+func ListOrgCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_code_scanning_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_CODE_SCANNING_ALERTS_DESCRIPTION", "List code scanning alerts across all repositories in a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_CODE_SCANNING_ALERTS_USER_TITLE", "List organization code scanning alerts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The name of the organization."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter code scanning alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "closed", "dismissed", "fixed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter code scanning alerts by severity"),
+				mcp.Enum("critical", "high", "medium", "low", "warning", "note", "error"),
+			),
+			mcp.WithString("tool_name",
+				mcp.Description("The name of the tool used for code scanning."),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedCodeScanningAlert]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			severity, err := OptionalParam[string](request, "severity")
+			opts, pagination, err := codeScanningAlertListOptions(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			toolName, err := OptionalParam[string](request, "tool_name")
+
+			markdown, err := markdownOutput(ctx, request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -136,25 +222,155 @@ func ListCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHel
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			alerts, resp, err := client.CodeScanning.ListAlertsForRepo(ctx, owner, repo, &github.AlertListOptions{Ref: ref, State: state, Severity: severity, ToolName: toolName})
+			alerts, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Alert, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.CodeScanning.ListAlertsForOrg(ctx, org, opts)
+			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to list alerts: %w", err)
+				return nil, fmt.Errorf("failed to list organization alerts: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
+				return responseErrorResult(resp, "failed to list organization alerts"), nil
+			}
+
+			structured := listResult(cleanCodeScanningAlerts(alerts), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderCodeScanningAlerts(alerts)), next), structured), nil
+			}
+
+			return projectedListResult(request, compactOr(ctx, alerts, cleanCodeScanningAlerts), structured, next), nil
+		}
+}
+
+// ListEnterpriseCodeScanningAlerts creates a tool to list code scanning alerts across all organizations in an enterprise.
+func ListEnterpriseCodeScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_enterprise_code_scanning_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ENTERPRISE_CODE_SCANNING_ALERTS_DESCRIPTION", "List code scanning alerts across all repositories in a GitHub enterprise. Requires enterprise admin or security manager access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENTERPRISE_CODE_SCANNING_ALERTS_USER_TITLE", "List enterprise code scanning alerts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Required(),
+				mcp.Description("The slug of the enterprise."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter code scanning alerts by state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "closed", "dismissed", "fixed"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Filter code scanning alerts by severity"),
+				mcp.Enum("critical", "high", "medium", "low", "warning", "note", "error"),
+			),
+			mcp.WithString("tool_name",
+				mcp.Description("The name of the tool used for code scanning."),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedCodeScanningAlert]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts, pagination, err := codeScanningAlertListOptions(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// go-github does not wrap the enterprise code scanning endpoint, so we build the request ourselves.
+			alerts, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Alert, *github.Response, error) {
+				opts.ListOptions = listOpts
+				u := fmt.Sprintf("enterprises/%s/code-scanning/alerts?%s", url.PathEscape(enterprise), codeScanningAlertQuery(opts).Encode())
+				req, err := client.NewRequest(http.MethodGet, u, nil)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return nil, nil, fmt.Errorf("failed to create request: %w", err)
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
-			}
 
-			r, err := json.Marshal(alerts)
+				var alerts []*github.Alert
+				resp, err := client.Do(ctx, req, &alerts)
+				return alerts, resp, err
+			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+				return nil, fmt.Errorf("failed to list enterprise alerts: %w", err)
 			}
+			defer func() { _ = resp.Body.Close() }()
 
-			return mcp.NewToolResultText(string(r)), nil
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list enterprise alerts"), nil
+			}
+
+			structured := listResult(cleanCodeScanningAlerts(alerts), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderCodeScanningAlerts(alerts)), next), structured), nil
+			}
+
+			return projectedListResult(request, compactOr(ctx, alerts, cleanCodeScanningAlerts), structured, next), nil
 		}
 }
+
+// codeScanningAlertListOptions reads the filter and pagination parameters shared by the
+// organization and enterprise code scanning alert tools.
+func codeScanningAlertListOptions(request mcp.CallToolRequest) (*github.AlertListOptions, PaginationParams, error) {
+	state, err := OptionalParam[string](request, "state")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	severity, err := OptionalParam[string](request, "severity")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	toolName, err := OptionalParam[string](request, "tool_name")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	pagination, err := OptionalPaginationParams(request)
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+
+	return &github.AlertListOptions{
+		State:    state,
+		Severity: severity,
+		ToolName: toolName,
+	}, pagination, nil
+}
+
+// codeScanningAlertQuery encodes AlertListOptions as query parameters, for endpoints that go-github does not wrap.
+func codeScanningAlertQuery(opts *github.AlertListOptions) url.Values {
+	q := url.Values{}
+	if opts.State != "" {
+		q.Set("state", opts.State)
+	}
+	if opts.Severity != "" {
+		q.Set("severity", opts.Severity)
+	}
+	if opts.ToolName != "" {
+		q.Set("tool_name", opts.ToolName)
+	}
+	if opts.After != "" {
+		q.Set("after", opts.After)
+	}
+	if opts.ListOptions.Page != 0 {
+		q.Set("page", strconv.Itoa(opts.ListOptions.Page))
+	}
+	if opts.ListOptions.PerPage != 0 {
+		q.Set("per_page", strconv.Itoa(opts.ListOptions.PerPage))
+	}
+	return q
+}
@@ -164,6 +164,8 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 						"state":     "open",
 						"severity":  "high",
 						"tool_name": "codeql",
+						"page":      "1",
+						"per_page":  "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, mockAlerts),
 					),
@@ -238,3 +240,235 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListOrgCodeScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgCodeScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_code_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "tool_name")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	// Setup mock alerts for success case
+	mockAlerts := []*github.Alert{
+		{
+			Number:     github.Ptr(42),
+			State:      github.Ptr("open"),
+			Rule:       &github.Rule{ID: github.Ptr("test-rule-1"), SecuritySeverityLevel: github.Ptr("critical")},
+			Repository: &github.Repository{FullName: github.Ptr("org/repo-a")},
+		},
+		{
+			Number:     github.Ptr(7),
+			State:      github.Ptr("open"),
+			Rule:       &github.Rule{ID: github.Ptr("test-rule-2"), SecuritySeverityLevel: github.Ptr("critical")},
+			Repository: &github.Repository{FullName: github.Ptr("org/repo-b")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedAlerts []*github.Alert
+		expectedErrMsg string
+	}{
+		{
+			name: "successful organization alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					expect(t, expectations{
+						path: "/orgs/org/code-scanning/alerts",
+						queryParams: map[string]string{
+							"state":    "open",
+							"severity": "critical",
+							"page":     "2",
+							"per_page": "50",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"state":    "open",
+				"severity": "critical",
+				"page":     float64(2),
+				"perPage":  float64(50),
+			},
+			expectError:    false,
+			expectedAlerts: mockAlerts,
+		},
+		{
+			name: "organization alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgCodeScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedAlerts []*github.Alert
+			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
+			assert.NoError(t, err)
+			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
+			for i, alert := range returnedAlerts {
+				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
+				assert.Equal(t, *tc.expectedAlerts[i].Repository.FullName, *alert.Repository.FullName)
+			}
+		})
+	}
+}
+
+func Test_ListEnterpriseCodeScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnterpriseCodeScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_enterprise_code_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise"})
+
+	mockAlerts := []*github.Alert{
+		{
+			Number:     github.Ptr(1),
+			State:      github.Ptr("open"),
+			Repository: &github.Repository{FullName: github.Ptr("org/repo")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedAlerts []*github.Alert
+		expectedErrMsg string
+	}{
+		{
+			name: "successful enterprise alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetEnterprisesCodeScanningAlertsByEnterprise,
+					expect(t, expectations{
+						path: "/enterprises/acme/code-scanning/alerts",
+						queryParams: map[string]string{
+							"state":     "open",
+							"tool_name": "CodeQL",
+							"page":      "1",
+							"per_page":  "30",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"state":      "open",
+				"tool_name":  "CodeQL",
+			},
+			expectError:    false,
+			expectedAlerts: mockAlerts,
+		},
+		{
+			name: "enterprise alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetEnterprisesCodeScanningAlertsByEnterprise,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list enterprise alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnterpriseCodeScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedAlerts []*github.Alert
+			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
+			assert.NoError(t, err)
+			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
+			for i, alert := range returnedAlerts {
+				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
+			}
+		})
+	}
+}
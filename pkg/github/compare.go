@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// CommitSummary is the gist of a commit, as listed in comparisons, blame and file history.
+type CommitSummary struct {
+	SHA string `json:"sha"`
+	// Message is the first line of the commit message.
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+	Date    string `json:"date,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// RefComparison is how a head ref differs from a base ref.
+type RefComparison struct {
+	// Status is one of ahead, behind, diverged or identical, describing head relative to base.
+	Status       string          `json:"status"`
+	AheadBy      int             `json:"ahead_by"`
+	BehindBy     int             `json:"behind_by"`
+	MergeBaseSHA string          `json:"merge_base_sha,omitempty"`
+	TotalCommits int             `json:"total_commits"`
+	URL          string          `json:"url,omitempty"`
+	Commits      []CommitSummary `json:"commits"`
+	// Files is only set on the first page of commits, and covers the whole comparison.
+	Files    []DiffFile `json:"files,omitempty"`
+	NextPage int        `json:"next_page,omitempty"`
+}
+
+// comparisonFile converts a file of a commit comparison, parsing its patch. GitHub leaves out the patch of
+// binary files and of files with very large diffs, in which case only the stats are returned.
+func comparisonFile(f *github.CommitFile, maxLines int) (DiffFile, error) {
+	file := diffFile{
+		OldPath: f.GetPreviousFilename(),
+		NewPath: f.GetFilename(),
+		Status:  f.GetStatus(),
+	}
+	if file.OldPath == "" && file.Status != "added" {
+		file.OldPath = file.NewPath
+	}
+	if file.Status == "removed" {
+		file.NewPath = ""
+	}
+	if f.Patch != nil {
+		hunks, err := parsePatch(f.GetPatch())
+		if err != nil {
+			return DiffFile{}, err
+		}
+		file.Hunks = hunks
+	}
+
+	out := file.structured(maxLines)
+	out.Additions = f.GetAdditions()
+	out.Deletions = f.GetDeletions()
+	if f.Patch == nil && f.GetChanges() > 0 {
+		out.Truncated = true
+	}
+	return out, nil
+}
+
+// CompareRefs creates a tool to compare two refs of a repository.
+func CompareRefs(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("compare_refs",
+			mcp.WithDescription(t("TOOL_COMPARE_REFS_DESCRIPTION", "Compare two branches, tags or commits of a GitHub repository: how far head is ahead of and behind base, the commits on head that are not on base, and the files changed since their merge base with their diffs. Use owner:branch as head or base to compare across forks.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_COMPARE_REFS_USER_TITLE", "Compare refs"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("base",
+				mcp.Required(),
+				mcp.Description("Base branch, tag or commit SHA, such as main. Use owner:branch for a branch of a fork"),
+			),
+			mcp.WithString("head",
+				mcp.Required(),
+				mcp.Description("Head branch, tag or commit SHA to compare with base. Use owner:branch for a branch of a fork"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Only return changed files whose path matches this glob, such as *.go or docs/*. Patterns without a slash match the file name in any directory"),
+			),
+			mcp.WithNumber("maxLinesPerFile",
+				mcp.Description("Maximum number of diff lines to return per file. Files with more lines are marked as truncated"),
+			),
+			WithPagePagination(),
+			WithFields(),
+			WithOutputSchema[RefComparison](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			base, err := requiredParam[string](request, "base")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			head, err := requiredParam[string](request, "head")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if pattern != "" {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid path pattern %q: %s", pattern, err)), nil
+				}
+			}
+			maxLinesPerFile, err := OptionalIntParam(request, "maxLinesPerFile")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{
+				Page:    pagination.page,
+				PerPage: pagination.perPage,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare refs: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to compare refs"), nil
+			}
+
+			result := RefComparison{
+				Status:       comparison.GetStatus(),
+				AheadBy:      comparison.GetAheadBy(),
+				BehindBy:     comparison.GetBehindBy(),
+				MergeBaseSHA: comparison.GetMergeBaseCommit().GetSHA(),
+				TotalCommits: comparison.GetTotalCommits(),
+				URL:          comparison.GetHTMLURL(),
+				Commits:      make([]CommitSummary, 0, len(comparison.Commits)),
+				NextPage:     resp.NextPage,
+			}
+			for _, c := range comparison.Commits {
+				commit := CommitSummary{
+					SHA:     c.GetSHA(),
+					Message: strings.SplitN(c.GetCommit().GetMessage(), "\n", 2)[0],
+					Author:  c.GetAuthor().GetLogin(),
+					URL:     c.GetHTMLURL(),
+				}
+				if commit.Author == "" {
+					commit.Author = c.GetCommit().GetAuthor().GetName()
+				}
+				if date := c.GetCommit().GetAuthor().GetDate(); !date.IsZero() {
+					commit.Date = date.Format(time.RFC3339)
+				}
+				result.Commits = append(result.Commits, commit)
+			}
+			for _, f := range comparison.Files {
+				if pattern != "" {
+					matchNew, _ := matchPathGlob(pattern, f.GetFilename())
+					matchOld, _ := matchPathGlob(pattern, f.GetPreviousFilename())
+					if !matchNew && (f.GetPreviousFilename() == "" || !matchOld) {
+						continue
+					}
+				}
+				file, err := comparisonFile(f, maxLinesPerFile)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse diff of %s: %w", f.GetFilename(), err)
+				}
+				result.Files = append(result.Files, file)
+			}
+
+			return projectedTextResult(request, result), nil
+		}
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CompareRefs(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CompareRefs(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "compare_refs", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "base")
+	assert.Contains(t, tool.InputSchema.Properties, "head")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "maxLinesPerFile")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "base", "head"})
+
+	mockComparison := &github.CommitsComparison{
+		Status:          github.Ptr("diverged"),
+		AheadBy:         github.Ptr(2),
+		BehindBy:        github.Ptr(5),
+		TotalCommits:    github.Ptr(2),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/compare/main...octocat:feature"),
+		MergeBaseCommit: &github.RepositoryCommit{SHA: github.Ptr("base123")},
+		Commits: []*github.RepositoryCommit{
+			{
+				SHA:     github.Ptr("abc123"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+				Author:  &github.User{Login: github.Ptr("octocat")},
+				Commit: &github.Commit{
+					Message: github.Ptr("Add feature\n\nWith a longer description"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("The Octocat"), Date: &github.Timestamp{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}},
+				},
+			},
+			{
+				SHA: github.Ptr("def456"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Fix typo"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("Someone Without An Account")},
+				},
+			},
+		},
+		Files: []*github.CommitFile{
+			{
+				Filename:  github.Ptr("pkg/feature.go"),
+				Status:    github.Ptr("modified"),
+				Additions: github.Ptr(2),
+				Deletions: github.Ptr(1),
+				Changes:   github.Ptr(3),
+				Patch:     github.Ptr("@@ -1,2 +1,3 @@ package pkg\n-var x = 1\n+var x = 2\n+var y = 3\n var z = 4"),
+			},
+			{
+				Filename:         github.Ptr("docs/feature.md"),
+				PreviousFilename: github.Ptr("docs/draft.md"),
+				Status:           github.Ptr("renamed"),
+			},
+			{
+				Filename:  github.Ptr("assets/logo.png"),
+				Status:    github.Ptr("added"),
+				Additions: github.Ptr(0),
+				Deletions: github.Ptr(0),
+				Changes:   github.Ptr(0),
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		verify          func(t *testing.T, comparison RefComparison)
+	}{
+		{
+			name: "compare a fork branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+						mockResponse(t, http.StatusOK, mockComparison),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "octocat:feature",
+			},
+			verify: func(t *testing.T, comparison RefComparison) {
+				assert.Equal(t, "diverged", comparison.Status)
+				assert.Equal(t, 2, comparison.AheadBy)
+				assert.Equal(t, 5, comparison.BehindBy)
+				assert.Equal(t, "base123", comparison.MergeBaseSHA)
+				assert.Equal(t, []CommitSummary{
+					{SHA: "abc123", Message: "Add feature", Author: "octocat", Date: "2025-01-02T03:04:05Z", URL: "https://github.com/owner/repo/commit/abc123"},
+					{SHA: "def456", Message: "Fix typo", Author: "Someone Without An Account"},
+				}, comparison.Commits)
+				require.Len(t, comparison.Files, 3)
+				assert.Equal(t, "pkg/feature.go", comparison.Files[0].Path)
+				assert.Equal(t, 2, comparison.Files[0].Additions)
+				assert.Equal(t, 1, comparison.Files[0].Deletions)
+				require.Len(t, comparison.Files[0].Hunks, 1)
+				assert.Equal(t, "@@ -1,2 +1,3 @@ package pkg", comparison.Files[0].Hunks[0].Header)
+				assert.Len(t, comparison.Files[0].Hunks[0].Lines, 4)
+				assert.Equal(t, "docs/draft.md", comparison.Files[1].PreviousPath)
+				assert.Empty(t, comparison.Files[1].Hunks)
+				assert.False(t, comparison.Files[2].Truncated)
+			},
+		},
+		{
+			name: "filter and trim files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposCompareByOwnerByRepoByBasehead, mockComparison),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":           "owner",
+				"repo":            "repo",
+				"base":            "main",
+				"head":            "feature",
+				"path":            "*.go",
+				"maxLinesPerFile": float64(2),
+			},
+			verify: func(t *testing.T, comparison RefComparison) {
+				require.Len(t, comparison.Files, 1)
+				file := comparison.Files[0]
+				assert.True(t, file.Truncated)
+				assert.Equal(t, 2, file.Additions)
+				assert.Equal(t, []DiffLine{
+					{Type: "removed", Content: "var x = 1", OldLine: 1},
+					{Type: "added", Content: "var x = 2", NewLine: 1},
+				}, file.Hunks[0].Lines)
+			},
+		},
+		{
+			name:         "invalid path pattern",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "feature",
+				"path":  "[",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid path pattern",
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCompareByOwnerByRepoByBasehead,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"base":  "main",
+				"head":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to compare refs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CompareRefs(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var comparison RefComparison
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &comparison))
+			tc.verify(t, comparison)
+		})
+	}
+}
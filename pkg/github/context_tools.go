@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -19,6 +20,7 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 		mcp.WithString("reason",
 			mcp.Description("Optional: the reason for requesting the user information"),
 		),
+		WithOutputSchema[github.User](),
 	)
 
 	type args struct{}
@@ -33,7 +35,7 @@ func GetMe(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Too
 			return mcp.NewToolResultErrorFromErr("failed to get user", err), nil
 		}
 
-		return MarshalledTextResult(user), nil
+		return structuredTextResult(user), nil
 	})
 
 	return tool, handler
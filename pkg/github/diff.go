@@ -0,0 +1,384 @@
+package github
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// diffFile is the section of a unified diff, as produced by git, that covers a single file.
+type diffFile struct {
+	// OldPath is empty for added files and NewPath is empty for removed files.
+	OldPath string
+	NewPath string
+	// Status is one of added, removed, renamed or modified.
+	Status string
+	Binary bool
+	Hunks  []diffHunk
+}
+
+// path returns the path of the file in the head of the diff, or its old path if it was removed.
+func (f diffFile) path() string {
+	if f.NewPath != "" {
+		return f.NewPath
+	}
+	return f.OldPath
+}
+
+// diffHunk is a contiguous block of changes along with its surrounding context lines.
+type diffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	// Section is the text following the hunk range, usually the enclosing function.
+	Section string
+	Lines   []diffLine
+}
+
+// newEnd returns the last line of the hunk on the new side of the diff.
+func (h diffHunk) newEnd() int {
+	return h.NewStart + h.NewLines - 1
+}
+
+// containsNewRange reports whether lines start through end of the new side of the diff all fall within the hunk.
+func (h diffHunk) containsNewRange(start, end int) bool {
+	return h.NewLines > 0 && start >= h.NewStart && end <= h.newEnd()
+}
+
+// diffLine is a single line of a hunk. OldLine is zero for added lines and NewLine is zero for removed lines.
+type diffLine struct {
+	// Op is ' ' for context lines, '+' for added lines and '-' for removed lines.
+	Op      byte
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+// DiffFile is a file of a diff with its hunks parsed.
+type DiffFile struct {
+	Path string `json:"path"`
+	// PreviousPath is set for renamed files.
+	PreviousPath string     `json:"previous_path,omitempty"`
+	Status       string     `json:"status"`
+	Binary       bool       `json:"binary,omitempty"`
+	Additions    int        `json:"additions"`
+	Deletions    int        `json:"deletions"`
+	Hunks        []DiffHunk `json:"hunks"`
+	// Truncated is set when lines of the file were left out to keep the response small. Additions and
+	// Deletions always count all lines.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// DiffHunk is a hunk of a DiffFile.
+type DiffHunk struct {
+	Header   string     `json:"header"`
+	OldStart int        `json:"old_start"`
+	OldLines int        `json:"old_lines"`
+	NewStart int        `json:"new_start"`
+	NewLines int        `json:"new_lines"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// DiffLine is a line of a DiffHunk. Type is one of context, added or removed.
+type DiffLine struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
+
+// StructuredDiff is a page of the files of a diff.
+type StructuredDiff struct {
+	// TotalFiles is the number of files matching the path filter, across all pages.
+	TotalFiles int        `json:"total_files"`
+	Files      []DiffFile `json:"files"`
+	NextPage   int        `json:"next_page,omitempty"`
+}
+
+var diffLineTypes = map[byte]string{
+	' ': "context",
+	'+': "added",
+	'-': "removed",
+}
+
+// structured converts the file for output, keeping at most maxLines lines across its hunks when maxLines is positive.
+func (f diffFile) structured(maxLines int) DiffFile {
+	out := DiffFile{
+		Path:   f.path(),
+		Status: f.Status,
+		Binary: f.Binary,
+		Hunks:  make([]DiffHunk, 0, len(f.Hunks)),
+	}
+	if f.Status == "renamed" {
+		out.PreviousPath = f.OldPath
+	}
+
+	kept := 0
+	for _, h := range f.Hunks {
+		hunk := DiffHunk{
+			Header:   strings.TrimSpace(fmt.Sprintf("@@ -%d,%d +%d,%d @@ %s", h.OldStart, h.OldLines, h.NewStart, h.NewLines, h.Section)),
+			OldStart: h.OldStart,
+			OldLines: h.OldLines,
+			NewStart: h.NewStart,
+			NewLines: h.NewLines,
+			Lines:    make([]DiffLine, 0, len(h.Lines)),
+		}
+		for _, l := range h.Lines {
+			switch l.Op {
+			case '+':
+				out.Additions++
+			case '-':
+				out.Deletions++
+			}
+			if maxLines > 0 && kept >= maxLines {
+				out.Truncated = true
+				continue
+			}
+			hunk.Lines = append(hunk.Lines, DiffLine{
+				Type:    diffLineTypes[l.Op],
+				Content: l.Text,
+				OldLine: l.OldLine,
+				NewLine: l.NewLine,
+			})
+			kept++
+		}
+		if len(hunk.Lines) > 0 || len(h.Lines) == 0 {
+			out.Hunks = append(out.Hunks, hunk)
+		}
+	}
+
+	return out
+}
+
+// matchPathGlob reports whether a file path matches a glob pattern, using path.Match syntax, where a "**"
+// segment also matches any number of directories. Patterns without a slash are matched against the file
+// name, so "*.go" matches Go files in any directory.
+func matchPathGlob(pattern, name string) (bool, error) {
+	if !strings.Contains(pattern, "/") {
+		name = path.Base(name)
+	}
+	if !strings.Contains(pattern, "**") {
+		return path.Match(pattern, name)
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+// matchGlobSegments matches the segments of a path against those of a glob pattern.
+func matchGlobSegments(pattern, name []string) (bool, error) {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if ok, err := matchGlobSegments(pattern[1:], name[i:]); ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(name) == 0 {
+			return false, nil
+		}
+		if ok, err := path.Match(pattern[0], name[0]); !ok || err != nil {
+			return false, err
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0, nil
+}
+
+// structuredDiff returns a page of the files of a parsed diff whose current or previous path matches the
+// pattern, which may be empty to keep all files.
+func structuredDiff(files []diffFile, pattern string, page, perPage, maxLinesPerFile int) (StructuredDiff, error) {
+	matching := files
+	if pattern != "" {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return StructuredDiff{}, fmt.Errorf("invalid path pattern %q: %w", pattern, err)
+		}
+		matching = nil
+		for _, f := range files {
+			matchNew, _ := matchPathGlob(pattern, f.NewPath)
+			matchOld, _ := matchPathGlob(pattern, f.OldPath)
+			if (f.NewPath != "" && matchNew) || (f.OldPath != "" && matchOld) {
+				matching = append(matching, f)
+			}
+		}
+	}
+
+	result := StructuredDiff{
+		TotalFiles: len(matching),
+		Files:      []DiffFile{},
+	}
+	start := (page - 1) * perPage
+	if start >= len(matching) {
+		return result, nil
+	}
+	end := min(start+perPage, len(matching))
+	for _, f := range matching[start:end] {
+		result.Files = append(result.Files, f.structured(maxLinesPerFile))
+	}
+	if end < len(matching) {
+		result.NextPage = page + 1
+	}
+
+	return result, nil
+}
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// parseUnifiedDiff parses a git diff, such as the one returned for a pull request or a commit comparison.
+func parseUnifiedDiff(diff string) ([]diffFile, error) {
+	var files []diffFile
+	var file *diffFile
+	var hunk *diffHunk
+	var oldLine, newLine, oldLeft, newLeft int
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Lines of the current hunk come first, as their content can look like any header.
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			op := byte(' ')
+			text := ""
+			if line != "" {
+				op, text = line[0], line[1:]
+			}
+			switch {
+			case op == ' ' && (oldLeft == 0 || newLeft == 0),
+				op == '+' && newLeft == 0,
+				op == '-' && oldLeft == 0:
+				return nil, fmt.Errorf("hunk of %s has more lines than its header says: %q", file.path(), line)
+			}
+			switch op {
+			case ' ':
+				hunk.Lines = append(hunk.Lines, diffLine{Op: op, Text: text, OldLine: oldLine, NewLine: newLine})
+				oldLine++
+				newLine++
+				oldLeft--
+				newLeft--
+				continue
+			case '+':
+				hunk.Lines = append(hunk.Lines, diffLine{Op: op, Text: text, NewLine: newLine})
+				newLine++
+				newLeft--
+				continue
+			case '-':
+				hunk.Lines = append(hunk.Lines, diffLine{Op: op, Text: text, OldLine: oldLine})
+				oldLine++
+				oldLeft--
+				continue
+			case '\\':
+				// "\ No newline at end of file"
+				continue
+			case '@', 'd':
+				if strings.HasPrefix(line, "@@ ") || strings.HasPrefix(line, "diff --git ") {
+					return nil, fmt.Errorf("hunk of %s has fewer lines than its header says", file.path())
+				}
+			}
+			return nil, fmt.Errorf("unexpected line in hunk of %s: %q", file.path(), line)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, diffFile{Status: "modified"})
+			file = &files[len(files)-1]
+			hunk = nil
+			file.OldPath, file.NewPath = parseDiffGitHeader(line)
+		case file == nil:
+			// Anything before the first file header, such as a commit message, is ignored.
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file" following the last line of a hunk.
+		case strings.HasPrefix(line, "@@ "):
+			m := hunkHeaderRE.FindStringSubmatch(line)
+			if m == nil {
+				return nil, fmt.Errorf("malformed hunk header in %s: %q", file.path(), line)
+			}
+			file.Hunks = append(file.Hunks, diffHunk{
+				OldStart: atoiOr(m[1], 0),
+				OldLines: atoiOr(m[2], 1),
+				NewStart: atoiOr(m[3], 0),
+				NewLines: atoiOr(m[4], 1),
+				Section:  m[5],
+			})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+		case hunk != nil && line != "" && strings.ContainsRune(" +-", rune(line[0])):
+			// Like git, reject hunks with more lines than their header says, whose line numbers can't be trusted.
+			return nil, fmt.Errorf("hunk of %s has more lines than its header says: %q", file.path(), line)
+		case hunk != nil && line != "":
+			return nil, fmt.Errorf("unexpected line after hunk of %s: %q", file.path(), line)
+		case strings.HasPrefix(line, "new file mode"):
+			file.Status = "added"
+			file.OldPath = ""
+		case strings.HasPrefix(line, "deleted file mode"):
+			file.Status = "removed"
+			file.NewPath = ""
+		case strings.HasPrefix(line, "rename from "):
+			file.Status = "renamed"
+			file.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			file.Status = "renamed"
+			file.NewPath = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+			file.Binary = true
+		case strings.HasPrefix(line, "--- "):
+			file.OldPath = parseDiffFilePath(strings.TrimPrefix(line, "--- "), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			file.NewPath = parseDiffFilePath(strings.TrimPrefix(line, "+++ "), "b/")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read diff: %w", err)
+	}
+	if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, fmt.Errorf("hunk of %s has fewer lines than its header says", file.path())
+	}
+
+	return files, nil
+}
+
+// parsePatch parses the hunks of a single file, as found in the patch field of the files of a commit or a
+// commit comparison, which leaves out the file headers.
+func parsePatch(patch string) ([]diffHunk, error) {
+	files, err := parseUnifiedDiff("diff --git a/file b/file\n" + patch)
+	if err != nil {
+		return nil, err
+	}
+	return files[0].Hunks, nil
+}
+
+// parseDiffGitHeader returns the paths of a "diff --git a/old b/new" line. The ---/+++ lines, when present,
+// are more reliable for paths containing spaces.
+func parseDiffGitHeader(line string) (string, string) {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	i := strings.Index(rest, " b/")
+	if i < 0 || !strings.HasPrefix(rest, "a/") {
+		return "", ""
+	}
+	return rest[2:i], rest[i+3:]
+}
+
+func parseDiffFilePath(s, prefix string) string {
+	// git appends a tab to paths containing spaces.
+	s = strings.TrimSuffix(s, "\t")
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+func atoiOr(s string, fallback int) int {
+	if s == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,243 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@ package main
+ import "fmt"
+
+-func main() {
++func main() { // entry point
++	defer fmt.Println("bye")
+ 	fmt.Println("hi")
+@@ -20,2 +21,2 @@ func helper() {
+-	return 1
++	return 2
+ }
+\ No newline at end of file
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1 @@
++--- not a header
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-one
+-two
+diff --git a/a.go b/b.go
+similarity index 100%
+rename from a.go
+rename to b.go
+diff --git a/logo.png b/logo.png
+index 5555555..6666666 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+	files, err := parseUnifiedDiff(diff)
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+
+	mainGo := files[0]
+	assert.Equal(t, "main.go", mainGo.OldPath)
+	assert.Equal(t, "main.go", mainGo.NewPath)
+	assert.Equal(t, "modified", mainGo.Status)
+	require.Len(t, mainGo.Hunks, 2)
+	assert.Equal(t, diffHunk{
+		OldStart: 1, OldLines: 4, NewStart: 1, NewLines: 5,
+		Section: "package main",
+		Lines: []diffLine{
+			{Op: ' ', Text: `import "fmt"`, OldLine: 1, NewLine: 1},
+			{Op: ' ', Text: "", OldLine: 2, NewLine: 2},
+			{Op: '-', Text: "func main() {", OldLine: 3},
+			{Op: '+', Text: "func main() { // entry point", NewLine: 3},
+			{Op: '+', Text: `	defer fmt.Println("bye")`, NewLine: 4},
+			{Op: ' ', Text: `	fmt.Println("hi")`, OldLine: 4, NewLine: 5},
+		},
+	}, mainGo.Hunks[0])
+	assert.Equal(t, 21, mainGo.Hunks[1].NewStart)
+	assert.Equal(t, 22, mainGo.Hunks[1].newEnd())
+	assert.True(t, mainGo.Hunks[1].containsNewRange(21, 22))
+	assert.False(t, mainGo.Hunks[1].containsNewRange(20, 22))
+
+	added := files[1]
+	assert.Equal(t, "added", added.Status)
+	assert.Empty(t, added.OldPath)
+	assert.Equal(t, "docs/new.md", added.path())
+	require.Len(t, added.Hunks, 1)
+	assert.Equal(t, []diffLine{{Op: '+', Text: "--- not a header", NewLine: 1}}, added.Hunks[0].Lines)
+
+	removed := files[2]
+	assert.Equal(t, "removed", removed.Status)
+	assert.Empty(t, removed.NewPath)
+	assert.Equal(t, "old.txt", removed.path())
+	require.Len(t, removed.Hunks, 1)
+	assert.Equal(t, 0, removed.Hunks[0].NewLines)
+	assert.False(t, removed.Hunks[0].containsNewRange(0, 0))
+
+	renamed := files[3]
+	assert.Equal(t, "renamed", renamed.Status)
+	assert.Equal(t, "a.go", renamed.OldPath)
+	assert.Equal(t, "b.go", renamed.NewPath)
+	assert.Empty(t, renamed.Hunks)
+
+	binary := files[4]
+	assert.True(t, binary.Binary)
+	assert.Equal(t, "logo.png", binary.path())
+}
+
+func Test_ParseUnifiedDiffMalformedHunk(t *testing.T) {
+	_, err := parseUnifiedDiff("diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1 +1 @@\n?garbage\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected line in hunk of x")
+
+	// Hunks whose line counts don't match their header are rejected, as their line numbers can't be trusted.
+	_, err = parseUnifiedDiff("diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1 +1 @@\n-a\n+b\n+c\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hunk of x has more lines than its header says")
+
+	_, err = parseUnifiedDiff("diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1,2 +1,1 @@\n y\n z\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hunk of x has more lines than its header says")
+
+	_, err = parseUnifiedDiff("diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n-a\n+b\n@@ -9 +9 @@\n-c\n+d\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hunk of x has fewer lines than its header says")
+
+	_, err = parseUnifiedDiff("diff --git a/x b/x\n--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n-a\n+b\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hunk of x has fewer lines than its header says")
+}
+
+func Test_StructuredDiff(t *testing.T) {
+	diff := `diff --git a/pkg/a.go b/pkg/a.go
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -1,2 +1,2 @@
+-one
++uno
+ two
+@@ -10 +10 @@ func f() {
+-ten
++diez
+diff --git a/cmd/main.go b/cmd/main.go
+new file mode 100644
+--- /dev/null
++++ b/cmd/main.go
+@@ -0,0 +1 @@
++package main
+diff --git a/docs/old.md b/docs/new.md
+similarity index 90%
+rename from docs/old.md
+rename to docs/new.md
+--- a/docs/old.md
++++ b/docs/new.md
+@@ -3 +3 @@
+-draft
++final
+`
+	files, err := parseUnifiedDiff(diff)
+	require.NoError(t, err)
+
+	t.Run("filters by file name in any directory", func(t *testing.T) {
+		result, err := structuredDiff(files, "*.go", 1, 30, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.TotalFiles)
+		require.Len(t, result.Files, 2)
+		assert.Equal(t, "pkg/a.go", result.Files[0].Path)
+		assert.Equal(t, 2, result.Files[0].Additions)
+		assert.Equal(t, 2, result.Files[0].Deletions)
+		assert.Equal(t, "@@ -10,1 +10,1 @@ func f() {", result.Files[0].Hunks[1].Header)
+		assert.Equal(t, "cmd/main.go", result.Files[1].Path)
+		assert.Equal(t, "added", result.Files[1].Status)
+		assert.Zero(t, result.NextPage)
+	})
+
+	t.Run("filters by directory and matches previous paths", func(t *testing.T) {
+		result, err := structuredDiff(files, "docs/old.*", 1, 30, 0)
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, "docs/new.md", result.Files[0].Path)
+		assert.Equal(t, "docs/old.md", result.Files[0].PreviousPath)
+		assert.Equal(t, "renamed", result.Files[0].Status)
+	})
+
+	t.Run("pages by file", func(t *testing.T) {
+		result, err := structuredDiff(files, "", 1, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.TotalFiles)
+		assert.Len(t, result.Files, 2)
+		assert.Equal(t, 2, result.NextPage)
+
+		result, err = structuredDiff(files, "", 2, 2, 0)
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, "docs/new.md", result.Files[0].Path)
+		assert.Zero(t, result.NextPage)
+
+		result, err = structuredDiff(files, "", 3, 2, 0)
+		require.NoError(t, err)
+		assert.Empty(t, result.Files)
+	})
+
+	t.Run("truncates lines per file", func(t *testing.T) {
+		result, err := structuredDiff(files, "pkg/*", 1, 30, 3)
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		file := result.Files[0]
+		assert.True(t, file.Truncated)
+		assert.Equal(t, 2, file.Additions)
+		require.Len(t, file.Hunks, 1)
+		assert.Equal(t, []DiffLine{
+			{Type: "removed", Content: "one", OldLine: 1},
+			{Type: "added", Content: "uno", NewLine: 1},
+			{Type: "context", Content: "two", OldLine: 2, NewLine: 2},
+		}, file.Hunks[0].Lines)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := structuredDiff(files, "pkg/[", 1, 30, 0)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid path pattern")
+	})
+}
+
+func Test_MatchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"*.go", "pkg/github/diff.go", true},
+		{"*.go", "README.md", false},
+		{"pkg/*.go", "pkg/github/diff.go", false},
+		{"pkg/**/*.go", "pkg/github/diff.go", true},
+		{"pkg/**/*.go", "pkg/diff.go", true},
+		{"pkg/**", "pkg/github/diff.go", true},
+		{"**/testdata/**", "pkg/github/testdata/a.json", true},
+		{"**/testdata/**", "pkg/github/diff.go", false},
+		{"vendor/**", "pkg/vendor/a.go", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.name, func(t *testing.T) {
+			matched, err := matchPathGlob(tc.pattern, tc.name)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
@@ -32,6 +32,7 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 				mcp.Description("The name of the toolset to enable"),
 				ToolsetEnum(toolsetGroup),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsets back to a map for JSON serialization
@@ -44,7 +45,7 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 				return mcp.NewToolResultError(fmt.Sprintf("Toolset %s not found", toolsetName)), nil
 			}
 			if toolset.Enabled {
-				return mcp.NewToolResultText(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
+				return actionResult(fmt.Sprintf("Toolset %s is already enabled", toolsetName)), nil
 			}
 
 			toolset.Enabled = true
@@ -55,7 +56,7 @@ func EnableToolset(s *server.MCPServer, toolsetGroup *toolsets.ToolsetGroup, t t
 			// s.sendNotificationToAllClients("notifications/tools/list_changed", nil)
 			s.AddTools(toolset.GetActiveTools()...)
 
-			return mcp.NewToolResultText(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
+			return actionResult(fmt.Sprintf("Toolset %s enabled", toolsetName)), nil
 		}
 }
 
@@ -66,6 +67,7 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				Title:        t("TOOL_LIST_AVAILABLE_TOOLSETS_USER_TITLE", "List available toolsets"),
 				ReadOnlyHint: toBoolPtr(true),
 			}),
+			WithOutputSchema[ListResult[map[string]string]](),
 		),
 		func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
@@ -89,7 +91,7 @@ func ListAvailableToolsets(toolsetGroup *toolsets.ToolsetGroup, t translations.T
 				return nil, fmt.Errorf("failed to marshal features: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), listResult(payload, "")), nil
 		}
 }
 
@@ -105,6 +107,7 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				mcp.Description("The name of the toolset you want to get the tools for"),
 				ToolsetEnum(toolsetGroup),
 			),
+			WithOutputSchema[ListResult[map[string]string]](),
 		),
 		func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			// We need to convert the toolsetGroup back to a map for JSON serialization
@@ -133,6 +136,6 @@ func GetToolsetsTools(toolsetGroup *toolsets.ToolsetGroup, t translations.Transl
 				return nil, fmt.Errorf("failed to marshal features: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), listResult(payload, "")), nil
 		}
 }
@@ -0,0 +1,293 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ErrorCode classifies why a tool failed, telling the model whether and how the call can be fixed.
+type ErrorCode string
+
+const (
+	// ErrorNotFound is returned when the resource doesn't exist, or can't be seen with the token.
+	ErrorNotFound ErrorCode = "not_found"
+	// ErrorPermissionDenied is returned when the token lacks the scopes or access a call needs.
+	ErrorPermissionDenied ErrorCode = "permission_denied"
+	// ErrorValidationFailed is returned when the arguments of a call are invalid.
+	ErrorValidationFailed ErrorCode = "validation_failed"
+	// ErrorRateLimited is returned when the primary or secondary rate limit was exceeded.
+	ErrorRateLimited ErrorCode = "rate_limited"
+	// ErrorConflict is returned when the resource changed since it was read, or is in a state that
+	// doesn't allow the call.
+	ErrorConflict ErrorCode = "conflict"
+	// ErrorUpstreamUnavailable is returned when GitHub failed or couldn't be reached.
+	ErrorUpstreamUnavailable ErrorCode = "upstream_unavailable"
+	// ErrorInternal is returned for any other failure.
+	ErrorInternal ErrorCode = "internal"
+)
+
+// errorHints tell the model what to do about each kind of error.
+var errorHints = map[ErrorCode]string{
+	ErrorNotFound:            "Check the owner, repository, number or ref. GitHub also answers not found for private resources the token can't access.",
+	ErrorPermissionDenied:    "The token lacks the scopes or repository access this call needs. Ask the user to grant them rather than retrying.",
+	ErrorValidationFailed:    "Fix the arguments and call the tool again.",
+	ErrorRateLimited:         "Wait until retry_after before calling GitHub again, and fetch fewer or smaller pages.",
+	ErrorConflict:            "The resource changed since it was read, or its state doesn't allow this. Read it again before retrying.",
+	ErrorUpstreamUnavailable: "GitHub failed or timed out. Retry the call later.",
+	ErrorInternal:            "Retrying the same call is unlikely to help.",
+}
+
+// ToolError is a classified tool failure. It is returned as the text of error results and, as the "error"
+// field of their _meta, as an object clients can act on.
+type ToolError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Hint    string    `json:"hint,omitempty"`
+	// Status is the HTTP status GitHub answered with, if any.
+	Status int `json:"status,omitempty"`
+	// Details are the individual errors GitHub reported, such as the invalid fields of a validation error.
+	Details []string `json:"details,omitempty"`
+	// RetryAfter is when rate limited calls can be retried, if known.
+	RetryAfter *time.Time `json:"retry_after,omitempty"`
+}
+
+// newToolError returns an error of the given code, with the hint for that code.
+func newToolError(code ErrorCode, message string) *ToolError {
+	return &ToolError{Code: code, Message: message, Hint: errorHints[code]}
+}
+
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+// text returns the error as the text of a tool result.
+func (e *ToolError) text() string {
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for _, detail := range e.Details {
+		fmt.Fprintf(&b, "\n- %s", detail)
+	}
+	fmt.Fprintf(&b, "\nError code: %s.", e.Code)
+	if e.RetryAfter != nil {
+		fmt.Fprintf(&b, " Retry after %s.", e.RetryAfter.UTC().Format(time.RFC3339))
+	}
+	if e.Hint != "" {
+		fmt.Fprintf(&b, " %s", e.Hint)
+	}
+	return b.String()
+}
+
+// result returns the error as a tool error result.
+func (e *ToolError) result() *mcp.CallToolResult {
+	result := mcp.NewToolResultError(e.text())
+	result.Meta = map[string]any{"error": e}
+	return result
+}
+
+// toolErrorResult classifies err and returns it as a tool error result.
+func toolErrorResult(err error) *mcp.CallToolResult {
+	return classifyError(err).result()
+}
+
+// responseError returns the error of a response with an unexpected status, parsed from its body.
+func responseError(resp *github.Response) error {
+	if err := github.CheckResponse(resp.Response); err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// responseErrorResult returns the tool error result of a response with an unexpected status, prefixed with
+// message.
+func responseErrorResult(resp *github.Response, message string) *mcp.CallToolResult {
+	return toolErrorResult(fmt.Errorf("%s: %w", message, responseError(resp)))
+}
+
+var (
+	// gqlStatusPattern matches the status of failed GraphQL requests, as reported by the GraphQL client.
+	gqlStatusPattern = regexp.MustCompile(`non-200 OK status code: (\d{3})`)
+
+	// gqlErrorPatterns classify the messages of GraphQL errors, which carry no status.
+	gqlErrorPatterns = []struct {
+		pattern *regexp.Regexp
+		code    ErrorCode
+	}{
+		{regexp.MustCompile(`(?i)could not resolve to|not found`), ErrorNotFound},
+		{regexp.MustCompile(`(?i)resource not accessible|must have .*(permission|access)|insufficient scopes|forbidden`), ErrorPermissionDenied},
+		{regexp.MustCompile(`(?i)rate limit`), ErrorRateLimited},
+		{regexp.MustCompile(`(?i)was modified|is out of date|already exists|not mergeable|conflict`), ErrorConflict},
+		{regexp.MustCompile(`(?i)timeout|timed out|something went wrong`), ErrorUpstreamUnavailable},
+	}
+)
+
+// classifyError returns err as a ToolError, classified from the REST error, GraphQL error or network
+// failure it wraps. The message of REST errors is reduced to the one GitHub returned, without the request.
+func classifyError(err error) *ToolError {
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) {
+		classified := *toolErr
+		classified.Message = err.Error()
+		return &classified
+	}
+
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		toolErr = newToolError(ErrorRateLimited, errorMessage(err, rateLimitErr, rateLimitErr.Message))
+		toolErr.Status = statusOf(rateLimitErr.Response)
+		if !rateLimitErr.Rate.Reset.IsZero() {
+			toolErr.RetryAfter = &rateLimitErr.Rate.Reset.Time
+		}
+		return toolErr
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		toolErr = newToolError(ErrorRateLimited, errorMessage(err, abuseErr, abuseErr.Message))
+		toolErr.Status = statusOf(abuseErr.Response)
+		if abuseErr.RetryAfter != nil {
+			at := time.Now().Add(*abuseErr.RetryAfter)
+			toolErr.RetryAfter = &at
+		}
+		return toolErr
+	}
+
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) {
+		status := statusOf(errResp.Response)
+		toolErr = newToolError(statusCode(status), errorMessage(err, errResp, errResp.Message))
+		toolErr.Status = status
+		for _, e := range errResp.Errors {
+			if e.Message != "" {
+				toolErr.Details = append(toolErr.Details, e.Message)
+			} else {
+				toolErr.Details = append(toolErr.Details, e.Error())
+			}
+		}
+		if toolErr.Code == ErrorRateLimited && errResp.Response != nil {
+			if seconds, err := strconv.Atoi(errResp.Response.Header.Get("Retry-After")); err == nil {
+				at := time.Now().Add(time.Duration(seconds) * time.Second)
+				toolErr.RetryAfter = &at
+			}
+		}
+		return toolErr
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return newToolError(ErrorUpstreamUnavailable, err.Error())
+	}
+
+	message := err.Error()
+	if match := gqlStatusPattern.FindStringSubmatch(message); match != nil {
+		status, _ := strconv.Atoi(match[1])
+		toolErr = newToolError(statusCode(status), message)
+		toolErr.Status = status
+		return toolErr
+	}
+	for _, p := range gqlErrorPatterns {
+		if p.pattern.MatchString(message) {
+			return newToolError(p.code, message)
+		}
+	}
+	return newToolError(ErrorInternal, message)
+}
+
+// statusCode returns the code of errors answered with the given HTTP status.
+func statusCode(status int) ErrorCode {
+	switch {
+	case status == http.StatusNotFound || status == http.StatusGone:
+		return ErrorNotFound
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return ErrorPermissionDenied
+	case status == http.StatusBadRequest || status == http.StatusUnprocessableEntity:
+		return ErrorValidationFailed
+	case status == http.StatusTooManyRequests:
+		return ErrorRateLimited
+	case status == http.StatusConflict || status == http.StatusPreconditionFailed || status == http.StatusMethodNotAllowed:
+		return ErrorConflict
+	case status >= 500:
+		return ErrorUpstreamUnavailable
+	default:
+		return ErrorInternal
+	}
+}
+
+// statusOf returns the status of resp, or zero when there is no response.
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// errorMessage returns the message of err, which wraps the REST error apiErr, with the error GitHub
+// returned in place of apiErr. The request and status apiErr describes are left out, as the code tells
+// more and URLs only take room.
+func errorMessage(err error, apiErr error, message string) string {
+	if message == "" {
+		message = "GitHub returned an error"
+	}
+	if resp := apiErrResponse(apiErr); resp == nil || resp.Request == nil {
+		// REST errors without a request can't be printed.
+		return message
+	}
+	prefix, ok := strings.CutSuffix(err.Error(), apiErr.Error())
+	if !ok {
+		return message
+	}
+	return prefix + message
+}
+
+// apiErrResponse returns the HTTP response of a REST error.
+func apiErrResponse(err error) *http.Response {
+	switch err := err.(type) {
+	case *github.ErrorResponse:
+		return err.Response
+	case *github.RateLimitError:
+		return err.Response
+	case *github.AbuseRateLimitError:
+		return err.Response
+	}
+	return nil
+}
+
+// withToolErrors is a middleware returning the errors of tool handlers as classified tool error results,
+// which the model can act on, rather than as protocol errors. Error results handlers return themselves are
+// failures to validate the arguments, unless they are already classified.
+func withToolErrors(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil {
+			return toolErrorResult(err), nil
+		}
+		if result == nil || !result.IsError {
+			return result, nil
+		}
+		if _, ok := result.Meta["error"]; ok {
+			return result, nil
+		}
+		var message []string
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				message = append(message, text.Text)
+			}
+		}
+		toolErr := newToolError(ErrorValidationFailed, strings.Join(message, "\n"))
+		if result.Meta == nil {
+			result.Meta = make(map[string]any)
+		}
+		result.Meta["error"] = toolErr
+		return result, nil
+	}
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorHTTPResponse returns the response to a request to GitHub answered with status.
+func errorHTTPResponse(status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "api.github.com", Path: "/repos/owner/repo"}},
+	}
+}
+
+func Test_ClassifyError(t *testing.T) {
+	reset := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	retryAfter := time.Minute
+
+	tests := []struct {
+		name       string
+		err        error
+		expected   ToolError
+		retryAfter bool
+	}{
+		{
+			name: "not found",
+			err:  fmt.Errorf("failed to get repository: %w", &github.ErrorResponse{Response: errorHTTPResponse(404), Message: "Not Found"}),
+			expected: ToolError{
+				Code:    ErrorNotFound,
+				Message: "failed to get repository: Not Found",
+				Status:  404,
+			},
+		},
+		{
+			name: "permission denied",
+			err:  fmt.Errorf("failed to update repository: %w", &github.ErrorResponse{Response: errorHTTPResponse(403), Message: "Resource not accessible by integration"}),
+			expected: ToolError{
+				Code:    ErrorPermissionDenied,
+				Message: "failed to update repository: Resource not accessible by integration",
+				Status:  403,
+			},
+		},
+		{
+			name: "validation failed",
+			err: fmt.Errorf("failed to create issue: %w", &github.ErrorResponse{
+				Response: errorHTTPResponse(422),
+				Message:  "Validation Failed",
+				Errors: []github.Error{
+					{Resource: "Issue", Field: "title", Code: "missing_field"},
+					{Code: "custom", Message: "labels must be strings"},
+				},
+			}),
+			expected: ToolError{
+				Code:    ErrorValidationFailed,
+				Message: "failed to create issue: Validation Failed",
+				Status:  422,
+				Details: []string{"missing_field error caused by title field on Issue resource", "labels must be strings"},
+			},
+		},
+		{
+			name: "conflict",
+			err:  fmt.Errorf("failed to merge pull request: %w", &github.ErrorResponse{Response: errorHTTPResponse(409), Message: "Head branch was modified"}),
+			expected: ToolError{
+				Code:    ErrorConflict,
+				Message: "failed to merge pull request: Head branch was modified",
+				Status:  409,
+			},
+		},
+		{
+			name: "upstream unavailable",
+			err:  fmt.Errorf("failed to list commits: %w", &github.ErrorResponse{Response: errorHTTPResponse(502)}),
+			expected: ToolError{
+				Code:    ErrorUpstreamUnavailable,
+				Message: "failed to list commits: GitHub returned an error",
+				Status:  502,
+			},
+		},
+		{
+			name: "rate limited",
+			err: fmt.Errorf("failed to search code: %w", &github.RateLimitError{
+				Response: errorHTTPResponse(403),
+				Message:  "API rate limit exceeded",
+				Rate:     github.Rate{Reset: github.Timestamp{Time: reset}},
+			}),
+			expected: ToolError{
+				Code:       ErrorRateLimited,
+				Message:    "failed to search code: API rate limit exceeded",
+				Status:     403,
+				RetryAfter: &reset,
+			},
+		},
+		{
+			name: "secondary rate limited",
+			err: fmt.Errorf("failed to create issue: %w", &github.AbuseRateLimitError{
+				Response:   errorHTTPResponse(403),
+				Message:    "You have exceeded a secondary rate limit",
+				RetryAfter: &retryAfter,
+			}),
+			expected: ToolError{
+				Code:    ErrorRateLimited,
+				Message: "failed to create issue: You have exceeded a secondary rate limit",
+				Status:  403,
+			},
+			retryAfter: true,
+		},
+		{
+			name: "timeout",
+			err:  fmt.Errorf("failed to get commit: %w", context.DeadlineExceeded),
+			expected: ToolError{
+				Code:    ErrorUpstreamUnavailable,
+				Message: "failed to get commit: context deadline exceeded",
+			},
+		},
+		{
+			name: "graphql not found",
+			err:  errors.New("Could not resolve to a Repository with the name 'owner/missing'."),
+			expected: ToolError{
+				Code:    ErrorNotFound,
+				Message: "Could not resolve to a Repository with the name 'owner/missing'.",
+			},
+		},
+		{
+			name: "graphql status",
+			err:  errors.New("non-200 OK status code: 401 Unauthorized body: \"Bad credentials\""),
+			expected: ToolError{
+				Code:    ErrorPermissionDenied,
+				Message: "non-200 OK status code: 401 Unauthorized body: \"Bad credentials\"",
+				Status:  401,
+			},
+		},
+		{
+			name: "classified",
+			err:  fmt.Errorf("failed to add suggestion: %w", newToolError(ErrorNotFound, "no pending review found for the viewer")),
+			expected: ToolError{
+				Code:    ErrorNotFound,
+				Message: "failed to add suggestion: no pending review found for the viewer",
+			},
+		},
+		{
+			name: "internal",
+			err:  errors.New("failed to marshal issue"),
+			expected: ToolError{
+				Code:    ErrorInternal,
+				Message: "failed to marshal issue",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			toolErr := classifyError(tc.err)
+			if tc.retryAfter {
+				require.NotNil(t, toolErr.RetryAfter)
+				assert.WithinDuration(t, time.Now().Add(retryAfter), *toolErr.RetryAfter, 5*time.Second)
+				toolErr.RetryAfter = nil
+			}
+			tc.expected.Hint = errorHints[tc.expected.Code]
+			assert.Equal(t, &tc.expected, toolErr)
+		})
+	}
+}
+
+func Test_ResponseErrorResult(t *testing.T) {
+	httpResp := errorHTTPResponse(http.StatusNotFound)
+	httpResp.Body = io.NopCloser(strings.NewReader(`{"message":"Branch not found"}`))
+
+	result := responseErrorResult(&github.Response{Response: httpResp}, "failed to get branch")
+	require.True(t, result.IsError)
+	assert.Equal(t, "failed to get branch: Branch not found\nError code: not_found. "+errorHints[ErrorNotFound], getTextResult(t, result).Text)
+	toolErr, ok := result.Meta["error"].(*ToolError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorNotFound, toolErr.Code)
+	assert.Equal(t, http.StatusNotFound, toolErr.Status)
+
+	// Unexpected successful statuses have no error to parse.
+	result = responseErrorResult(&github.Response{Response: &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: http.NoBody}}, "failed to create branch")
+	toolErr, ok = result.Meta["error"].(*ToolError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorInternal, toolErr.Code)
+	assert.Equal(t, "failed to create branch: unexpected status 200 OK", toolErr.Message)
+}
+
+func Test_WithToolErrors(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+		),
+	))
+	s := NewServer("test")
+	s.AddTool(GetIssue(stubGetClientFn(client), translations.NullTranslationHelper))
+	s.AddTool(mcp.NewTool("invalid_tool"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError("missing required parameter: owner"), nil
+	})
+
+	callTool := func(params string) mcp.CallToolResult {
+		t.Helper()
+		response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":`+params+`}`))
+		require.IsType(t, mcp.JSONRPCResponse{}, response)
+		result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		return result
+	}
+
+	// Errors returned by handlers are classified tool errors rather than protocol errors.
+	result := callTool(`{"name":"get_issue","arguments":{"owner":"owner","repo":"repo","issue_number":42}}`)
+	require.True(t, result.IsError)
+	assert.Equal(t, "failed to get issue: Not Found\nError code: not_found. "+errorHints[ErrorNotFound], result.Content[0].(mcp.TextContent).Text)
+	toolErr, ok := result.Meta["error"].(*ToolError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorNotFound, toolErr.Code)
+	assert.Equal(t, http.StatusNotFound, toolErr.Status)
+
+	// Error results of handlers keep their text and are validation errors.
+	result = callTool(`{"name":"invalid_tool"}`)
+	require.True(t, result.IsError)
+	assert.Equal(t, "missing required parameter: owner", result.Content[0].(mcp.TextContent).Text)
+	toolErr, ok = result.Meta["error"].(*ToolError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorValidationFailed, toolErr.Code)
+	assert.Equal(t, "missing required parameter: owner", toolErr.Message)
+}
@@ -0,0 +1,227 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// projectResult returns v reduced to the fields selected by the "fields" parameter of the request,
+// or v as is when no fields are selected. Selected fields that don't exist in the type of v are an error.
+//
+// Only the text content of results is projected. Structured content must match the output schema of the
+// tool, which requires its non-optional fields, so it always has all of them.
+func projectResult(request mcp.CallToolRequest, v any) (any, error) {
+	fields, err := OptionalFieldsParam(request)
+	if err != nil {
+		return nil, err
+	}
+	return projectFields(v, fields)
+}
+
+// projectedTextResult is like structuredTextResult, with the text reduced to the fields selected by the
+// "fields" parameter of the request.
+func projectedTextResult(request mcp.CallToolRequest, v any) *mcp.CallToolResult {
+	projected, err := projectResult(request, v)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	result := MarshalledTextResult(projected)
+	if result.IsError {
+		return result
+	}
+	return structuredResult(result, v)
+}
+
+// projectedListResult returns the list v as JSON text reduced to the fields selected by the "fields" parameter of
+// the request, noting the cursor to the following items if any, with the given structured content.
+func projectedListResult(request mcp.CallToolRequest, v any, structured any, next string) *mcp.CallToolResult {
+	projected, err := projectResult(request, v)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+	result := MarshalledTextResult(projected)
+	if result.IsError {
+		return result
+	}
+	return structuredResult(withNextCursor(result, next), structured)
+}
+
+// projectFields returns v reduced to the fields at the given dot-paths, after checking that they exist
+// in the type of v.
+func projectFields(v any, fields []string) (any, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	tree := fieldTree{}
+	for _, path := range fields {
+		if err := checkFieldPath(reflect.TypeOf(v), path); err != nil {
+			return nil, err
+		}
+		tree.add(path)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return tree.project(decoded), nil
+}
+
+// fieldTree holds selected fields by name. A nil subtree selects the whole field.
+type fieldTree map[string]fieldTree
+
+func (tree fieldTree) add(path string) {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		sub, ok := tree[name]
+		if ok && sub == nil {
+			// The whole field is already selected.
+			return
+		}
+		if !ok {
+			sub = fieldTree{}
+			tree[name] = sub
+		}
+		tree = sub
+	}
+	tree[names[len(names)-1]] = nil
+}
+
+// project returns the selected fields of v, which was decoded from JSON. Lists are projected item by item.
+func (tree fieldTree) project(v any) any {
+	switch v := v.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = tree.project(item)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(tree))
+		for name, sub := range tree {
+			field, ok := v[name]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				out[name] = field
+			} else {
+				out[name] = sub.project(field)
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// checkFieldPath checks that the dot-path names a JSON field of t, or of the items of t when it is a list.
+// Fields of maps and interfaces can't be checked and are accepted. The name of the items of a list of strings
+// is accepted too, as compact results flatten lists of objects, such as labels, to the names of the objects.
+func checkFieldPath(t reflect.Type, path string) error {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		parent := strings.Join(names[:i], ".")
+		list := false
+		for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			list = list || t.Kind() != reflect.Pointer
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() == reflect.Map || t.Kind() == reflect.Interface {
+			return nil
+		}
+		if list && t.Kind() == reflect.String && name == "name" && i == len(names)-1 {
+			return nil
+		}
+		if t.Kind() != reflect.Struct || t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+			return fmt.Errorf("invalid field %q: %q has no fields", path, parent)
+		}
+
+		fields := jsonFields(t)
+		field, ok := fields[name]
+		if !ok {
+			valid := make([]string, 0, len(fields))
+			for n := range fields {
+				valid = append(valid, n)
+			}
+			sort.Strings(valid)
+			if parent == "" {
+				return fmt.Errorf("unknown field %q, valid fields are: %s", path, strings.Join(valid, ", "))
+			}
+			return fmt.Errorf("unknown field %q, valid fields of %q are: %s", path, parent, strings.Join(valid, ", "))
+		}
+		t = field
+	}
+	return nil
+}
+
+// jsonFields returns the types of the fields of struct type t by their JSON names, including the fields
+// of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for _, f := range structJSONFields(t) {
+		fields[f.name] = f.typ
+	}
+	return fields
+}
+
+// jsonField is a field of a struct as encoded in JSON.
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// structJSONFields returns the fields of struct type t as encoded in JSON, followed by the fields of its
+// embedded structs that aren't shadowed by fields of t.
+func structJSONFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	names := make(map[string]bool)
+	var embedded []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && f.Anonymous {
+			et := f.Type
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct {
+				embedded = append(embedded, structJSONFields(et)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name: name, typ: f.Type, omitEmpty: strings.Contains(","+opts+",", ",omitempty,")})
+		names[name] = true
+	}
+	for _, f := range embedded {
+		if !names[f.name] {
+			fields = append(fields, f)
+			names[f.name] = true
+		}
+	}
+	return fields
+}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProjectFields(t *testing.T) {
+	issues := []*github.Issue{
+		{
+			Number: github.Ptr(1),
+			Title:  github.Ptr("First issue"),
+			State:  github.Ptr("open"),
+			User:   &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1))},
+			Labels: []*github.Label{
+				{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a")},
+				{Name: github.Ptr("help wanted"), Color: github.Ptr("008672")},
+			},
+		},
+		{
+			Number: github.Ptr(2),
+			Title:  github.Ptr("Second issue"),
+			State:  github.Ptr("closed"),
+		},
+	}
+
+	tests := []struct {
+		name          string
+		value         any
+		fields        []string
+		expectedJSON  string
+		expectedError string
+	}{
+		{
+			name:         "fields of list items",
+			value:        issues,
+			fields:       []string{"number", "title", "user.login", "labels.name"},
+			expectedJSON: `[{"number":1,"title":"First issue","user":{"login":"octocat"},"labels":[{"name":"bug"},{"name":"help wanted"}]},{"number":2,"title":"Second issue"}]`,
+		},
+		{
+			name:         "whole field wins over its subfields",
+			value:        issues[0],
+			fields:       []string{"user.login", "user"},
+			expectedJSON: `{"user":{"login":"octocat","id":1}}`,
+		},
+		{
+			name:         "fields of a result object",
+			value:        &github.IssuesSearchResult{Total: github.Ptr(2), Issues: issues},
+			fields:       []string{"total_count", "items.number"},
+			expectedJSON: `{"total_count":2,"items":[{"number":1},{"number":2}]}`,
+		},
+		{
+			name:         "fields of a compact object",
+			value:        cleanIssue(issues[0]),
+			fields:       []string{"labels", "user.login"},
+			expectedJSON: `{"labels":["bug","help wanted"],"user":{"login":"octocat"}}`,
+		},
+		{
+			name:         "names of compact labels",
+			value:        cleanIssues(issues),
+			fields:       []string{"number", "labels.name"},
+			expectedJSON: `[{"number":1,"labels":["bug","help wanted"]},{"number":2}]`,
+		},
+		{
+			name:          "subfield of compact labels",
+			value:         cleanIssue(issues[0]),
+			fields:        []string{"labels.color"},
+			expectedError: `invalid field "labels.color": "labels" has no fields`,
+		},
+		{
+			name:          "unknown field",
+			value:         issues,
+			fields:        []string{"titel"},
+			expectedError: `unknown field "titel", valid fields are: `,
+		},
+		{
+			name:          "unknown subfield",
+			value:         issues,
+			fields:        []string{"user.logn"},
+			expectedError: `unknown field "user.logn", valid fields of "user" are: `,
+		},
+		{
+			name:          "subfield of a scalar",
+			value:         issues,
+			fields:        []string{"title.text"},
+			expectedError: `invalid field "title.text": "title" has no fields`,
+		},
+		{
+			name:          "subfield of a timestamp",
+			value:         issues,
+			fields:        []string{"created_at.year"},
+			expectedError: `invalid field "created_at.year": "created_at" has no fields`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			projected, err := projectFields(tc.value, tc.fields)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := json.Marshal(projected)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expectedJSON, string(data))
+		})
+	}
+}
+
+func Test_ProjectFields_ListsValidFields(t *testing.T) {
+	_, err := projectFields(&github.User{}, []string{"name.first"})
+	require.EqualError(t, err, `invalid field "name.first": "name" has no fields`)
+
+	_, err = projectFields(&CleanedUser{}, []string{"name"})
+	require.EqualError(t, err, `unknown field "name", valid fields are: id, login, type`)
+}
+
+func Test_GetIssue_Fields(t *testing.T) {
+	mockIssue := &github.Issue{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Test Issue"),
+		Body:    github.Ptr("This is a test issue"),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:    &github.User{Login: github.Ptr("octocat")},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			mockIssue,
+			mockIssue,
+		),
+	))
+	_, handler := GetIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"fields":       "number, title,user.login",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.JSONEq(t, `{"number":42,"title":"Test Issue","user":{"login":"octocat"}}`, getTextResult(t, result).Text)
+
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+		"fields":       "number,titel",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, `unknown field "titel", valid fields are: `)
+}
+
+func Test_ListBranches_Fields(t *testing.T) {
+	mockBranches := []*github.Branch{
+		{Name: github.Ptr("main"), Protected: github.Ptr(true), Commit: &github.RepositoryCommit{SHA: github.Ptr("abc123")}},
+		{Name: github.Ptr("develop"), Protected: github.Ptr(false), Commit: &github.RepositoryCommit{SHA: github.Ptr("def456")}},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetReposBranchesByOwnerByRepo,
+			mockBranches,
+		),
+	))
+	_, handler := ListBranches(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":  "owner",
+		"repo":   "repo",
+		"fields": "name,commit.sha",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.JSONEq(t, `[{"name":"main","commit":{"sha":"abc123"}},{"name":"develop","commit":{"sha":"def456"}}]`, getTextResult(t, result).Text)
+
+	// Structured content keeps all the fields of the output schema.
+	structured, ok := result.StructuredContent.(ListResult[*github.Branch])
+	require.True(t, ok)
+	require.Len(t, structured.Items, 2)
+	assert.True(t, structured.Items[0].GetProtected())
+}
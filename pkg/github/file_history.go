@@ -0,0 +1,411 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// BlameRange is a range of lines of a file that were last changed by the same commit.
+type BlameRange struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+	// Age is how recently the lines were changed relative to the rest of the file, from 1 for the oldest to 10
+	// for the newest.
+	Age    int           `json:"age"`
+	Commit CommitSummary `json:"commit"`
+}
+
+// FileBlame is the blame of a file at a commit.
+type FileBlame struct {
+	Path   string       `json:"path"`
+	SHA    string       `json:"sha"`
+	Ranges []BlameRange `json:"ranges"`
+}
+
+// FileHistoryCommit is a commit that changed a file, along with the path the file had at that commit.
+type FileHistoryCommit struct {
+	CommitSummary
+	Path string `json:"path"`
+}
+
+// FileRename is a commit that renamed a file.
+type FileRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	SHA  string `json:"sha"`
+}
+
+// FileHistoryCursor is where to continue listing the history of a file from. Path and ref change when the
+// history continues from before the file was renamed.
+type FileHistoryCursor struct {
+	Path  string `json:"path"`
+	Ref   string `json:"ref"`
+	After string `json:"after,omitempty"`
+}
+
+// FileHistory is a page of the commits that changed a file.
+type FileHistory struct {
+	Commits []FileHistoryCommit `json:"commits"`
+	Renames []FileRename        `json:"renames,omitempty"`
+	Next    *FileHistoryCursor  `json:"next,omitempty"`
+}
+
+// historyCommitNode is the selection of a commit shared by the blame and history queries.
+type historyCommitNode struct {
+	Oid             githubv4.GitObjectID
+	MessageHeadline githubv4.String
+	CommittedDate   githubv4.DateTime
+	URL             githubv4.URI
+	Author          *struct {
+		Name githubv4.String
+		User *struct {
+			Login githubv4.String
+		}
+	}
+}
+
+// blameCommit is the selection of the commit to blame a file at.
+type blameCommit struct {
+	Oid   githubv4.GitObjectID
+	Blame struct {
+		Ranges []struct {
+			StartingLine githubv4.Int
+			EndingLine   githubv4.Int
+			Age          githubv4.Int
+			Commit       historyCommitNode
+		}
+	} `graphql:"blame(path: $path)"`
+}
+
+// historyCommit is the selection of the commit to list the history of a file from.
+type historyCommit struct {
+	Oid     githubv4.GitObjectID
+	History struct {
+		Nodes    []historyCommitNode
+		PageInfo struct {
+			HasNextPage githubv4.Boolean
+			EndCursor   githubv4.String
+		}
+	} `graphql:"history(first: $first, after: $after, path: $path)"`
+}
+
+func commitSummaryFromNode(node historyCommitNode) CommitSummary {
+	commit := CommitSummary{
+		SHA:     string(node.Oid),
+		Message: string(node.MessageHeadline),
+		Date:    node.CommittedDate.Format(time.RFC3339),
+	}
+	if node.URL.URL != nil {
+		commit.URL = node.URL.String()
+	}
+	if node.Author != nil {
+		commit.Author = string(node.Author.Name)
+		if node.Author.User != nil {
+			commit.Author = string(node.Author.User.Login)
+		}
+	}
+	return commit
+}
+
+// GetFileBlame creates a tool to get the blame of a file.
+func GetFileBlame(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_blame",
+			mcp.WithDescription(t("TOOL_GET_FILE_BLAME_DESCRIPTION", "Get the blame of a file in a GitHub repository: for each range of lines, the commit that last changed them with its author, date and message. Useful to find out when and why lines changed, for example when investigating a regression.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_BLAME_USER_TITLE", "Get file blame"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to blame the file at. Defaults to the default branch"),
+			),
+			mcp.WithNumber("startLine",
+				mcp.Description("Only return ranges that include lines from this line on"),
+			),
+			mcp.WithNumber("endLine",
+				mcp.Description("Only return ranges that include lines up to this line"),
+			),
+			WithOutputSchema[FileBlame](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner     string
+				Repo      string
+				Path      string
+				Ref       string
+				StartLine int
+				EndLine   int
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Ref == "" {
+				params.Ref = "HEAD"
+			}
+			if params.StartLine < 0 || params.EndLine < 0 || (params.EndLine > 0 && params.StartLine > params.EndLine) {
+				return mcp.NewToolResultError("startLine and endLine must be positive line numbers, with startLine no greater than endLine"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// Annotated tags resolve to tag objects, whose target is the commit.
+			var query struct {
+				Repository struct {
+					Object *struct {
+						Commit blameCommit `graphql:"... on Commit"`
+						Tag    struct {
+							Target struct {
+								Commit blameCommit `graphql:"... on Commit"`
+							}
+						} `graphql:"... on Tag"`
+					} `graphql:"object(expression: $ref)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+
+			vars := map[string]any{
+				"owner": githubv4.String(params.Owner),
+				"repo":  githubv4.String(params.Repo),
+				"ref":   githubv4.String(params.Ref),
+				"path":  githubv4.String(params.Path),
+			}
+
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return toolErrorResult(err), nil
+			}
+			if query.Repository.Object == nil {
+				return newToolError(ErrorNotFound, fmt.Sprintf("ref %s does not resolve to a commit", params.Ref)).result(), nil
+			}
+			commit := query.Repository.Object.Commit
+			if commit.Oid == "" {
+				commit = query.Repository.Object.Tag.Target.Commit
+			}
+			if commit.Oid == "" {
+				return newToolError(ErrorNotFound, fmt.Sprintf("ref %s does not resolve to a commit", params.Ref)).result(), nil
+			}
+
+			result := FileBlame{
+				Path:   params.Path,
+				SHA:    string(commit.Oid),
+				Ranges: []BlameRange{},
+			}
+			for _, r := range commit.Blame.Ranges {
+				start, end := int(r.StartingLine), int(r.EndingLine)
+				if params.StartLine > 0 {
+					if end < params.StartLine {
+						continue
+					}
+					start = max(start, params.StartLine)
+				}
+				if params.EndLine > 0 {
+					if start > params.EndLine {
+						continue
+					}
+					end = min(end, params.EndLine)
+				}
+				result.Ranges = append(result.Ranges, BlameRange{
+					StartLine: start,
+					EndLine:   end,
+					Age:       int(r.Age),
+					Commit:    commitSummaryFromNode(r.Commit),
+				})
+			}
+
+			return structuredTextResult(result), nil
+		}
+}
+
+// GetFileHistory creates a tool to list the commits that changed a file, following renames.
+func GetFileHistory(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_file_history",
+			mcp.WithDescription(t("TOOL_GET_FILE_HISTORY_DESCRIPTION", "List the commits that changed a file in a GitHub repository, newest first. When the start of the history of the file is reached and it was created by renaming another file, the history continues with the previous path. To get the next page, call again with the path, ref and after of the returned next cursor.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_FILE_HISTORY_USER_TITLE", "Get file history"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("Path to the file"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to list the history from. Defaults to the default branch"),
+			),
+			mcp.WithBoolean("followRenames",
+				mcp.Description("Continue with the previous path of the file when it was renamed. Defaults to true"),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Number of commits to return, from 1 to 100. Defaults to 30"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			mcp.WithString("after",
+				mcp.Description("Cursor from the next field of a previous call, to get the next page"),
+			),
+			WithFields(),
+			WithOutputSchema[FileHistory](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			params := struct {
+				Owner         string
+				Repo          string
+				Path          string
+				Ref           string
+				FollowRenames bool
+				PerPage       int32
+				After         string
+			}{
+				FollowRenames: true,
+				PerPage:       30,
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Ref == "" {
+				params.Ref = "HEAD"
+			}
+			if params.PerPage < 1 || params.PerPage > 100 {
+				return mcp.NewToolResultError("perPage must be between 1 and 100"), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			// Annotated tags resolve to tag objects, whose target is the commit.
+			var query struct {
+				Repository struct {
+					Object *struct {
+						Commit historyCommit `graphql:"... on Commit"`
+						Tag    struct {
+							Target struct {
+								Commit historyCommit `graphql:"... on Commit"`
+							}
+						} `graphql:"... on Tag"`
+					} `graphql:"object(expression: $ref)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+
+			result := FileHistory{Commits: []FileHistoryCommit{}}
+			path, ref, after := params.Path, params.Ref, params.After
+			remaining := params.PerPage
+			for {
+				// Start from a fresh result, as the decoder would otherwise append to the nodes of the previous query.
+				query.Repository.Object = nil
+				vars := map[string]any{
+					"owner": githubv4.String(params.Owner),
+					"repo":  githubv4.String(params.Repo),
+					"ref":   githubv4.String(ref),
+					"path":  githubv4.String(path),
+					"first": githubv4.Int(remaining),
+					"after": newGQLStringlike[githubv4.String](after),
+				}
+				if err := gqlClient.Query(ctx, &query, vars); err != nil {
+					return toolErrorResult(err), nil
+				}
+				if query.Repository.Object == nil {
+					return newToolError(ErrorNotFound, fmt.Sprintf("ref %s does not resolve to a commit", ref)).result(), nil
+				}
+				commit := query.Repository.Object.Commit
+				if commit.Oid == "" {
+					commit = query.Repository.Object.Tag.Target.Commit
+				}
+				if commit.Oid == "" {
+					return newToolError(ErrorNotFound, fmt.Sprintf("ref %s does not resolve to a commit", ref)).result(), nil
+				}
+
+				history := commit.History
+				for _, node := range history.Nodes {
+					result.Commits = append(result.Commits, FileHistoryCommit{
+						CommitSummary: commitSummaryFromNode(node),
+						Path:          path,
+					})
+					remaining--
+				}
+				if history.PageInfo.HasNextPage {
+					result.Next = &FileHistoryCursor{Path: path, Ref: ref, After: string(history.PageInfo.EndCursor)}
+					break
+				}
+				if !params.FollowRenames || len(history.Nodes) == 0 {
+					break
+				}
+
+				// The oldest commit touching the path created the file. Check whether it did so by renaming another file.
+				oldest := string(history.Nodes[len(history.Nodes)-1].Oid)
+				previousPath, parent, err := findRenameSource(ctx, getClient, params.Owner, params.Repo, oldest, path)
+				if err != nil {
+					return nil, err
+				}
+				if previousPath == "" {
+					break
+				}
+
+				result.Renames = append(result.Renames, FileRename{From: previousPath, To: path, SHA: oldest})
+				path, ref, after = previousPath, parent, ""
+				if remaining <= 0 {
+					result.Next = &FileHistoryCursor{Path: path, Ref: ref}
+					break
+				}
+			}
+
+			return projectedTextResult(request, result), nil
+		}
+}
+
+// findRenameSource returns the previous path of a file renamed by a commit, along with the first parent of the
+// commit, or an empty path if the commit did not rename the file.
+func findRenameSource(ctx context.Context, getClient GetClientFn, owner, repo, sha, path string) (string, string, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, sha, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get commit: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to get commit: %w", responseError(resp))
+	}
+
+	if len(commit.Parents) == 0 {
+		return "", "", nil
+	}
+	for _, f := range commit.Files {
+		if f.GetFilename() == path && f.GetStatus() == "renamed" {
+			return f.GetPreviousFilename(), commit.Parents[0].GetSHA(), nil
+		}
+	}
+	return "", "", nil
+}
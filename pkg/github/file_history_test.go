@@ -0,0 +1,443 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func historyCommitResponse(sha, message, login string) map[string]any {
+	author := map[string]any{"name": "Display Name", "user": nil}
+	if login != "" {
+		author["user"] = map[string]any{"login": login}
+	}
+	return map[string]any{
+		"oid":             sha,
+		"messageHeadline": message,
+		"committedDate":   "2025-03-04T05:06:07Z",
+		"url":             "https://github.com/owner/repo/commit/" + sha,
+		"author":          author,
+	}
+}
+
+func Test_GetFileBlame(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetFileBlame(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_file_blame", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "startLine")
+	assert.Contains(t, tool.InputSchema.Properties, "endLine")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	blameQuery := struct {
+		Repository struct {
+			Object *struct {
+				Commit blameCommit `graphql:"... on Commit"`
+				Tag    struct {
+					Target struct {
+						Commit blameCommit `graphql:"... on Commit"`
+					}
+				} `graphql:"... on Tag"`
+			} `graphql:"object(expression: $ref)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+
+	blamedCommit := map[string]any{
+		"oid": "head123",
+		"blame": map[string]any{
+			"ranges": []any{
+				map[string]any{"startingLine": 1, "endingLine": 10, "age": 1, "commit": historyCommitResponse("old111", "Initial commit", "octocat")},
+				map[string]any{"startingLine": 11, "endingLine": 12, "age": 10, "commit": historyCommitResponse("new222", "Fix off by one", "")},
+				map[string]any{"startingLine": 13, "endingLine": 40, "age": 1, "commit": historyCommitResponse("old111", "Initial commit", "octocat")},
+			},
+		},
+	}
+	blameResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"object": blamedCommit,
+		},
+	})
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedBlame      FileBlame
+	}{
+		{
+			name: "whole file at the default branch",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(blameQuery, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"ref":   githubv4.String("HEAD"),
+					"path":  githubv4.String("main.go"),
+				}, blameResponse),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+			},
+			expectedBlame: FileBlame{
+				Path: "main.go",
+				SHA:  "head123",
+				Ranges: []BlameRange{
+					{StartLine: 1, EndLine: 10, Age: 1, Commit: CommitSummary{SHA: "old111", Message: "Initial commit", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/old111"}},
+					{StartLine: 11, EndLine: 12, Age: 10, Commit: CommitSummary{SHA: "new222", Message: "Fix off by one", Author: "Display Name", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/new222"}},
+					{StartLine: 13, EndLine: 40, Age: 1, Commit: CommitSummary{SHA: "old111", Message: "Initial commit", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/old111"}},
+				},
+			},
+		},
+		{
+			name: "line range at a ref",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(blameQuery, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"ref":   githubv4.String("v1.2.0"),
+					"path":  githubv4.String("main.go"),
+				}, blameResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"path":      "main.go",
+				"ref":       "v1.2.0",
+				"startLine": float64(12),
+				"endLine":   float64(14),
+			},
+			expectedBlame: FileBlame{
+				Path: "main.go",
+				SHA:  "head123",
+				Ranges: []BlameRange{
+					{StartLine: 12, EndLine: 12, Age: 10, Commit: CommitSummary{SHA: "new222", Message: "Fix off by one", Author: "Display Name", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/new222"}},
+					{StartLine: 13, EndLine: 14, Age: 1, Commit: CommitSummary{SHA: "old111", Message: "Initial commit", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/old111"}},
+				},
+			},
+		},
+		{
+			name: "annotated tag",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(blameQuery, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"ref":   githubv4.String("v1.3.0"),
+					"path":  githubv4.String("main.go"),
+				}, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"object": map[string]any{"target": blamedCommit},
+					},
+				})),
+			),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"path":      "main.go",
+				"ref":       "v1.3.0",
+				"startLine": float64(11),
+				"endLine":   float64(12),
+			},
+			expectedBlame: FileBlame{
+				Path: "main.go",
+				SHA:  "head123",
+				Ranges: []BlameRange{
+					{StartLine: 11, EndLine: 12, Age: 10, Commit: CommitSummary{SHA: "new222", Message: "Fix off by one", Author: "Display Name", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/new222"}},
+				},
+			},
+		},
+		{
+			name: "unknown ref",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(blameQuery, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"ref":   githubv4.String("missing"),
+					"path":  githubv4.String("main.go"),
+				}, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{"object": nil},
+				})),
+			),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "main.go",
+				"ref":   "missing",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "ref missing does not resolve to a commit",
+		},
+		{
+			name:         "inverted line range",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"path":      "main.go",
+				"startLine": float64(20),
+				"endLine":   float64(10),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "startLine and endLine must be positive line numbers",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetFileBlame(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var blame FileBlame
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &blame))
+			assert.Equal(t, tc.expectedBlame, blame)
+		})
+	}
+}
+
+func Test_GetFileHistory(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	tool, _ := GetFileHistory(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_file_history", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "followRenames")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "after")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path"})
+
+	historyQuery := struct {
+		Repository struct {
+			Object *struct {
+				Commit historyCommit `graphql:"... on Commit"`
+				Tag    struct {
+					Target struct {
+						Commit historyCommit `graphql:"... on Commit"`
+					}
+				} `graphql:"... on Tag"`
+			} `graphql:"object(expression: $ref)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+
+	historyVars := func(ref, path string, first int32, after string) map[string]any {
+		vars := map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"ref":   githubv4.String(ref),
+			"path":  githubv4.String(path),
+			"first": githubv4.Int(first),
+			"after": (*githubv4.String)(nil),
+		}
+		if after != "" {
+			vars["after"] = githubv4mock.Ptr(githubv4.String(after))
+		}
+		return vars
+	}
+
+	historyObject := func(hasNextPage bool, endCursor string, commits ...map[string]any) map[string]any {
+		nodes := make([]any, 0, len(commits))
+		for _, c := range commits {
+			nodes = append(nodes, c)
+		}
+		return map[string]any{
+			"oid": "head123",
+			"history": map[string]any{
+				"nodes":    nodes,
+				"pageInfo": map[string]any{"hasNextPage": hasNextPage, "endCursor": endCursor},
+			},
+		}
+	}
+	historyResponse := func(hasNextPage bool, endCursor string, commits ...map[string]any) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"object": historyObject(hasNextPage, endCursor, commits...),
+			},
+		})
+	}
+
+	renameCommit := &github.RepositoryCommit{
+		SHA:     github.Ptr("ccc333"),
+		Parents: []*github.Commit{{SHA: github.Ptr("ddd444")}},
+		Files: []*github.CommitFile{
+			{Filename: github.Ptr("pkg/server.go"), PreviousFilename: github.Ptr("server.go"), Status: github.Ptr("renamed")},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		restClient         *http.Client
+		gqlClient          *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedHistory    FileHistory
+	}{
+		{
+			name: "follows a rename",
+			restClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/ccc333").andThen(
+						mockResponse(t, http.StatusOK, renameCommit),
+					),
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(historyQuery, historyVars("main", "pkg/server.go", 3, ""),
+					historyResponse(false, "Y3Vyc29yOjI=",
+						historyCommitResponse("bbb222", "Handle shutdown", "octocat"),
+						historyCommitResponse("ccc333", "Move server into pkg", "octocat"),
+					),
+				),
+				githubv4mock.NewQueryMatcher(historyQuery, historyVars("ddd444", "server.go", 1, ""),
+					historyResponse(true, "Y3Vyc29yOjE=",
+						historyCommitResponse("eee555", "Add server", "hubot"),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "pkg/server.go",
+				"ref":     "main",
+				"perPage": float64(3),
+			},
+			expectedHistory: FileHistory{
+				Commits: []FileHistoryCommit{
+					{CommitSummary: CommitSummary{SHA: "bbb222", Message: "Handle shutdown", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/bbb222"}, Path: "pkg/server.go"},
+					{CommitSummary: CommitSummary{SHA: "ccc333", Message: "Move server into pkg", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/ccc333"}, Path: "pkg/server.go"},
+					{CommitSummary: CommitSummary{SHA: "eee555", Message: "Add server", Author: "hubot", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/eee555"}, Path: "server.go"},
+				},
+				Renames: []FileRename{{From: "server.go", To: "pkg/server.go", SHA: "ccc333"}},
+				Next:    &FileHistoryCursor{Path: "server.go", Ref: "ddd444", After: "Y3Vyc29yOjE="},
+			},
+		},
+		{
+			name:       "continues from a cursor without following renames",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(historyQuery, historyVars("HEAD", "README.md", 30, "Y3Vyc29yOjE="),
+					historyResponse(false, "Y3Vyc29yOjI=",
+						historyCommitResponse("fff666", "Initial commit", "octocat"),
+					),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"path":          "README.md",
+				"after":         "Y3Vyc29yOjE=",
+				"followRenames": false,
+			},
+			expectedHistory: FileHistory{
+				Commits: []FileHistoryCommit{
+					{CommitSummary: CommitSummary{SHA: "fff666", Message: "Initial commit", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/fff666"}, Path: "README.md"},
+				},
+			},
+		},
+		{
+			name:       "annotated tag",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(historyQuery, historyVars("v1.3.0", "README.md", 30, ""),
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"object": map[string]any{
+								"target": historyObject(false, "Y3Vyc29yOjE=",
+									historyCommitResponse("fff666", "Initial commit", "octocat"),
+								),
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"path":          "README.md",
+				"ref":           "v1.3.0",
+				"followRenames": false,
+			},
+			expectedHistory: FileHistory{
+				Commits: []FileHistoryCommit{
+					{CommitSummary: CommitSummary{SHA: "fff666", Message: "Initial commit", Author: "octocat", Date: "2025-03-04T05:06:07Z", URL: "https://github.com/owner/repo/commit/fff666"}, Path: "README.md"},
+				},
+			},
+		},
+		{
+			name:       "invalid page size",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "README.md",
+				"perPage": float64(500),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "perPage must be between 1 and 100",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			restClient := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := GetFileHistory(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var history FileHistory
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &history))
+			assert.Equal(t, tc.expectedHistory, history)
+		})
+	}
+}
@@ -0,0 +1,558 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// GitTreeEntry is a file, directory or submodule of a git tree.
+type GitTreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	// Type is blob for files, tree for directories and commit for submodules.
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	// Size is only set for blobs.
+	Size int `json:"size,omitempty"`
+}
+
+// GitTree is a listing of a git tree, along with its subtrees when listed recursively.
+type GitTree struct {
+	SHA string `json:"sha"`
+	// Truncated is set when GitHub left entries out of a recursive listing because the tree is too large.
+	// Subtrees can then be listed on their own by their SHA.
+	Truncated bool           `json:"truncated,omitempty"`
+	Entries   []GitTreeEntry `json:"entries"`
+}
+
+// GitBlob is the content of a git blob. Encoding is utf-8 for text and base64 for binary content.
+type GitBlob struct {
+	SHA      string `json:"sha"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GitRef is a git reference and the object it points to.
+type GitRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+	// PreviousSHA is set when the ref was updated or deleted.
+	PreviousSHA string `json:"previous_sha,omitempty"`
+}
+
+// isTextContent reports whether content looks like text rather than binary data.
+func isTextContent(content []byte) bool {
+	return utf8.Valid(content) && !bytes.ContainsRune(content, 0)
+}
+
+// qualifyRef returns the ref in its refs/heads/main form, accepting heads/main as well.
+func qualifyRef(ref string) (string, error) {
+	if !strings.HasPrefix(ref, "refs/") {
+		ref = "refs/" + ref
+	}
+	if strings.Count(ref, "/") < 2 || strings.HasSuffix(ref, "/") {
+		return "", fmt.Errorf("ref must be fully qualified, such as refs/heads/main or refs/tags/v1.0, got %q", ref)
+	}
+	return ref, nil
+}
+
+// zeroOID is the object ID that deletes a ref when it is updated to it.
+const zeroOID = "0000000000000000000000000000000000000000"
+
+// updateRefIfUnchanged points ref to sha, or deletes it when sha is zeroOID, with the updateRefs mutation.
+// GitHub only applies the update if the ref still points to expectedSHA, so commits pushed since it was read
+// are never lost, even to a forced update. It returns an error result when the update was refused.
+func updateRefIfUnchanged(ctx context.Context, client *github.Client, gqlClient *githubv4.Client, owner, repo, ref, expectedSHA, sha string, force bool) (*mcp.CallToolResult, error) {
+	var repoQuery struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+	}
+	if err := gqlClient.Query(ctx, &repoQuery, vars); err != nil {
+		return toolErrorResult(err), nil
+	}
+
+	var mutation struct {
+		UpdateRefs struct {
+			ClientMutationID githubv4.String
+		} `graphql:"updateRefs(input: $input)"`
+	}
+	update := githubv4.RefUpdate{
+		Name:      githubv4.GitRefname(ref),
+		AfterOid:  githubv4.GitObjectID(sha),
+		BeforeOid: newGQLStringlike[githubv4.GitObjectID](expectedSHA),
+	}
+	if force {
+		update.Force = githubv4.NewBoolean(true)
+	}
+	input := githubv4.UpdateRefsInput{
+		RepositoryID: repoQuery.Repository.ID,
+		RefUpdates:   []githubv4.RefUpdate{update},
+	}
+	if err := gqlClient.Mutate(ctx, &mutation, input, nil); err != nil {
+		// Tell the model where the ref points now when it was moved, which is the usual reason for a refusal.
+		current, resp, getErr := client.Git.GetRef(ctx, owner, repo, ref)
+		if getErr == nil {
+			defer func() { _ = resp.Body.Close() }()
+			if current := current.GetObject().GetSHA(); current != expectedSHA {
+				return newToolError(ErrorConflict, fmt.Sprintf("%s points to %s, not the expected %s. It was changed since it was last read", ref, current, expectedSHA)).result(), nil
+			}
+		}
+		return toolErrorResult(fmt.Errorf("failed to update reference: %w", err)), nil
+	}
+	return nil, nil
+}
+
+// GetGitTree creates a tool to list the entries of a git tree.
+func GetGitTree(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_tree",
+			mcp.WithDescription(t("TOOL_GET_GIT_TREE_DESCRIPTION", "List the files and directories of a git tree with their modes, SHAs and sizes. Lists the whole repository at once when recursive, which is much cheaper than walking it with get_file_contents.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_TREE_USER_TITLE", "Get git tree"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag, commit SHA or tree SHA. Defaults to the default branch"),
+			),
+			mcp.WithBoolean("recursive",
+				mcp.Description("List the entries of subtrees as well. Defaults to true"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Only return entries whose path matches this glob, such as *.go or docs/*. Patterns without a slash match the file name in any directory"),
+			),
+			mcp.WithString("type",
+				mcp.Description("Only return entries of this type"),
+				mcp.Enum("blob", "tree", "commit"),
+			),
+			WithOutputSchema[GitTree](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ref == "" {
+				ref = "HEAD"
+			}
+			recursive, ok, err := OptionalParamOK[bool](request, "recursive")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !ok {
+				recursive = true
+			}
+			pattern, err := OptionalParam[string](request, "path")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if pattern != "" {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid path pattern %q: %s", pattern, err)), nil
+				}
+			}
+			entryType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, recursive)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get tree: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get tree"), nil
+			}
+
+			result := GitTree{
+				SHA:       tree.GetSHA(),
+				Truncated: tree.GetTruncated(),
+				Entries:   []GitTreeEntry{},
+			}
+			for _, e := range tree.Entries {
+				if entryType != "" && e.GetType() != entryType {
+					continue
+				}
+				if pattern != "" {
+					if match, _ := matchPathGlob(pattern, e.GetPath()); !match {
+						continue
+					}
+				}
+				result.Entries = append(result.Entries, GitTreeEntry{
+					Path: e.GetPath(),
+					Mode: e.GetMode(),
+					Type: e.GetType(),
+					SHA:  e.GetSHA(),
+					Size: e.GetSize(),
+				})
+			}
+
+			return structuredTextResult(result), nil
+		}
+}
+
+// GetGitBlob creates a tool to get the content of a git blob by its SHA.
+func GetGitBlob(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_git_blob",
+			mcp.WithDescription(t("TOOL_GET_GIT_BLOB_DESCRIPTION", "Get the content of a git blob by its SHA, as listed by get_git_tree. Text is returned as is and binary content base64 encoded.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_GIT_BLOB_USER_TITLE", "Get git blob"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("Blob SHA"),
+			),
+			WithOutputSchema[GitBlob](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			blob, resp, err := client.Git.GetBlob(ctx, owner, repo, sha)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get blob: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get blob"), nil
+			}
+
+			result := GitBlob{
+				SHA:      blob.GetSHA(),
+				Size:     blob.GetSize(),
+				Encoding: blob.GetEncoding(),
+				Content:  blob.GetContent(),
+			}
+			if result.Encoding == "base64" {
+				// GitHub wraps base64 content at 60 characters.
+				content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(result.Content, "\n", ""))
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode blob content: %w", err)
+				}
+				if isTextContent(content) {
+					result.Encoding = "utf-8"
+					result.Content = string(content)
+				} else {
+					result.Content = base64.StdEncoding.EncodeToString(content)
+				}
+			}
+
+			return structuredTextResult(result), nil
+		}
+}
+
+// CreateGitRef creates a tool to create a git reference.
+func CreateGitRef(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_git_ref",
+			mcp.WithDescription(t("TOOL_CREATE_GIT_REF_DESCRIPTION", "Create a git reference, such as a branch or a lightweight tag, pointing to a commit")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_GIT_REF_USER_TITLE", "Create git ref"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Fully qualified name of the ref, such as refs/heads/feature or refs/tags/v1.0"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA the ref points to"),
+			),
+			WithOutputSchema[GitRef](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err = qualifyRef(ref)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			created, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(ref),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				return responseErrorResult(resp, "failed to create reference"), nil
+			}
+
+			return structuredTextResult(GitRef{
+				Ref: created.GetRef(),
+				SHA: created.GetObject().GetSHA(),
+			}), nil
+		}
+}
+
+// UpdateGitRef creates a tool to move a git reference to another commit.
+func UpdateGitRef(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_git_ref",
+			mcp.WithDescription(t("TOOL_UPDATE_GIT_REF_DESCRIPTION", "Point a git reference, such as a branch, to another commit. Only fast-forward updates are allowed unless force is set, which requires the SHA the ref is expected to point to. With an expected SHA, the update is only applied if the ref still points to it, so that commits pushed in the meantime are not lost.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_GIT_REF_USER_TITLE", "Update git ref"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Fully qualified name of the ref, such as refs/heads/feature"),
+			),
+			mcp.WithString("sha",
+				mcp.Required(),
+				mcp.Description("SHA to point the ref to"),
+			),
+			mcp.WithBoolean("force",
+				mcp.Description("Allow updates that are not fast-forwards, discarding commits. Requires expectedSha"),
+			),
+			mcp.WithString("expectedSha",
+				mcp.Description("SHA the ref currently points to. The update is refused if the ref was moved"),
+			),
+			WithOutputSchema[GitRef](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err = qualifyRef(ref)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sha, err := requiredParam[string](request, "sha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			force, err := OptionalParam[bool](request, "force")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedSHA, err := OptionalParam[string](request, "expectedSha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if force && expectedSHA == "" {
+				return mcp.NewToolResultError("expectedSha is required for forced updates"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if expectedSHA != "" {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				result, err := updateRefIfUnchanged(ctx, client, gqlClient, owner, repo, ref, expectedSHA, sha, force)
+				if result != nil || err != nil {
+					return result, err
+				}
+				return structuredTextResult(GitRef{Ref: ref, SHA: sha, PreviousSHA: expectedSHA}), nil
+			}
+
+			updated, resp, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+				Ref:    github.Ptr(ref),
+				Object: &github.GitObject{SHA: github.Ptr(sha)},
+			}, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to update reference"), nil
+			}
+
+			return structuredTextResult(GitRef{
+				Ref: updated.GetRef(),
+				SHA: updated.GetObject().GetSHA(),
+			}), nil
+		}
+}
+
+// DeleteGitRef creates a tool to delete a git reference.
+func DeleteGitRef(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_git_ref",
+			mcp.WithDescription(t("TOOL_DELETE_GIT_REF_DESCRIPTION", "Delete a git reference, such as a branch or a tag")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_GIT_REF_USER_TITLE", "Delete git ref"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("ref",
+				mcp.Required(),
+				mcp.Description("Fully qualified name of the ref, such as refs/heads/feature"),
+			),
+			mcp.WithString("expectedSha",
+				mcp.Description("SHA the ref currently points to. The ref is not deleted if it was moved"),
+			),
+			WithOutputSchema[GitRef](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := requiredParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err = qualifyRef(ref)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			expectedSHA, err := OptionalParam[string](request, "expectedSha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if expectedSHA != "" {
+				gqlClient, err := getGQLClient(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+				}
+				result, err := updateRefIfUnchanged(ctx, client, gqlClient, owner, repo, ref, expectedSHA, zeroOID, false)
+				if result != nil || err != nil {
+					return result, err
+				}
+				return structuredTextResult(GitRef{Ref: ref, PreviousSHA: expectedSHA}), nil
+			}
+
+			resp, err := client.Git.DeleteRef(ctx, owner, repo, ref)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete reference: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return responseErrorResult(resp, "failed to delete reference"), nil
+			}
+
+			return structuredTextResult(GitRef{Ref: ref}), nil
+		}
+}
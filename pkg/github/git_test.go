@@ -0,0 +1,688 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetGitTree(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitTree(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_git_tree", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "recursive")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockTree := &github.Tree{
+		SHA: github.Ptr("tree123"),
+		Entries: []*github.TreeEntry{
+			{Path: github.Ptr("README.md"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("blob1"), Size: github.Ptr(120)},
+			{Path: github.Ptr("cmd"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("tree1")},
+			{Path: github.Ptr("cmd/main.go"), Mode: github.Ptr("100644"), Type: github.Ptr("blob"), SHA: github.Ptr("blob2"), Size: github.Ptr(2048)},
+			{Path: github.Ptr("script/build"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("blob3"), Size: github.Ptr(64)},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedTree    GitTree
+	}{
+		{
+			name: "list the whole repository",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/git/trees/HEAD",
+						queryParams: map[string]string{"recursive": "1"},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectedTree: GitTree{
+				SHA: "tree123",
+				Entries: []GitTreeEntry{
+					{Path: "README.md", Mode: "100644", Type: "blob", SHA: "blob1", Size: 120},
+					{Path: "cmd", Mode: "040000", Type: "tree", SHA: "tree1"},
+					{Path: "cmd/main.go", Mode: "100644", Type: "blob", SHA: "blob2", Size: 2048},
+					{Path: "script/build", Mode: "100755", Type: "blob", SHA: "blob3", Size: 64},
+				},
+			},
+		},
+		{
+			name: "filter entries of a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					expect(t, expectations{
+						path:        "/repos/owner/repo/git/trees/main",
+						queryParams: map[string]string{},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTree),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ref":       "main",
+				"recursive": false,
+				"path":      "cmd/*",
+				"type":      "blob",
+			},
+			expectedTree: GitTree{
+				SHA: "tree123",
+				Entries: []GitTreeEntry{
+					{Path: "cmd/main.go", Mode: "100644", Type: "blob", SHA: "blob2", Size: 2048},
+				},
+			},
+		},
+		{
+			name:         "invalid path pattern",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "[",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "invalid path pattern",
+		},
+		{
+			name: "unknown ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get tree",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitTree(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var tree GitTree
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &tree))
+			assert.Equal(t, tc.expectedTree, tree)
+		})
+	}
+}
+
+func Test_GetGitBlob(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetGitBlob(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_git_blob", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "sha"})
+
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, 0x01}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+		expectedBlob   GitBlob
+	}{
+		{
+			name: "text blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					expectPath(t, "/repos/owner/repo/git/blobs/blob1").andThen(
+						mockResponse(t, http.StatusOK, &github.Blob{
+							SHA:      github.Ptr("blob1"),
+							Size:     github.Ptr(12),
+							Encoding: github.Ptr("base64"),
+							Content:  github.Ptr("SGVsbG8g\nd29ybGQK\n"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "blob1",
+			},
+			expectedBlob: GitBlob{SHA: "blob1", Size: 12, Encoding: "utf-8", Content: "Hello world\n"},
+		},
+		{
+			name: "binary blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					&github.Blob{
+						SHA:      github.Ptr("blob2"),
+						Size:     github.Ptr(len(binary)),
+						Encoding: github.Ptr("base64"),
+						Content:  github.Ptr(base64.StdEncoding.EncodeToString(binary)),
+					},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "blob2",
+			},
+			expectedBlob: GitBlob{SHA: "blob2", Size: len(binary), Encoding: "base64", Content: base64.StdEncoding.EncodeToString(binary)},
+		},
+		{
+			name: "unknown blob",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get blob",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetGitBlob(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var blob GitBlob
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &blob))
+			assert.Equal(t, tc.expectedBlob, blob)
+		})
+	}
+}
+
+func Test_CreateGitRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateGitRef(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_git_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+		expectedRef     GitRef
+	}{
+		{
+			name: "create a tag",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/tags/v1.0",
+						"sha": "abc123",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/tags/v1.0"),
+							Object: &github.GitObject{SHA: github.Ptr("abc123")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "tags/v1.0",
+				"sha":   "abc123",
+			},
+			expectedRef: GitRef{Ref: "refs/tags/v1.0", SHA: "abc123"},
+		},
+		{
+			name:         "unqualified ref",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "feature",
+				"sha":   "abc123",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "ref must be fully qualified",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateGitRef(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var ref GitRef
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ref))
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
+
+// refUpdateMatchers return the mocked repository ID query and updateRefs mutation of updateRefIfUnchanged.
+func refUpdateMatchers(update githubv4.RefUpdate, response githubv4mock.GQLResponse) []githubv4mock.Matcher {
+	var repoQuery struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	var mutation struct {
+		UpdateRefs struct {
+			ClientMutationID githubv4.String
+		} `graphql:"updateRefs(input: $input)"`
+	}
+	return []githubv4mock.Matcher{
+		githubv4mock.NewQueryMatcher(
+			repoQuery,
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{"id": "R_kgDOA"},
+			}),
+		),
+		githubv4mock.NewMutationMatcher(
+			mutation,
+			githubv4.UpdateRefsInput{
+				RepositoryID: githubv4.ID("R_kgDOA"),
+				RefUpdates:   []githubv4.RefUpdate{update},
+			},
+			nil,
+			response,
+		),
+	}
+}
+
+func Test_UpdateGitRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateGitRef(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_git_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "force")
+	assert.Contains(t, tool.InputSchema.Properties, "expectedSha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref", "sha"})
+
+	currentRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("old123")},
+	}
+	updatedRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("new456")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		gqlMatchers     []githubv4mock.Matcher
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+		expectedRef     GitRef
+	}{
+		{
+			name: "fast-forward a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					expectRequestBody(t, map[string]interface{}{
+						"sha":   "new456",
+						"force": false,
+					}).andThen(
+						mockResponse(t, http.StatusOK, updatedRef),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/feature",
+				"sha":   "new456",
+			},
+			expectedRef: GitRef{Ref: "refs/heads/feature", SHA: "new456"},
+		},
+		{
+			name:         "force update with expected sha",
+			mockedClient: mock.NewMockedHTTPClient(),
+			gqlMatchers: refUpdateMatchers(githubv4.RefUpdate{
+				Name:      "refs/heads/feature",
+				AfterOid:  "new456",
+				BeforeOid: githubv4mock.Ptr(githubv4.GitObjectID("old123")),
+				Force:     githubv4.NewBoolean(true),
+			}, githubv4mock.DataResponse(map[string]any{
+				"updateRefs": map[string]any{"clientMutationId": ""},
+			})),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "heads/feature",
+				"sha":         "new456",
+				"force":       true,
+				"expectedSha": "old123",
+			},
+			expectedRef: GitRef{Ref: "refs/heads/feature", SHA: "new456", PreviousSHA: "old123"},
+		},
+		{
+			name: "ref moved since it was read",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, currentRef),
+			),
+			gqlMatchers: refUpdateMatchers(githubv4.RefUpdate{
+				Name:      "refs/heads/feature",
+				AfterOid:  "new456",
+				BeforeOid: githubv4mock.Ptr(githubv4.GitObjectID("stale789")),
+				Force:     githubv4.NewBoolean(true),
+			}, githubv4mock.ErrorResponse("A ref update failed")),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "refs/heads/feature",
+				"sha":         "new456",
+				"force":       true,
+				"expectedSha": "stale789",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "refs/heads/feature points to old123, not the expected stale789",
+		},
+		{
+			name: "update refused for another reason",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, currentRef),
+			),
+			gqlMatchers: refUpdateMatchers(githubv4.RefUpdate{
+				Name:      "refs/heads/feature",
+				AfterOid:  "new456",
+				BeforeOid: githubv4mock.Ptr(githubv4.GitObjectID("old123")),
+			}, githubv4mock.ErrorResponse("Resource not accessible by integration")),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "refs/heads/feature",
+				"sha":         "new456",
+				"expectedSha": "old123",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "Error code: permission_denied.",
+		},
+		{
+			name:         "force without expected sha",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/feature",
+				"sha":   "new456",
+				"force": true,
+			},
+			expectToolError: true,
+			expectedErrMsg:  "expectedSha is required for forced updates",
+		},
+		{
+			name: "not a fast-forward",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Update is not a fast forward"}`),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/feature",
+				"sha":   "new456",
+			},
+			expectError:    true,
+			expectedErrMsg: "Update is not a fast forward",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...))
+			_, handler := UpdateGitRef(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var ref GitRef
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ref))
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
+
+func Test_DeleteGitRef(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteGitRef(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_git_ref", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "expectedSha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "ref"})
+
+	currentRef := &github.Reference{
+		Ref:    github.Ptr("refs/heads/feature"),
+		Object: &github.GitObject{SHA: github.Ptr("old123")},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		gqlMatchers     []githubv4mock.Matcher
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+		expectedRef     GitRef
+	}{
+		{
+			name: "delete a branch",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposGitRefsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/git/refs/heads/feature").andThen(
+						mockResponse(t, http.StatusNoContent, ""),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"ref":   "refs/heads/feature",
+			},
+			expectedRef: GitRef{Ref: "refs/heads/feature"},
+		},
+		{
+			name:         "delete a branch with expected sha",
+			mockedClient: mock.NewMockedHTTPClient(),
+			gqlMatchers: refUpdateMatchers(githubv4.RefUpdate{
+				Name:      "refs/heads/feature",
+				AfterOid:  zeroOID,
+				BeforeOid: githubv4mock.Ptr(githubv4.GitObjectID("old123")),
+			}, githubv4mock.DataResponse(map[string]any{
+				"updateRefs": map[string]any{"clientMutationId": ""},
+			})),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "refs/heads/feature",
+				"expectedSha": "old123",
+			},
+			expectedRef: GitRef{Ref: "refs/heads/feature", PreviousSHA: "old123"},
+		},
+		{
+			name: "ref moved since it was read",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposGitRefByOwnerByRepoByRef, currentRef),
+			),
+			gqlMatchers: refUpdateMatchers(githubv4.RefUpdate{
+				Name:      "refs/heads/feature",
+				AfterOid:  zeroOID,
+				BeforeOid: githubv4mock.Ptr(githubv4.GitObjectID("stale789")),
+			}, githubv4mock.ErrorResponse("A ref update failed")),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "refs/heads/feature",
+				"expectedSha": "stale789",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "not the expected stale789",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.gqlMatchers...))
+			_, handler := DeleteGitRef(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var ref GitRef
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &ref))
+			assert.Equal(t, tc.expectedRef, ref)
+		})
+	}
+}
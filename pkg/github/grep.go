@@ -0,0 +1,355 @@
+package github
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// maxSnapshotArchiveSize is the size of the largest repository archive grep_repository downloads, in bytes.
+	maxSnapshotArchiveSize = 128 << 20
+	// maxSnapshotSize is the most text grep_repository keeps of a repository snapshot, in bytes.
+	maxSnapshotSize = 128 << 20
+	// maxSnapshotFileSize is the size of the largest file grep_repository searches, in bytes.
+	maxSnapshotFileSize = 1 << 20
+	// defaultGrepMaxResults and maxGrepResults bound how many matching lines grep_repository returns.
+	defaultGrepMaxResults = 100
+	maxGrepResults        = 1000
+	// maxGrepContextLines is the most lines of context grep_repository returns on each side of a match.
+	maxGrepContextLines = 20
+)
+
+// repoSnapshots caches the text files of repositories at a commit, by repository and commit SHA, which
+// never change. Refs are resolved on every call, with the caller's token, so only callers who can read the
+// repository get its cached snapshot.
+var repoSnapshots = newLRUCache[*repoSnapshot](512 << 20)
+
+// repoSnapshot is the text files of a repository at a commit.
+type repoSnapshot struct {
+	files []snapshotFile
+	// skipped is how many files were left out, being binary or larger than maxSnapshotFileSize.
+	skipped int
+	size    int
+}
+
+type snapshotFile struct {
+	path    string
+	content string
+}
+
+// GrepFile is the matches of grep_repository in a file.
+type GrepFile struct {
+	Path    string         `json:"path"`
+	Matches []*CodeContext `json:"matches"`
+}
+
+// GrepResult is the result of grep_repository.
+type GrepResult struct {
+	SHA   string     `json:"sha"`
+	Files []GrepFile `json:"files"`
+	// MatchCount is how many matching lines were returned.
+	MatchCount    int `json:"match_count"`
+	FilesSearched int `json:"files_searched"`
+	// FilesSkipped is how many files weren't searched, being binary or too large.
+	FilesSkipped int `json:"files_skipped,omitempty"`
+	// Truncated is set when the search stopped at max_results.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// GrepRepository creates a tool to search the files of a repository at any ref with a regular expression or
+// a literal string, over a snapshot downloaded as an archive.
+func GrepRepository(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("grep_repository",
+			mcp.WithDescription(t("TOOL_GREP_REPOSITORY_DESCRIPTION", "Search the files of a GitHub repository at any branch, tag or commit for lines matching a regular expression or literal string, like grep. Unlike search_code, it supports regular expressions and any ref, and isn't rate limited after the first call: the repository is downloaded once per commit and cached. Returns matching lines with their line numbers and surrounding lines.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GREP_REPOSITORY_USER_TITLE", "Grep repository"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("pattern",
+				mcp.Required(),
+				mcp.Description("Pattern lines are matched against, in Go regular expression syntax (RE2) unless literal is true"),
+			),
+			mcp.WithString("ref",
+				mcp.Description("Branch, tag or commit SHA to search, defaults to the default branch"),
+			),
+			mcp.WithBoolean("literal",
+				mcp.Description("Match the pattern as a literal string rather than a regular expression"),
+			),
+			mcp.WithBoolean("ignore_case",
+				mcp.Description("Match case insensitively"),
+			),
+			mcp.WithArray("paths",
+				mcp.Description("Only search files matching one of these globs, such as '*.go' or 'src/**/*.ts'. Globs without a slash match file names in any directory"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithArray("exclude_paths",
+				mcp.Description("Don't search files matching any of these globs"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithNumber("context_lines",
+				mcp.Description(fmt.Sprintf("Lines to return before and after each match, max %d", maxGrepContextLines)),
+				mcp.Min(0),
+				mcp.Max(maxGrepContextLines),
+			),
+			mcp.WithNumber("max_results",
+				mcp.Description(fmt.Sprintf("Stop after this many matching lines, defaults to %d, max %d", defaultGrepMaxResults, maxGrepResults)),
+				mcp.Min(1),
+				mcp.Max(maxGrepResults),
+			),
+			WithOutputSchema[GrepResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pattern, err := requiredParam[string](request, "pattern")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ref, err := OptionalParam[string](request, "ref")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			literal, err := OptionalParam[bool](request, "literal")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			ignoreCase, err := OptionalParam[bool](request, "ignore_case")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			paths, err := OptionalStringArrayParam(request, "paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			excludePaths, err := OptionalStringArrayParam(request, "exclude_paths")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			for _, glob := range append(paths, excludePaths...) {
+				if _, err := path.Match(glob, ""); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid path pattern %q: %s", glob, err)), nil
+				}
+			}
+			contextLines, err := OptionalIntParam(request, "context_lines")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if contextLines < 0 || contextLines > maxGrepContextLines {
+				return mcp.NewToolResultError(fmt.Sprintf("context_lines must be between 0 and %d", maxGrepContextLines)), nil
+			}
+			maxResults, err := OptionalIntParamWithDefault(request, "max_results", defaultGrepMaxResults)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxResults < 1 || maxResults > maxGrepResults {
+				return mcp.NewToolResultError(fmt.Sprintf("max_results must be between 1 and %d", maxGrepResults)), nil
+			}
+
+			if literal {
+				pattern = regexp.QuoteMeta(pattern)
+			}
+			if ignoreCase {
+				pattern = "(?i)" + pattern
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid pattern: %s", err)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Resolving the ref also checks the caller can read the repository before using a cached snapshot.
+			if ref == "" {
+				ref = "HEAD"
+			}
+			sha, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve ref: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			snapshot, err := loadRepoSnapshot(ctx, client, owner, repo, sha)
+			if err != nil {
+				return nil, err
+			}
+
+			return structuredTextResult(grepSnapshot(snapshot, sha, re, paths, excludePaths, contextLines, maxResults)), nil
+		}
+}
+
+// loadRepoSnapshot returns the text files of a repository at a commit, from the cache or downloaded as a
+// tarball.
+func loadRepoSnapshot(ctx context.Context, client *github.Client, owner, repo, sha string) (*repoSnapshot, error) {
+	key := owner + "/" + repo + "@" + sha
+	if snapshot, ok := repoSnapshots.get(key); ok {
+		return snapshot, nil
+	}
+
+	link, resp, err := client.Repositories.GetArchiveLink(ctx, owner, repo, github.Tarball, &github.RepositoryContentGetOptions{Ref: sha}, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archive link: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	archive, err := client.Client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+	defer func() { _ = archive.Body.Close() }()
+
+	if archive.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download archive: %w", responseError(&github.Response{Response: archive}))
+	}
+
+	snapshot, err := readTarball(io.LimitReader(archive.Body, maxSnapshotArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+	repoSnapshots.add(key, snapshot, snapshot.size)
+	return snapshot, nil
+}
+
+// readTarball reads the text files of a repository tarball, sorted by path, without the directory GitHub puts
+// them in.
+func readTarball(r io.Reader) (*repoSnapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	snapshot := &repoSnapshot{}
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("the repository archive is larger than %d bytes", maxSnapshotArchiveSize)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		_, name, ok := strings.Cut(header.Name, "/")
+		if !ok || name == "" {
+			continue
+		}
+		if header.Size > maxSnapshotFileSize {
+			snapshot.skipped++
+			continue
+		}
+		content, err := io.ReadAll(archive)
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("the repository archive is larger than %d bytes", maxSnapshotArchiveSize)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", name, err)
+		}
+		if !isTextContent(content) {
+			snapshot.skipped++
+			continue
+		}
+		snapshot.size += len(content)
+		if snapshot.size > maxSnapshotSize {
+			return nil, fmt.Errorf("the repository has more than %d bytes of text files", maxSnapshotSize)
+		}
+		snapshot.files = append(snapshot.files, snapshotFile{path: name, content: string(content)})
+	}
+	sort.Slice(snapshot.files, func(i, j int) bool { return snapshot.files[i].path < snapshot.files[j].path })
+	return snapshot, nil
+}
+
+// grepSnapshot returns the lines of the files of snapshot matching re, with contextLines lines around them,
+// stopping after maxResults matching lines. Files are searched if they match one of paths, when given, and
+// none of excludePaths.
+func grepSnapshot(snapshot *repoSnapshot, sha string, re *regexp.Regexp, paths, excludePaths []string, contextLines, maxResults int) GrepResult {
+	result := GrepResult{SHA: sha, Files: []GrepFile{}, FilesSkipped: snapshot.skipped}
+	for _, file := range snapshot.files {
+		if !matchesAnyGlob(paths, file.path, true) || matchesAnyGlob(excludePaths, file.path, false) {
+			continue
+		}
+		result.FilesSearched++
+
+		// Lines are matched one at a time, so that ^ and $ match at their start and end.
+		lines := strings.Split(strings.TrimSuffix(file.content, "\n"), "\n")
+		var matchLines []int
+		for i, line := range lines {
+			if !re.MatchString(line) {
+				continue
+			}
+			if result.MatchCount == maxResults {
+				result.Truncated = true
+				break
+			}
+			matchLines = append(matchLines, i+1)
+			result.MatchCount++
+		}
+		if len(matchLines) > 0 {
+			result.Files = append(result.Files, GrepFile{Path: file.path, Matches: lineContexts(lines, matchLines, contextLines)})
+		}
+		if result.Truncated {
+			break
+		}
+	}
+	return result
+}
+
+// matchesAnyGlob reports whether name matches one of globs, or returns empty when there are none.
+func matchesAnyGlob(globs []string, name string, empty bool) bool {
+	if len(globs) == 0 {
+		return empty
+	}
+	for _, glob := range globs {
+		if ok, _ := matchPathGlob(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTarball returns a repository tarball of files, in the top-level directory GitHub puts them in.
+func mockTarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	require.NoError(t, archive.WriteHeader(&tar.Header{Name: "owner-repo-abc1234/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for name, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: "owner-repo-abc1234/" + name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func Test_GrepRepository(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GrepRepository(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "grep_repository", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pattern")
+	assert.Contains(t, tool.InputSchema.Properties, "ref")
+	assert.Contains(t, tool.InputSchema.Properties, "paths")
+	assert.Contains(t, tool.InputSchema.Properties, "exclude_paths")
+	assert.Contains(t, tool.InputSchema.Properties, "context_lines")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pattern"})
+
+	repoSnapshots = newLRUCache[*repoSnapshot](1 << 20)
+	t.Cleanup(func() { repoSnapshots = newLRUCache[*repoSnapshot](512 << 20) })
+
+	const sha = "abc1234abc1234abc1234abc1234abc1234abc12"
+	tarball := mockTarball(t, map[string]string{
+		"main.go":              "package main\n\nfunc main() {\n\t// TODO: parse flags\n\trun()\n}\n",
+		"pkg/run/run.go":       "package run\n\n// Run runs.\nfunc Run() {\n\t// todo: everything\n}\n",
+		"vendor/lib/lib.go":    "package lib\n\n// TODO: vendored\n",
+		"docs/README.md":       "# TODO(a+b)\n",
+		"assets/logo.png":      "\x89PNG\x00\x01",
+		"pkg/run/run_test.go":  "package run\n",
+		"pkg/run/testdata/big": "",
+	})
+
+	var downloads atomic.Int32
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepoByRef,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(sha))
+			}),
+		),
+		mock.WithRequestMatchHandler(
+			mock.GetReposTarballByOwnerByRepoByRef,
+			expectPath(t, "/repos/owner/repo/tarball/"+sha).andThen(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Location", "https://codeload.github.com/owner/repo/legacy.tar.gz/"+sha)
+					w.WriteHeader(http.StatusFound)
+				}),
+			),
+		),
+		mock.WithRequestMatchHandler(
+			mock.EndpointPattern{
+				Pattern: "/owner/repo/legacy.tar.gz/{sha}",
+				Method:  "GET",
+			},
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				downloads.Add(1)
+				_, _ = w.Write(tarball)
+			}),
+		),
+	))
+	_, handler := GrepRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedResult GrepResult
+		expectedErrMsg string
+	}{
+		{
+			name: "literal search",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "TODO(a+b)",
+				"literal": true,
+			},
+			expectedResult: GrepResult{
+				SHA: sha,
+				Files: []GrepFile{
+					{Path: "docs/README.md", Matches: []*CodeContext{{StartLine: 1, EndLine: 1, MatchLines: []int{1}, Content: "1\t# TODO(a+b)\n"}}},
+				},
+				MatchCount:    1,
+				FilesSearched: 6,
+				FilesSkipped:  1,
+			},
+		},
+		{
+			name: "regex search with globs and context",
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"ref":           "main",
+				"pattern":       `todo\b`,
+				"ignore_case":   true,
+				"paths":         []any{"*.go"},
+				"exclude_paths": []any{"vendor/**", "**/*_test.go"},
+				"context_lines": float64(1),
+			},
+			expectedResult: GrepResult{
+				SHA: sha,
+				Files: []GrepFile{
+					{Path: "main.go", Matches: []*CodeContext{{StartLine: 3, EndLine: 5, MatchLines: []int{4}, Content: "3\tfunc main() {\n4\t\t// TODO: parse flags\n5\t\trun()\n"}}},
+					{Path: "pkg/run/run.go", Matches: []*CodeContext{{StartLine: 4, EndLine: 6, MatchLines: []int{5}, Content: "4\tfunc Run() {\n5\t\t// todo: everything\n6\t}\n"}}},
+				},
+				MatchCount:    2,
+				FilesSearched: 2,
+				FilesSkipped:  1,
+			},
+		},
+		{
+			name: "anchored at line start and end",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": `^func \w+\(\) \{$`,
+				"paths":   []any{"*.go"},
+			},
+			expectedResult: GrepResult{
+				SHA: sha,
+				Files: []GrepFile{
+					{Path: "main.go", Matches: []*CodeContext{{StartLine: 3, EndLine: 3, MatchLines: []int{3}, Content: "3\tfunc main() {\n"}}},
+					{Path: "pkg/run/run.go", Matches: []*CodeContext{{StartLine: 4, EndLine: 4, MatchLines: []int{4}, Content: "4\tfunc Run() {\n"}}},
+				},
+				MatchCount:    2,
+				FilesSearched: 4,
+				FilesSkipped:  1,
+			},
+		},
+		{
+			name: "anchored at line end",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": `\)$`,
+			},
+			expectedResult: GrepResult{
+				SHA: sha,
+				Files: []GrepFile{
+					{Path: "docs/README.md", Matches: []*CodeContext{{StartLine: 1, EndLine: 1, MatchLines: []int{1}, Content: "1\t# TODO(a+b)\n"}}},
+					{Path: "main.go", Matches: []*CodeContext{{StartLine: 5, EndLine: 5, MatchLines: []int{5}, Content: "5\t\trun()\n"}}},
+				},
+				MatchCount:    2,
+				FilesSearched: 6,
+				FilesSkipped:  1,
+			},
+		},
+		{
+			name: "stops at max_results",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pattern":     "TODO",
+				"paths":       []any{"main.go", "vendor/**"},
+				"max_results": float64(1),
+			},
+			expectedResult: GrepResult{
+				SHA: sha,
+				Files: []GrepFile{
+					{Path: "main.go", Matches: []*CodeContext{{StartLine: 4, EndLine: 4, MatchLines: []int{4}, Content: "4\t\t// TODO: parse flags\n"}}},
+				},
+				MatchCount:    1,
+				FilesSearched: 2,
+				FilesSkipped:  1,
+				Truncated:     true,
+			},
+		},
+		{
+			name: "invalid regex",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "TODO(",
+			},
+			expectedErrMsg: "invalid pattern: error parsing regexp",
+		},
+		{
+			name: "invalid glob",
+			requestArgs: map[string]any{
+				"owner":   "owner",
+				"repo":    "repo",
+				"pattern": "TODO",
+				"paths":   []any{"[a-"},
+			},
+			expectedErrMsg: `invalid path pattern "[a-"`,
+		},
+		{
+			name: "invalid max_results",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"pattern":     "TODO",
+				"max_results": float64(maxGrepResults + 1),
+			},
+			expectedErrMsg: "max_results must be between 1 and 1000",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var returnedResult GrepResult
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &returnedResult))
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+
+	// The snapshot is downloaded once, and then searched from the cache.
+	assert.Equal(t, int32(1), downloads.Load())
+}
+
+func Test_GrepRepositoryNotFound(t *testing.T) {
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposCommitsByOwnerByRepoByRef,
+			mockResponse(t, http.StatusNotFound, `{"message": "No commit found for SHA: nope"}`),
+		),
+	))
+	_, handler := GrepRepository(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	_, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":   "owner",
+		"repo":    "repo",
+		"ref":     "nope",
+		"pattern": "TODO",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve ref")
+	assert.Equal(t, ErrorNotFound, classifyError(err).Code)
+}
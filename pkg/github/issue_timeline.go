@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+// IssueTimelineEvent is a normalized entry of an issue or pull request timeline. Only the fields relevant
+// to the event are set.
+type IssueTimelineEvent struct {
+	Event     string `json:"event"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// Body is set for comments and reviews.
+	Body      string `json:"body,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Assignee  string `json:"assignee,omitempty"`
+	Milestone string `json:"milestone,omitempty"`
+	// RenamedFrom and RenamedTo are set for title changes.
+	RenamedFrom string `json:"renamed_from,omitempty"`
+	RenamedTo   string `json:"renamed_to,omitempty"`
+	// CommitID is set when the event was caused by a commit, such as a closing "fixes #123" message.
+	CommitID    string `json:"commit_id,omitempty"`
+	ReviewState string `json:"review_state,omitempty"`
+	// RequestedReviewer is a user login or a team slug.
+	RequestedReviewer string `json:"requested_reviewer,omitempty"`
+	// Reference is set for cross-references from other issues and pull requests.
+	Reference *IssueReference `json:"reference,omitempty"`
+}
+
+// IssueReference identifies an issue or pull request that refers to another one.
+type IssueReference struct {
+	Repository    string `json:"repository,omitempty"`
+	Number        int    `json:"number"`
+	Title         string `json:"title,omitempty"`
+	State         string `json:"state,omitempty"`
+	IsPullRequest bool   `json:"is_pull_request"`
+	Merged        bool   `json:"merged,omitempty"`
+	URL           string `json:"url,omitempty"`
+}
+
+// IssueTimeline is a page of the timeline of an issue or pull request.
+type IssueTimeline struct {
+	Events []IssueTimelineEvent `json:"events"`
+	// LinkedPullRequests are the pull requests that close the issue when merged, or closed it, whichever
+	// page of the timeline is returned. They are always empty for pull requests.
+	LinkedPullRequests []IssueReference `json:"linked_pull_requests,omitempty"`
+	NextPage           int              `json:"next_page,omitempty"`
+	// NextCursor is the cursor to the following events, if any.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func issueTimelineEvent(item *github.Timeline) IssueTimelineEvent {
+	event := IssueTimelineEvent{
+		Event:       item.GetEvent(),
+		Actor:       item.GetActor().GetLogin(),
+		Body:        item.GetBody(),
+		Label:       item.GetLabel().GetName(),
+		Assignee:    item.GetAssignee().GetLogin(),
+		Milestone:   item.GetMilestone().GetTitle(),
+		RenamedFrom: item.GetRename().GetFrom(),
+		RenamedTo:   item.GetRename().GetTo(),
+		CommitID:    item.GetCommitID(),
+		ReviewState: item.GetState(),
+	}
+
+	// Comments and reviews carry their author in user rather than actor.
+	if event.Actor == "" {
+		event.Actor = item.GetUser().GetLogin()
+	}
+
+	switch {
+	case !item.GetCreatedAt().IsZero():
+		event.CreatedAt = item.GetCreatedAt().Format(time.RFC3339)
+	case !item.GetSubmittedAt().IsZero():
+		event.CreatedAt = item.GetSubmittedAt().Format(time.RFC3339)
+	}
+
+	if reviewer := item.GetReviewer().GetLogin(); reviewer != "" {
+		event.RequestedReviewer = reviewer
+	} else if team := item.GetRequestedTeam().GetSlug(); team != "" {
+		event.RequestedReviewer = team
+	}
+
+	if issue := item.GetSource().GetIssue(); issue != nil {
+		event.Reference = &IssueReference{
+			Repository:    issue.GetRepository().GetFullName(),
+			Number:        issue.GetNumber(),
+			Title:         issue.GetTitle(),
+			State:         issue.GetState(),
+			IsPullRequest: issue.IsPullRequest(),
+			Merged:        !issue.GetPullRequestLinks().GetMergedAt().IsZero(),
+			URL:           issue.GetHTMLURL(),
+		}
+	}
+
+	return event
+}
+
+// closingPullRequests returns the pull requests that close an issue when merged, or closed it, from its
+// closing references. It returns none for pull requests.
+func closingPullRequests(ctx context.Context, client *githubv4.Client, owner, repo string, number int) ([]IssueReference, error) {
+	var query struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				Issue struct {
+					ClosedByPullRequestsReferences struct {
+						Nodes []struct {
+							Number     githubv4.Int
+							Title      githubv4.String
+							State      githubv4.PullRequestState
+							URL        githubv4.String
+							Repository struct {
+								NameWithOwner githubv4.String
+							}
+						}
+					} `graphql:"closedByPullRequestsReferences(first: 100, includeClosedPrs: true)"`
+				} `graphql:"... on Issue"`
+			} `graphql:"issueOrPullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner":  githubv4.String(owner),
+		"repo":   githubv4.String(repo),
+		"number": githubv4.Int(number),
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+
+	nodes := query.Repository.IssueOrPullRequest.Issue.ClosedByPullRequestsReferences.Nodes
+	refs := make([]IssueReference, 0, len(nodes))
+	for _, pr := range nodes {
+		// Match the open and closed states of the REST API, which report merged pull requests as closed.
+		state := "closed"
+		if pr.State == githubv4.PullRequestStateOpen {
+			state = "open"
+		}
+		refs = append(refs, IssueReference{
+			Repository:    string(pr.Repository.NameWithOwner),
+			Number:        int(pr.Number),
+			Title:         string(pr.Title),
+			State:         state,
+			IsPullRequest: true,
+			Merged:        pr.State == githubv4.PullRequestStateMerged,
+			URL:           string(pr.URL),
+		})
+	}
+	return refs, nil
+}
+
+// GetIssueTimeline creates a tool to get the timeline of an issue or pull request.
+func GetIssueTimeline(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_issue_timeline",
+			mcp.WithDescription(t("TOOL_GET_ISSUE_TIMELINE_DESCRIPTION", "Get the timeline of an issue or pull request in a GitHub repository: comments, label and assignee changes, renames, cross-references from other issues and pull requests, and closures, oldest first. Use this to reconstruct the history of a long discussion. linked_pull_requests lists the pull requests that close the issue when merged, or closed it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_ISSUE_TIMELINE_USER_TITLE", "Get issue timeline"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[IssueTimeline](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			items, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Timeline, *github.Response, error) {
+				return client.Issues.ListIssueTimeline(ctx, owner, repo, issueNumber, &opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue timeline: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get issue timeline"), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			linked, err := closingPullRequests(ctx, gqlClient, owner, repo, issueNumber)
+			if err != nil {
+				return toolErrorResult(fmt.Errorf("failed to get closing pull requests: %w", err)), nil
+			}
+
+			timeline := IssueTimeline{
+				Events:             make([]IssueTimelineEvent, 0, len(items)),
+				LinkedPullRequests: linked,
+				NextPage:           nextPageOf(next),
+				NextCursor:         next,
+			}
+			for _, item := range items {
+				timeline.Events = append(timeline.Events, issueTimelineEvent(item))
+			}
+
+			return projectedTextResult(request, timeline), nil
+		}
+}
@@ -0,0 +1,297 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetIssueTimeline(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetIssueTimeline(stubGetClientFn(mockClient), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_issue_timeline", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	createdAt := &github.Timestamp{Time: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+	mergedAt := &github.Timestamp{Time: time.Date(2025, 3, 2, 9, 30, 0, 0, time.UTC)}
+
+	mockTimeline := []*github.Timeline{
+		{
+			Event:     github.Ptr("labeled"),
+			Actor:     &github.User{Login: github.Ptr("maintainer")},
+			CreatedAt: createdAt,
+			Label:     &github.Label{Name: github.Ptr("bug")},
+		},
+		{
+			Event:     github.Ptr("commented"),
+			User:      &github.User{Login: github.Ptr("reporter")},
+			CreatedAt: createdAt,
+			Body:      github.Ptr("Still happening on main"),
+		},
+		{
+			Event:     github.Ptr("renamed"),
+			Actor:     &github.User{Login: github.Ptr("maintainer")},
+			CreatedAt: createdAt,
+			Rename:    &github.Rename{From: github.Ptr("Crash"), To: github.Ptr("Crash on startup")},
+		},
+		{
+			Event:     github.Ptr("cross-referenced"),
+			Actor:     &github.User{Login: github.Ptr("contributor")},
+			CreatedAt: createdAt,
+			Source: &github.Source{
+				Type: github.Ptr("issue"),
+				Issue: &github.Issue{
+					Number:     github.Ptr(57),
+					Title:      github.Ptr("Fix crash on startup"),
+					State:      github.Ptr("closed"),
+					HTMLURL:    github.Ptr("https://github.com/owner/repo/pull/57"),
+					Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+					PullRequestLinks: &github.PullRequestLinks{
+						URL:      github.Ptr("https://api.github.com/repos/owner/repo/pulls/57"),
+						MergedAt: mergedAt,
+					},
+				},
+			},
+		},
+		{
+			Event:     github.Ptr("closed"),
+			Actor:     &github.User{Login: github.Ptr("contributor")},
+			CreatedAt: createdAt,
+			CommitID:  github.Ptr("6dcb09b5b57875f334f61aebed695e2e4193db5e"),
+		},
+	}
+
+	linkedPullRequest := IssueReference{
+		Repository:    "owner/repo",
+		Number:        57,
+		Title:         "Fix crash on startup",
+		State:         "closed",
+		IsPullRequest: true,
+		Merged:        true,
+		URL:           "https://github.com/owner/repo/pull/57",
+	}
+
+	closingQuery := struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				Issue struct {
+					ClosedByPullRequestsReferences struct {
+						Nodes []struct {
+							Number     githubv4.Int
+							Title      githubv4.String
+							State      githubv4.PullRequestState
+							URL        githubv4.String
+							Repository struct {
+								NameWithOwner githubv4.String
+							}
+						}
+					} `graphql:"closedByPullRequestsReferences(first: 100, includeClosedPrs: true)"`
+				} `graphql:"... on Issue"`
+			} `graphql:"issueOrPullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	closingVars := map[string]any{
+		"owner":  githubv4.String("owner"),
+		"repo":   githubv4.String("repo"),
+		"number": githubv4.Int(42),
+	}
+	closingResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"issueOrPullRequest": map[string]any{
+				"closedByPullRequestsReferences": map[string]any{
+					"nodes": []any{
+						map[string]any{
+							"number":     57,
+							"title":      "Fix crash on startup",
+							"state":      "MERGED",
+							"url":        "https://github.com/owner/repo/pull/57",
+							"repository": map[string]any{"nameWithOwner": "owner/repo"},
+						},
+						map[string]any{
+							"number":     61,
+							"title":      "Guard against a nil config",
+							"state":      "OPEN",
+							"url":        "https://github.com/fork/repo/pull/61",
+							"repository": map[string]any{"nameWithOwner": "fork/repo"},
+						},
+					},
+				},
+			},
+		},
+	})
+	openPullRequest := IssueReference{
+		Repository:    "fork/repo",
+		Number:        61,
+		Title:         "Guard against a nil config",
+		State:         "open",
+		IsPullRequest: true,
+		URL:           "https://github.com/fork/repo/pull/61",
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		gqlClient        *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectToolError  bool
+		expectedTimeline IssueTimeline
+		expectedErrMsg   string
+	}{
+		{
+			name: "normalizes events and gets the closing pull requests",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "5",
+					}).andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/42/timeline?page=2&per_page=5>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							b, _ := json.Marshal(mockTimeline)
+							_, _ = w.Write(b)
+						},
+					),
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingQuery, closingVars, closingResponse),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"perPage":      float64(5),
+			},
+			expectedTimeline: IssueTimeline{
+				Events: []IssueTimelineEvent{
+					{Event: "labeled", Actor: "maintainer", CreatedAt: "2025-03-01T12:00:00Z", Label: "bug"},
+					{Event: "commented", Actor: "reporter", CreatedAt: "2025-03-01T12:00:00Z", Body: "Still happening on main"},
+					{Event: "renamed", Actor: "maintainer", CreatedAt: "2025-03-01T12:00:00Z", RenamedFrom: "Crash", RenamedTo: "Crash on startup"},
+					{Event: "cross-referenced", Actor: "contributor", CreatedAt: "2025-03-01T12:00:00Z", Reference: &linkedPullRequest},
+					{Event: "closed", Actor: "contributor", CreatedAt: "2025-03-01T12:00:00Z", CommitID: "6dcb09b5b57875f334f61aebed695e2e4193db5e"},
+				},
+				LinkedPullRequests: []IssueReference{linkedPullRequest, openPullRequest},
+				NextPage:           2,
+				NextCursor:         pageCursor{Page: 2, PerPage: 5}.String(),
+			},
+		},
+		{
+			name: "closing pull requests don't depend on the page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockTimeline[:1],
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingQuery, closingVars, closingResponse),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"page":         float64(2),
+			},
+			expectedTimeline: IssueTimeline{
+				Events: []IssueTimelineEvent{
+					{Event: "labeled", Actor: "maintainer", CreatedAt: "2025-03-01T12:00:00Z", Label: "bug"},
+				},
+				LinkedPullRequests: []IssueReference{linkedPullRequest, openPullRequest},
+			},
+		},
+		{
+			name: "closing pull requests fail",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					mockTimeline[:1],
+				),
+			),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(closingQuery, closingVars, githubv4mock.ErrorResponse("Resource not accessible by integration")),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "failed to get closing pull requests",
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesTimelineByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue timeline",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := GetIssueTimeline(stubGetClientFn(client), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedTimeline IssueTimeline
+			err = json.Unmarshal([]byte(textContent.Text), &returnedTimeline)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTimeline, returnedTimeline)
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -37,6 +36,9 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Required(),
 				mcp.Description("The number of the issue"),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -52,6 +54,11 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -63,19 +70,24 @@ func GetIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get issue"), nil
 			}
 
-			r, err := json.Marshal(issue)
+			structured := cleanIssue(issue)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderIssue(issue)), structured), nil
+			}
+
+			projected, err := projectResult(request, compactOr(ctx, issue, cleanIssue))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal issue: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -103,6 +115,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("Comment content"),
 			),
+			WithOutputSchema[github.IssueComment](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -137,11 +150,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create comment: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create comment"), nil
 			}
 
 			r, err := json.Marshal(createdComment)
@@ -149,7 +158,7 @@ func AddIssueComment(getClient GetClientFn, t translations.TranslationHelperFunc
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), createdComment), nil
 		}
 }
 
@@ -186,59 +195,76 @@ func SearchIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[SearchResult[*CleanedIssue]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := requiredParam[string](request, "q")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			sort, err := OptionalParam[string](request, "sort")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			order, err := OptionalParam[string](request, "order")
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-			pagination, err := OptionalPaginationParams(request)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			opts := &github.SearchOptions{
-				Sort:  sort,
-				Order: order,
-				ListOptions: github.ListOptions{
-					PerPage: pagination.perPage,
-					Page:    pagination.page,
-				},
-			}
+			return searchIssues(ctx, getClient, request, query, "failed to search issues")
+		}
+}
 
-			client, err := getClient(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
-			}
-			result, resp, err := client.Search.Issues(ctx, query, opts)
-			if err != nil {
-				return nil, fmt.Errorf("failed to search issues: %w", err)
-			}
-			defer func() { _ = resp.Body.Close() }()
+// searchIssues runs an issue search query, with the sort, order, pagination, fields and output format
+// arguments of request, for the tools searching issues and pull requests.
+func searchIssues(ctx context.Context, getClient GetClientFn, request mcp.CallToolRequest, query, failure string) (*mcp.CallToolResult, error) {
+	sort, err := OptionalParam[string](request, "sort")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	order, err := OptionalParam[string](request, "order")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	pagination, err := OptionalPaginationParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search issues: %s", string(body))), nil
-			}
+	opts := &github.SearchOptions{
+		Sort:  sort,
+		Order: order,
+	}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+	markdown, err := markdownOutput(ctx, request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-			return mcp.NewToolResultText(string(r)), nil
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+	}
+	var result *github.IssuesSearchResult
+	issues, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+		opts.ListOptions = listOpts
+		page, resp, err := client.Search.Issues(ctx, query, opts)
+		if page == nil {
+			return nil, resp, err
 		}
+		result = page
+		return page.Issues, resp, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", failure, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return responseErrorResult(resp, failure), nil
+	}
+
+	result.Issues = issues
+
+	structured := searchResult(result.GetTotal(), result.GetIncompleteResults(), cleanIssues(result.Issues), next)
+	if markdown {
+		return structuredResult(withNextCursor(mcp.NewToolResultText(renderIssuesSearchResult(result)), next), structured), nil
+	}
+
+	return projectedListResult(request, compactOr(ctx, result, cleanIssuesSearchResult), structured, next), nil
 }
 
 // CreateIssue creates a tool to create a new issue in a GitHub repository.
@@ -283,6 +309,13 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithNumber("milestone",
 				mcp.Description("Milestone number"),
 			),
+			mcp.WithString("type",
+				mcp.Description("Issue type name, as returned by list_issue_types. Only available for repositories owned by an organization"),
+			),
+			mcp.WithNumber("parent_issue_number",
+				mcp.Description("Create the issue as a sub-issue of this issue, in the same repository"),
+			),
+			WithOutputSchema[CleanedIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -327,6 +360,16 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				milestoneNum = &milestone
 			}
 
+			issueType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			parentIssueNumber, err := OptionalIntParam(request, "parent_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			// Create the issue request
 			issueRequest := &github.IssueRequest{
 				Title:     github.Ptr(title),
@@ -335,6 +378,9 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Labels:    &labels,
 				Milestone: milestoneNum,
 			}
+			if issueType != "" {
+				issueRequest.Type = github.Ptr(issueType)
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
@@ -347,19 +393,23 @@ func CreateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
+				return responseErrorResult(resp, "failed to create issue"), nil
+			}
+
+			if parentIssueNumber != 0 {
+				_, parentResp, err := addSubIssue(ctx, client, owner, repo, parentIssueNumber, issue.GetID(), false)
 				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
+					return toolErrorResult(fmt.Errorf("issue #%d was created but could not be added as a sub-issue of #%d: %w", issue.GetNumber(), parentIssueNumber, err)), nil
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create issue: %s", string(body))), nil
+				_ = parentResp.Body.Close()
 			}
 
-			r, err := json.Marshal(issue)
+			r, err := json.Marshal(compactOr(ctx, issue, cleanIssue))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanIssue(issue)), nil
 		}
 }
 
@@ -403,6 +453,9 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Filter by date (ISO 8601 timestamp)"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedIssue]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -450,38 +503,39 @@ func ListIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				opts.Since = timestamp
 			}
 
-			if page, ok := request.GetArguments()["page"].(float64); ok {
-				opts.ListOptions.Page = int(page)
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			if perPage, ok := request.GetArguments()["perPage"].(float64); ok {
-				opts.ListOptions.PerPage = int(perPage)
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+			issues, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.Issues.ListByRepo(ctx, owner, repo, opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list issues: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list issues: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to list issues"), nil
 			}
 
-			r, err := json.Marshal(issues)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal issues: %w", err)
+			structured := listResult(cleanIssues(issues), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderIssues(issues)), next), structured), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, issues, cleanIssues), structured, next), nil
 		}
 }
 
@@ -516,7 +570,7 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Enum("open", "closed"),
 			),
 			mcp.WithArray("labels",
-				mcp.Description("New labels"),
+				mcp.Description("New labels, replacing all labels currently on the issue. Use add_labels_to_issue to keep the existing ones"),
 				mcp.Items(
 					map[string]interface{}{
 						"type": "string",
@@ -534,6 +588,10 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			mcp.WithNumber("milestone",
 				mcp.Description("New milestone number"),
 			),
+			mcp.WithString("type",
+				mcp.Description("New issue type name, as returned by list_issue_types"),
+			),
+			WithOutputSchema[CleanedIssue](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -604,6 +662,14 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				issueRequest.Milestone = &milestoneNum
 			}
 
+			issueType, err := OptionalParam[string](request, "type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if issueType != "" {
+				issueRequest.Type = github.Ptr(issueType)
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -615,19 +681,15 @@ func UpdateIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update issue: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to update issue"), nil
 			}
 
-			r, err := json.Marshal(updatedIssue)
+			r, err := json.Marshal(compactOr(ctx, updatedIssue, cleanIssue))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanIssue(updatedIssue)), nil
 		}
 }
 
@@ -651,12 +713,12 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("Issue number"),
 			),
-			mcp.WithNumber("page",
-				mcp.Description("Page number"),
-			),
+			WithPagination(),
 			mcp.WithNumber("per_page",
-				mcp.Description("Number of records per page"),
+				mcp.Description("Deprecated, use perPage"),
 			),
+			WithFields(),
+			WithOutputSchema[ListResult[*CleanedIssueComment]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -671,46 +733,37 @@ func GetIssueComments(getClient GetClientFn, t translations.TranslationHelperFun
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			page, err := OptionalIntParamWithDefault(request, "page", 1)
+			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			perPage, err := OptionalIntParamWithDefault(request, "per_page", 30)
+			// per_page is the name of perPage before this tool was paginated like the others.
+			legacyPerPage, err := OptionalIntParam(request, "per_page")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-
-			opts := &github.IssueListCommentsOptions{
-				ListOptions: github.ListOptions{
-					Page:    page,
-					PerPage: perPage,
-				},
+			args := request.GetArguments()
+			if _, ok := args["perPage"]; !ok && args["cursor"] == nil && legacyPerPage > 0 {
+				pagination.perPage = legacyPerPage
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issueNumber, opts)
+			comments, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.IssueComment, *github.Response, error) {
+				return client.Issues.ListComments(ctx, owner, repo, issueNumber, &github.IssueListCommentsOptions{ListOptions: opts})
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get issue comments: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue comments: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(comments)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return responseErrorResult(resp, "failed to get issue comments"), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, comments, cleanIssueComments), listResult(cleanIssueComments(comments), next), next), nil
 		}
 }
 
@@ -774,6 +827,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				mcp.Required(),
 				mcp.Description("Issue number"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -870,7 +924,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 			}
 
 			if err := client.Query(ctx, &getIssueQuery, variables); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get issue ID: %v", err)), nil
+				return toolErrorResult(fmt.Errorf("failed to get issue ID: %w", err)), nil
 			}
 
 			// Finally, do the assignment. Just for reference, assigning copilot to an issue that it is already
@@ -899,7 +953,7 @@ func AssignCopilotToIssue(getGQLClient GetGQLClientFn, t translations.Translatio
 				return nil, fmt.Errorf("failed to replace actors for assignable: %w", err)
 			}
 
-			return mcp.NewToolResultText("successfully assigned copilot to issue"), nil
+			return actionResult("successfully assigned copilot to issue"), nil
 		}
 }
 
@@ -27,6 +27,7 @@ func Test_GetIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "fields")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
 
 	// Setup mock issue for success case
@@ -396,6 +397,8 @@ func Test_CreateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
+	assert.Contains(t, tool.InputSchema.Properties, "parent_issue_number")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
 
 	// Setup mock issue for success case
@@ -473,6 +476,75 @@ func Test_CreateIssue(t *testing.T) {
 				State:   github.Ptr("open"),
 			},
 		},
+		{
+			name: "issue created as a typed sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					expectRequestBody(t, map[string]any{
+						"title":     "Child Issue",
+						"body":      "",
+						"labels":    []any{},
+						"assignees": []any{},
+						"type":      "Task",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Issue{
+							ID:      github.Ptr(int64(9001)),
+							Number:  github.Ptr(125),
+							Title:   github.Ptr("Child Issue"),
+							HTMLURL: github.Ptr("https://github.com/owner/repo/issues/125"),
+							State:   github.Ptr("open"),
+						}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id": float64(9001),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(100)}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"title":               "Child Issue",
+				"type":                "Task",
+				"parent_issue_number": float64(100),
+			},
+			expectError: false,
+			expectedIssue: &github.Issue{
+				Number:  github.Ptr(125),
+				Title:   github.Ptr("Child Issue"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/issues/125"),
+				State:   github.Ptr("open"),
+			},
+		},
+		{
+			name: "issue created but parent does not exist",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesByOwnerByRepo,
+					mockResponse(t, http.StatusCreated, &github.Issue{ID: github.Ptr(int64(9002)), Number: github.Ptr(126)}),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":               "owner",
+				"repo":                "repo",
+				"title":               "Orphan",
+				"parent_issue_number": float64(404),
+			},
+			expectError:    false,
+			expectedErrMsg: "issue #126 was created but could not be added as a sub-issue of #404",
+		},
 		{
 			name: "issue creation fails",
 			mockedClient: mock.NewMockedHTTPClient(
@@ -572,6 +644,7 @@ func Test_ListIssues(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "since")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
 	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "fields")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
 
 	// Setup mock issues for success case
@@ -748,6 +821,7 @@ func Test_UpdateIssue(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "labels")
 	assert.Contains(t, tool.InputSchema.Properties, "assignees")
 	assert.Contains(t, tool.InputSchema.Properties, "milestone")
+	assert.Contains(t, tool.InputSchema.Properties, "type")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
 
 	// Setup mock issue for success case
@@ -1000,6 +1074,8 @@ func Test_GetIssueComments(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
 	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "cursor")
 	assert.Contains(t, tool.InputSchema.Properties, "per_page")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
 
@@ -1060,6 +1136,29 @@ func Test_GetIssueComments(t *testing.T) {
 					),
 				),
 			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"page":         float64(2),
+				"perPage":      float64(10),
+			},
+			expectError:      false,
+			expectedComments: mockComments,
+		},
+		{
+			name: "successful comments retrieval with deprecated per_page",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesCommentsByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockComments),
+					),
+				),
+			),
 			requestArgs: map[string]interface{}{
 				"owner":        "owner",
 				"repo":         "repo",
@@ -0,0 +1,607 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// normalizeLabelColor strips the leading '#' from a hex color, since the API only accepts the bare hex code.
+func normalizeLabelColor(color string) string {
+	return strings.TrimPrefix(color, "#")
+}
+
+// ListLabels creates a tool to list the labels of a repository.
+func ListLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_labels",
+			mcp.WithDescription(t("TOOL_LIST_LABELS_DESCRIPTION", "List the labels defined in a GitHub repository. Use this to find existing label names before labelling issues or pull requests.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_LABELS_USER_TITLE", "List labels"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*github.Label]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			labels, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Label, *github.Response, error) {
+				return client.Issues.ListLabels(ctx, owner, repo, &opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list labels: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list labels"), nil
+			}
+
+			return projectedListResult(request, labels, listResult(labels, next), next), nil
+		}
+}
+
+// GetLabel creates a tool to get a single label of a repository.
+func GetLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_label",
+			mcp.WithDescription(t("TOOL_GET_LABEL_DESCRIPTION", "Get a label in a GitHub repository by name.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_LABEL_USER_TITLE", "Get label"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Label name"),
+			),
+			WithOutputSchema[github.Label](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			label, resp, err := client.Issues.GetLabel(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get label"), nil
+			}
+
+			r, err := json.Marshal(label)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), label), nil
+		}
+}
+
+// SearchLabels creates a tool to search for the labels of a repository.
+func SearchLabels(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_labels",
+			mcp.WithDescription(t("TOOL_SEARCH_LABELS_DESCRIPTION", "Search for labels in a GitHub repository by keywords in their name or description.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_LABELS_USER_TITLE", "Search labels"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Keywords to match in the label name or description"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, defaults to best match"),
+				mcp.Enum("created", "updated"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SearchResult[*github.LabelResult]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := requiredParam[string](request, "query")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			// Labels are searched by repository ID rather than name.
+			repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get repository: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			var result *github.LabelsSearchResult
+			labels, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.LabelResult, *github.Response, error) {
+				opts.ListOptions = listOpts
+				page, resp, err := client.Search.Labels(ctx, repository.GetID(), query, opts)
+				if page == nil {
+					return nil, resp, err
+				}
+				result = page
+				return page.Labels, resp, err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to search labels: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to search labels"), nil
+			}
+
+			for _, label := range labels {
+				label.URL = nil
+			}
+			result.Labels = labels
+
+			structured := searchResult(result.GetTotal(), result.GetIncompleteResults(), labels, next)
+			return projectedListResult(request, result, structured, next), nil
+		}
+}
+
+// CreateLabel creates a tool to create a label in a repository.
+func CreateLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_label",
+			mcp.WithDescription(t("TOOL_CREATE_LABEL_DESCRIPTION", "Create a label in a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_LABEL_USER_TITLE", "Create label"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Label name"),
+			),
+			mcp.WithString("color",
+				mcp.Required(),
+				mcp.Description("Hexadecimal color code of the label, for example 'f29513'"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Short description of the label"),
+			),
+			WithOutputSchema[github.Label](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			color, err := requiredParam[string](request, "color")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			description, err := OptionalParam[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			label := &github.Label{
+				Name:  github.Ptr(name),
+				Color: github.Ptr(normalizeLabelColor(color)),
+			}
+			if description != "" {
+				label.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdLabel, resp, err := client.Issues.CreateLabel(ctx, owner, repo, label)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				return responseErrorResult(resp, "failed to create label"), nil
+			}
+
+			r, err := json.Marshal(createdLabel)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), createdLabel), nil
+		}
+}
+
+// UpdateLabel creates a tool to update a label in a repository.
+func UpdateLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_label",
+			mcp.WithDescription(t("TOOL_UPDATE_LABEL_DESCRIPTION", "Update the name, color or description of a label in a GitHub repository. Renaming a label keeps it on the issues and pull requests it is applied to.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_LABEL_USER_TITLE", "Edit label"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Current label name"),
+			),
+			mcp.WithString("new_name",
+				mcp.Description("New label name"),
+			),
+			mcp.WithString("color",
+				mcp.Description("New hexadecimal color code, for example 'f29513'"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description"),
+			),
+			WithOutputSchema[github.Label](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Only send the fields that were provided
+			label := &github.Label{}
+
+			newName, err := OptionalParam[string](request, "new_name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if newName != "" {
+				label.Name = github.Ptr(newName)
+			}
+
+			color, err := OptionalParam[string](request, "color")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if color != "" {
+				label.Color = github.Ptr(normalizeLabelColor(color))
+			}
+
+			description, ok, err := OptionalParamOK[string](request, "description")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if ok {
+				label.Description = github.Ptr(description)
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updatedLabel, resp, err := client.Issues.EditLabel(ctx, owner, repo, name, label)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to update label"), nil
+			}
+
+			r, err := json.Marshal(updatedLabel)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), updatedLabel), nil
+		}
+}
+
+// DeleteLabel creates a tool to delete a label from a repository.
+func DeleteLabel(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_label",
+			mcp.WithDescription(t("TOOL_DELETE_LABEL_DESCRIPTION", "Delete a label from a GitHub repository. The label is also removed from every issue and pull request it is applied to.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_LABEL_USER_TITLE", "Delete label"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("name",
+				mcp.Required(),
+				mcp.Description("Label name"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			name, err := requiredParam[string](request, "name")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.DeleteLabel(ctx, owner, repo, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return responseErrorResult(resp, "failed to delete label"), nil
+			}
+
+			return actionResult(fmt.Sprintf("label %s deleted from %s/%s", name, owner, repo)), nil
+		}
+}
+
+// AddLabelsToIssue creates a tool to add labels to an issue or pull request, keeping the labels already applied.
+func AddLabelsToIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_labels_to_issue",
+			mcp.WithDescription(t("TOOL_ADD_LABELS_TO_ISSUE_DESCRIPTION", "Add labels to an issue or pull request in a GitHub repository. Labels already applied are kept, unlike update_issue which replaces them. Use list_labels to find existing label names.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_LABELS_TO_ISSUE_USER_TITLE", "Add labels to issue or pull request"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithArray("labels",
+				mcp.Required(),
+				mcp.Description("Names of the labels to add"),
+				mcp.Items(
+					map[string]interface{}{
+						"type": "string",
+					},
+				),
+			),
+			WithOutputSchema[ListResult[*github.Label]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			labels, err := OptionalStringArrayParam(request, "labels")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if len(labels) == 0 {
+				return mcp.NewToolResultError("missing required parameter: labels"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			appliedLabels, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, issueNumber, labels)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add labels: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to add labels"), nil
+			}
+
+			// The response holds every label now applied, not just the ones that were added.
+			r, err := json.Marshal(appliedLabels)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), listResult(appliedLabels, "")), nil
+		}
+}
+
+// RemoveLabelFromIssue creates a tool to remove a single label from an issue or pull request.
+func RemoveLabelFromIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_label_from_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_LABEL_FROM_ISSUE_DESCRIPTION", "Remove a label from an issue or pull request in a GitHub repository, keeping its other labels.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_LABEL_FROM_ISSUE_USER_TITLE", "Remove label from issue or pull request"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("Issue or pull request number"),
+			),
+			mcp.WithString("label",
+				mcp.Required(),
+				mcp.Description("Name of the label to remove"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			label, err := requiredParam[string](request, "label")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, issueNumber, label)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove label: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to remove label"), nil
+			}
+
+			return actionResult(fmt.Sprintf("label %s removed from #%d", label, issueNumber)), nil
+		}
+}
@@ -0,0 +1,607 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockLabels := []*github.Label{
+		{Name: github.Ptr("bug"), Color: github.Ptr("d73a4a"), Description: github.Ptr("Something isn't working")},
+		{Name: github.Ptr("enhancement"), Color: github.Ptr("a2eeef")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedLabels []*github.Label
+		expectedErrMsg string
+	}{
+		{
+			name: "successful labels listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "100",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockLabels),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"perPage": float64(100),
+			},
+			expectedLabels: mockLabels,
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposLabelsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list labels",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedLabels []*github.Label
+			err = json.Unmarshal([]byte(textContent.Text), &returnedLabels)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLabels, returnedLabels)
+		})
+	}
+}
+
+func Test_GetLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	mockLabel := &github.Label{Name: github.Ptr("good first issue"), Color: github.Ptr("7057ff")}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepoByName,
+			expectPath(t, "/repos/owner/repo/labels/good first issue").andThen(
+				mockResponse(t, http.StatusOK, mockLabel),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"name":  "good first issue",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedLabel github.Label
+	err = json.Unmarshal([]byte(textContent.Text), &returnedLabel)
+	require.NoError(t, err)
+	assert.Equal(t, mockLabel, &returnedLabel)
+}
+
+func Test_CreateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "name")
+	assert.Contains(t, tool.InputSchema.Properties, "color")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name", "color"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedLabel  *github.Label
+		expectedErrMsg string
+	}{
+		{
+			name: "color with leading hash",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"name":        "needs triage",
+						"color":       "fbca04",
+						"description": "Waiting for a maintainer",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Label{
+							Name:        github.Ptr("needs triage"),
+							Color:       github.Ptr("fbca04"),
+							Description: github.Ptr("Waiting for a maintainer"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "needs triage",
+				"color":       "#fbca04",
+				"description": "Waiting for a maintainer",
+			},
+			expectedLabel: &github.Label{
+				Name:        github.Ptr("needs triage"),
+				Color:       github.Ptr("fbca04"),
+				Description: github.Ptr("Waiting for a maintainer"),
+			},
+		},
+		{
+			name: "label already exists",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposLabelsByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"code": "already_exists"}]}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"name":  "bug",
+				"color": "d73a4a",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create label",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedLabel github.Label
+			err = json.Unmarshal([]byte(textContent.Text), &returnedLabel)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLabel, &returnedLabel)
+		})
+	}
+}
+
+func Test_UpdateLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "new_name")
+	assert.Contains(t, tool.InputSchema.Properties, "color")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	tests := []struct {
+		name          string
+		mockedClient  *http.Client
+		requestArgs   map[string]interface{}
+		expectedLabel *github.Label
+	}{
+		{
+			name: "rename only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					expectRequestBody(t, map[string]interface{}{
+						"name": "type: bug",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Label{Name: github.Ptr("type: bug"), Color: github.Ptr("d73a4a")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":    "owner",
+				"repo":     "repo",
+				"name":     "bug",
+				"new_name": "type: bug",
+			},
+			expectedLabel: &github.Label{Name: github.Ptr("type: bug"), Color: github.Ptr("d73a4a")},
+		},
+		{
+			name: "clear description and change color",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposLabelsByOwnerByRepoByName,
+					expectRequestBody(t, map[string]interface{}{
+						"color":       "000000",
+						"description": "",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Label{Name: github.Ptr("bug"), Color: github.Ptr("000000")}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"name":        "bug",
+				"color":       "#000000",
+				"description": "",
+			},
+			expectedLabel: &github.Label{Name: github.Ptr("bug"), Color: github.Ptr("000000")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedLabel github.Label
+			err = json.Unmarshal([]byte(textContent.Text), &returnedLabel)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLabel, &returnedLabel)
+		})
+	}
+}
+
+func Test_DeleteLabel(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteLabel(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_label", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "name"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposLabelsByOwnerByRepoByName,
+			http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := DeleteLabel(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner": "owner",
+		"repo":  "repo",
+		"name":  "wontfix",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	assert.Equal(t, "label wontfix deleted from owner/repo", textContent.Text)
+}
+
+func Test_AddLabelsToIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddLabelsToIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_labels_to_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "labels"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedLabels  []*github.Label
+		expectedErrMsg  string
+	}{
+		{
+			name: "adds labels with POST so existing ones are kept",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesLabelsByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, []interface{}{"bug", "p1"}).andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{
+							{Name: github.Ptr("needs triage")},
+							{Name: github.Ptr("bug")},
+							{Name: github.Ptr("p1")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"labels":       []interface{}{"bug", "p1"},
+			},
+			expectedLabels: []*github.Label{
+				{Name: github.Ptr("needs triage")},
+				{Name: github.Ptr("bug")},
+				{Name: github.Ptr("p1")},
+			},
+		},
+		{
+			name:         "no labels given",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"labels":       []interface{}{},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: labels",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddLabelsToIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedLabels []*github.Label
+			err = json.Unmarshal([]byte(textContent.Text), &returnedLabels)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLabels, returnedLabels)
+		})
+	}
+}
+
+func Test_RemoveLabelFromIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveLabelFromIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_label_from_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "label"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "label removed",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					expectPath(t, "/repos/owner/repo/issues/42/labels/bug").andThen(
+						mockResponse(t, http.StatusOK, []*github.Label{{Name: github.Ptr("p1")}}),
+					),
+				),
+			),
+		},
+		{
+			name: "label not on the issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Label does not exist"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to remove label",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveLabelFromIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"label":        "bug",
+			}))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, "label bug removed from #42", textContent.Text)
+		})
+	}
+}
+
+func Test_SearchLabels(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchLabels(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "search_labels", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "query"})
+
+	mockSearchResult := &github.LabelsSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Labels: []*github.LabelResult{
+			{
+				ID:    github.Ptr(int64(1)),
+				URL:   github.Ptr("https://api.github.com/repos/owner/repo/labels/bug"),
+				Name:  github.Ptr("bug"),
+				Color: github.Ptr("d73a4a"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "labels found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposByOwnerByRepo,
+					&github.Repository{ID: github.Ptr(int64(42))},
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetSearchLabels,
+					expectQueryParams(t, map[string]string{
+						"repository_id": "42",
+						"q":             "bug",
+						"page":          "1",
+						"per_page":      "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+		},
+		{
+			name: "repository not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposByOwnerByRepo,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get repository",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+				"query": "bug",
+			}))
+
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			var returnedResult github.LabelsSearchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+			require.Len(t, returnedResult.Labels, 1)
+			assert.Equal(t, "bug", *returnedResult.Labels[0].Name)
+			assert.Nil(t, returnedResult.Labels[0].URL)
+		})
+	}
+}
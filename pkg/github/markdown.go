@@ -0,0 +1,460 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+)
+
+// The render functions below turn tool results into concise Markdown for the markdown output format.
+// Details of a single object are a heading, a list of its properties and its body, and lists are tables.
+
+// mdCell makes s fit in a table cell, on a single line and with its pipes escaped.
+func mdCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// mdSummary shortens text to its first line, cut to at most maxLen runes, for table cells.
+func mdSummary(text string, maxLen int) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if runes := []rune(line); len(runes) > maxLen {
+		line = string(runes[:maxLen-1]) + "…"
+	}
+	return mdCell(line)
+}
+
+// mdLink returns text linking to url, or text alone when url is empty.
+func mdLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", `\[`, "]", `\]`).Replace(text), url)
+}
+
+// mdCount returns n followed by noun, made plural by adding "s" unless n is 1.
+func mdCount(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func mdDate(ts *github.Timestamp) string {
+	if ts == nil || ts.IsZero() {
+		return ""
+	}
+	return ts.UTC().Format("2006-01-02")
+}
+
+func mdUser(user *github.User) string {
+	if user.GetLogin() == "" {
+		return ""
+	}
+	return "@" + user.GetLogin()
+}
+
+func mdUsers(users []*github.User) string {
+	logins := make([]string, 0, len(users))
+	for _, user := range users {
+		logins = append(logins, mdUser(user))
+	}
+	return strings.Join(logins, ", ")
+}
+
+func mdLabels(labels []*github.Label) string {
+	return strings.Join(labelNames(labels), ", ")
+}
+
+// mdTable renders rows as a table under header, leaving out the columns that are empty in every row, such
+// as the repository of alerts listed for a single repository.
+func mdTable(header []string, rows [][]string) string {
+	var columns []int
+	for i := range header {
+		for _, row := range rows {
+			if row[i] != "" {
+				columns = append(columns, i)
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, i := range columns {
+			b.WriteString(" " + mdCell(cells[i]) + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(header)
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return b.String()
+}
+
+// mdProperty is a labeled value in the property list of an object.
+type mdProperty struct {
+	label string
+	value string
+}
+
+// mdDetails renders an object as its title, the non-empty properties and its body.
+func mdDetails(title string, properties []mdProperty, body string) string {
+	var b strings.Builder
+	b.WriteString("# " + title + "\n\n")
+	for _, p := range properties {
+		if p.value != "" {
+			fmt.Fprintf(&b, "- **%s:** %s\n", p.label, p.value)
+		}
+	}
+	if body = strings.TrimSpace(body); body != "" {
+		b.WriteString("\n" + body + "\n")
+	}
+	return b.String()
+}
+
+func issueState(issue *github.Issue) string {
+	if reason := issue.GetStateReason(); issue.GetState() == "closed" && reason != "" {
+		return fmt.Sprintf("%s (%s)", issue.GetState(), reason)
+	}
+	return issue.GetState()
+}
+
+// renderIssue renders an issue with its properties and body.
+func renderIssue(issue *github.Issue) string {
+	return mdDetails(fmt.Sprintf("#%d: %s", issue.GetNumber(), issue.GetTitle()), []mdProperty{
+		{"State", issueState(issue)},
+		{"Author", mdUser(issue.User)},
+		{"Assignees", mdUsers(issue.Assignees)},
+		{"Labels", mdLabels(issue.Labels)},
+		{"Milestone", issue.GetMilestone().GetTitle()},
+		{"Comments", strconv.Itoa(issue.GetComments())},
+		{"Created", mdDate(issue.CreatedAt)},
+		{"Updated", mdDate(issue.UpdatedAt)},
+		{"Closed", mdDate(issue.ClosedAt)},
+		{"URL", issue.GetHTMLURL()},
+	}, issue.GetBody())
+}
+
+// renderIssues renders issues as a table.
+func renderIssues(issues []*github.Issue) string {
+	if len(issues) == 0 {
+		return "No issues found.\n"
+	}
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		rows = append(rows, []string{
+			strconv.Itoa(issue.GetNumber()),
+			mdLink(issue.GetTitle(), issue.GetHTMLURL()),
+			issueState(issue),
+			mdUser(issue.User),
+			mdLabels(issue.Labels),
+			strconv.Itoa(issue.GetComments()),
+			mdDate(issue.UpdatedAt),
+		})
+	}
+	return mdTable([]string{"#", "Title", "State", "Author", "Labels", "Comments", "Updated"}, rows)
+}
+
+// renderIssuesSearchResult renders the issues and pull requests found by a search as a table.
+func renderIssuesSearchResult(result *github.IssuesSearchResult) string {
+	if len(result.Issues) == 0 {
+		return "No issues or pull requests found.\n"
+	}
+	rows := make([][]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		kind := "Issue"
+		if issue.IsPullRequest() {
+			kind = "PR"
+		}
+		rows = append(rows, []string{
+			strings.TrimPrefix(issue.GetRepositoryURL(), "https://api.github.com/repos/"),
+			strconv.Itoa(issue.GetNumber()),
+			kind,
+			mdLink(issue.GetTitle(), issue.GetHTMLURL()),
+			issueState(issue),
+			mdUser(issue.User),
+			mdDate(issue.UpdatedAt),
+		})
+	}
+	summary := mdCount(result.GetTotal(), "result")
+	if result.GetIncompleteResults() {
+		summary += ", the search timed out before finding all of them"
+	}
+	return summary + "\n\n" + mdTable([]string{"Repository", "#", "Type", "Title", "State", "Author", "Updated"}, rows)
+}
+
+func pullRequestState(pr *github.PullRequest) string {
+	switch {
+	case pr.GetMerged() || pr.MergedAt != nil:
+		return "merged"
+	case pr.GetDraft() && pr.GetState() == "open":
+		return "draft"
+	default:
+		return pr.GetState()
+	}
+}
+
+// renderPullRequest renders a pull request with its properties and body.
+func renderPullRequest(pr *github.PullRequest) string {
+	var changes string
+	if pr.ChangedFiles != nil {
+		changes = fmt.Sprintf("+%d −%d in %s, %s", pr.GetAdditions(), pr.GetDeletions(), mdCount(pr.GetChangedFiles(), "file"), mdCount(pr.GetCommits(), "commit"))
+	}
+	var mergeable string
+	if pr.GetState() == "open" && pr.GetMergeableState() != "" {
+		mergeable = pr.GetMergeableState()
+	}
+	return mdDetails(fmt.Sprintf("#%d: %s", pr.GetNumber(), pr.GetTitle()), []mdProperty{
+		{"State", pullRequestState(pr)},
+		{"Author", mdUser(pr.User)},
+		{"Branches", fmt.Sprintf("`%s` → `%s`", pr.GetHead().GetLabel(), pr.GetBase().GetRef())},
+		{"Changes", changes},
+		{"Mergeable", mergeable},
+		{"Assignees", mdUsers(pr.Assignees)},
+		{"Requested reviewers", mdUsers(pr.RequestedReviewers)},
+		{"Labels", mdLabels(pr.Labels)},
+		{"Milestone", pr.GetMilestone().GetTitle()},
+		{"Created", mdDate(pr.CreatedAt)},
+		{"Updated", mdDate(pr.UpdatedAt)},
+		{"Merged", mdDate(pr.MergedAt)},
+		{"Closed", mdDate(pr.ClosedAt)},
+		{"URL", pr.GetHTMLURL()},
+	}, pr.GetBody())
+}
+
+// renderPullRequests renders pull requests as a table.
+func renderPullRequests(prs []*github.PullRequest) string {
+	if len(prs) == 0 {
+		return "No pull requests found.\n"
+	}
+	rows := make([][]string, 0, len(prs))
+	for _, pr := range prs {
+		rows = append(rows, []string{
+			strconv.Itoa(pr.GetNumber()),
+			mdLink(pr.GetTitle(), pr.GetHTMLURL()),
+			pullRequestState(pr),
+			mdUser(pr.User),
+			fmt.Sprintf("`%s` → `%s`", pr.GetHead().GetRef(), pr.GetBase().GetRef()),
+			mdDate(pr.UpdatedAt),
+		})
+	}
+	return mdTable([]string{"#", "Title", "State", "Author", "Branches", "Updated"}, rows)
+}
+
+// renderPullRequestReviews renders the reviews of a pull request as a table, with the first line of each review.
+func renderPullRequestReviews(reviews []*github.PullRequestReview) string {
+	if len(reviews) == 0 {
+		return "No reviews found.\n"
+	}
+	rows := make([][]string, 0, len(reviews))
+	for _, review := range reviews {
+		rows = append(rows, []string{
+			mdUser(review.User),
+			strings.ToLower(strings.ReplaceAll(review.GetState(), "_", " ")),
+			mdDate(review.SubmittedAt),
+			mdSummary(review.GetBody(), 100),
+			review.GetHTMLURL(),
+		})
+	}
+	return mdTable([]string{"Reviewer", "State", "Submitted", "Comment", "URL"}, rows)
+}
+
+func releaseStatus(release *github.RepositoryRelease) string {
+	switch {
+	case release.GetDraft():
+		return "draft"
+	case release.GetPrerelease():
+		return "prerelease"
+	default:
+		return "published"
+	}
+}
+
+// renderRelease renders a release with its properties, assets and notes.
+func renderRelease(release *github.RepositoryRelease) string {
+	title := release.GetTagName()
+	if name := release.GetName(); name != "" && name != title {
+		title = fmt.Sprintf("%s (%s)", name, title)
+	}
+	assets := make([]string, 0, len(release.Assets))
+	for _, asset := range release.Assets {
+		assets = append(assets, mdLink(asset.GetName(), asset.GetBrowserDownloadURL()))
+	}
+	return mdDetails(title, []mdProperty{
+		{"Status", releaseStatus(release)},
+		{"Tag", "`" + release.GetTagName() + "`"},
+		{"Target", release.GetTargetCommitish()},
+		{"Author", mdUser(release.Author)},
+		{"Published", mdDate(release.PublishedAt)},
+		{"Assets", strings.Join(assets, ", ")},
+		{"URL", release.GetHTMLURL()},
+	}, release.GetBody())
+}
+
+// renderReleases renders releases as a table.
+func renderReleases(releases []*github.RepositoryRelease) string {
+	if len(releases) == 0 {
+		return "No releases found.\n"
+	}
+	rows := make([][]string, 0, len(releases))
+	for _, release := range releases {
+		rows = append(rows, []string{
+			mdLink(release.GetTagName(), release.GetHTMLURL()),
+			release.GetName(),
+			releaseStatus(release),
+			mdUser(release.Author),
+			mdDate(release.PublishedAt),
+		})
+	}
+	return mdTable([]string{"Tag", "Name", "Status", "Author", "Published"}, rows)
+}
+
+func codeScanningAlertLocation(alert *github.Alert) string {
+	location := alert.GetMostRecentInstance().GetLocation()
+	if location.GetPath() == "" {
+		return ""
+	}
+	if location.GetStartLine() == 0 {
+		return "`" + location.GetPath() + "`"
+	}
+	return fmt.Sprintf("`%s:%d`", location.GetPath(), location.GetStartLine())
+}
+
+// renderCodeScanningAlert renders a code scanning alert with its rule and most recent instance.
+func renderCodeScanningAlert(alert *github.Alert) string {
+	state := alert.GetState()
+	if reason := alert.GetDismissedReason(); reason != "" {
+		state = fmt.Sprintf("%s (%s)", state, reason)
+	}
+	return mdDetails(fmt.Sprintf("Code scanning alert #%d: %s", alert.GetNumber(), alert.GetRule().GetDescription()), []mdProperty{
+		{"State", state},
+		{"Severity", codeScanningAlertSeverity(alert)},
+		{"Rule", "`" + alert.GetRule().GetID() + "`"},
+		{"Tool", alert.GetTool().GetName()},
+		{"Repository", alert.GetRepository().GetFullName()},
+		{"Location", codeScanningAlertLocation(alert)},
+		{"Ref", alert.GetMostRecentInstance().GetRef()},
+		{"Created", mdDate(alert.CreatedAt)},
+		{"Fixed", mdDate(alert.FixedAt)},
+		{"Dismissed", mdDate(alert.DismissedAt)},
+		{"URL", alert.GetHTMLURL()},
+	}, alert.GetMostRecentInstance().GetMessage().GetText())
+}
+
+// renderCodeScanningAlerts renders code scanning alerts as a table, with their repository for alerts
+// listed across repositories.
+func renderCodeScanningAlerts(alerts []*github.Alert) string {
+	if len(alerts) == 0 {
+		return "No code scanning alerts found.\n"
+	}
+	rows := make([][]string, 0, len(alerts))
+	for _, alert := range alerts {
+		rows = append(rows, []string{
+			alert.GetRepository().GetFullName(),
+			mdLink(strconv.Itoa(alert.GetNumber()), alert.GetHTMLURL()),
+			codeScanningAlertSeverity(alert),
+			alert.GetRule().GetDescription(),
+			codeScanningAlertLocation(alert),
+			alert.GetState(),
+			mdDate(alert.CreatedAt),
+		})
+	}
+	return mdTable([]string{"Repository", "#", "Severity", "Rule", "Location", "State", "Created"}, rows)
+}
+
+func secretScanningAlertType(alert *github.SecretScanningAlert) string {
+	if name := alert.GetSecretTypeDisplayName(); name != "" {
+		return name
+	}
+	return alert.GetSecretType()
+}
+
+// renderSecretScanningAlert renders a secret scanning alert, leaving out the secret itself.
+func renderSecretScanningAlert(alert *github.SecretScanningAlert) string {
+	state := alert.GetState()
+	if resolution := alert.GetResolution(); resolution != "" {
+		state = fmt.Sprintf("%s (%s)", state, resolution)
+	}
+	var flags []string
+	if alert.GetPubliclyLeaked() {
+		flags = append(flags, "publicly leaked")
+	}
+	if alert.GetPushProtectionBypassed() {
+		flags = append(flags, "push protection bypassed")
+	}
+	return mdDetails(fmt.Sprintf("Secret scanning alert #%d: %s", alert.GetNumber(), secretScanningAlertType(alert)), []mdProperty{
+		{"State", state},
+		{"Validity", alert.GetValidity()},
+		{"Flags", strings.Join(flags, ", ")},
+		{"Repository", alert.GetRepository().GetFullName()},
+		{"Created", mdDate(alert.CreatedAt)},
+		{"Resolved", mdDate(alert.ResolvedAt)},
+		{"Resolved by", mdUser(alert.ResolvedBy)},
+		{"URL", alert.GetHTMLURL()},
+	}, alert.GetResolutionComment())
+}
+
+// renderSecretScanningAlerts renders secret scanning alerts as a table, leaving out the secrets.
+func renderSecretScanningAlerts(alerts []*github.SecretScanningAlert) string {
+	if len(alerts) == 0 {
+		return "No secret scanning alerts found.\n"
+	}
+	rows := make([][]string, 0, len(alerts))
+	for _, alert := range alerts {
+		rows = append(rows, []string{
+			alert.GetRepository().GetFullName(),
+			mdLink(strconv.Itoa(alert.GetNumber()), alert.GetHTMLURL()),
+			secretScanningAlertType(alert),
+			alert.GetState(),
+			alert.GetValidity(),
+			mdDate(alert.CreatedAt),
+		})
+	}
+	return mdTable([]string{"Repository", "#", "Secret type", "State", "Validity", "Created"}, rows)
+}
+
+// renderNotification renders a notification thread.
+func renderNotification(notification *github.Notification) string {
+	unread := "no"
+	if notification.GetUnread() {
+		unread = "yes"
+	}
+	return mdDetails(notification.GetSubject().GetTitle(), []mdProperty{
+		{"Type", notification.GetSubject().GetType()},
+		{"Repository", notification.GetRepository().GetFullName()},
+		{"Reason", notification.GetReason()},
+		{"Unread", unread},
+		{"Updated", mdDate(notification.UpdatedAt)},
+		{"Thread ID", notification.GetID()},
+	}, "")
+}
+
+// renderNotifications renders notifications as a table, marking unread ones.
+func renderNotifications(notifications []*github.Notification) string {
+	if len(notifications) == 0 {
+		return "No notifications found.\n"
+	}
+	rows := make([][]string, 0, len(notifications))
+	for _, notification := range notifications {
+		unread := ""
+		if notification.GetUnread() {
+			unread = "●"
+		}
+		rows = append(rows, []string{
+			unread,
+			notification.GetID(),
+			notification.GetRepository().GetFullName(),
+			notification.GetSubject().GetType(),
+			notification.GetSubject().GetTitle(),
+			notification.GetReason(),
+			mdDate(notification.UpdatedAt),
+		})
+	}
+	return mdTable([]string{"Unread", "ID", "Repository", "Type", "Title", "Reason", "Updated"}, rows)
+}
@@ -0,0 +1,315 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertMarkdownSnapshot checks rendered Markdown against the snapshot in __markdownsnaps__, which is written
+// when it does not exist yet outside of CI, or when UPDATE_MARKDOWNSNAPS is set to "true".
+func assertMarkdownSnapshot(t *testing.T, name, rendered string) {
+	t.Helper()
+	snapPath := filepath.Join("__markdownsnaps__", name+".md")
+
+	snap, err := os.ReadFile(snapPath) //nolint:gosec // filepaths are controlled by the test suite, so this is safe.
+	if os.Getenv("UPDATE_MARKDOWNSNAPS") == "true" || (os.IsNotExist(err) && os.Getenv("GITHUB_ACTIONS") != "true") {
+		require.NoError(t, os.MkdirAll(filepath.Dir(snapPath), 0700))
+		require.NoError(t, os.WriteFile(snapPath, []byte(rendered), 0600))
+		return
+	}
+	require.NoError(t, err, "Markdown snapshot does not exist for %s. Please run the tests with UPDATE_MARKDOWNSNAPS=true to create it", name)
+	assert.Equal(t, string(snap), rendered)
+}
+
+func markdownTestTime(day int) *github.Timestamp {
+	return &github.Timestamp{Time: time.Date(2025, time.March, day, 10, 30, 0, 0, time.UTC)}
+}
+
+func Test_MarkdownRenderers(t *testing.T) {
+	octocat := &github.User{Login: github.Ptr("octocat")}
+	hubot := &github.User{Login: github.Ptr("hubot")}
+	repo := &github.Repository{FullName: github.Ptr("owner/repo")}
+
+	issue := &github.Issue{
+		Number:        github.Ptr(42),
+		Title:         github.Ptr("Crash when | is in a title"),
+		State:         github.Ptr("open"),
+		Body:          github.Ptr("Steps to reproduce:\n\n1. Open a file\n2. See the crash"),
+		User:          octocat,
+		Assignees:     []*github.User{hubot},
+		Labels:        []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+		Milestone:     &github.Milestone{Title: github.Ptr("v1.0")},
+		Comments:      github.Ptr(3),
+		CreatedAt:     markdownTestTime(1),
+		UpdatedAt:     markdownTestTime(2),
+		HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/42"),
+		RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+	}
+	closedIssue := &github.Issue{
+		Number:        github.Ptr(7),
+		Title:         github.Ptr("Support [links] in titles"),
+		State:         github.Ptr("closed"),
+		StateReason:   github.Ptr("completed"),
+		User:          hubot,
+		Comments:      github.Ptr(0),
+		UpdatedAt:     markdownTestTime(5),
+		ClosedAt:      markdownTestTime(5),
+		HTMLURL:       github.Ptr("https://github.com/owner/repo/pull/7"),
+		RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+		PullRequestLinks: &github.PullRequestLinks{
+			URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/7"),
+		},
+	}
+
+	pr := &github.PullRequest{
+		Number:             github.Ptr(43),
+		Title:              github.Ptr("Fix the crash"),
+		State:              github.Ptr("open"),
+		Body:               github.Ptr("Fixes #42"),
+		User:               octocat,
+		Head:               &github.PullRequestBranch{Label: github.Ptr("octocat:fix-crash"), Ref: github.Ptr("fix-crash")},
+		Base:               &github.PullRequestBranch{Ref: github.Ptr("main")},
+		Additions:          github.Ptr(12),
+		Deletions:          github.Ptr(3),
+		ChangedFiles:       github.Ptr(2),
+		Commits:            github.Ptr(1),
+		MergeableState:     github.Ptr("clean"),
+		RequestedReviewers: []*github.User{hubot},
+		Labels:             []*github.Label{{Name: github.Ptr("bug")}},
+		CreatedAt:          markdownTestTime(3),
+		UpdatedAt:          markdownTestTime(4),
+		HTMLURL:            github.Ptr("https://github.com/owner/repo/pull/43"),
+	}
+	mergedPR := &github.PullRequest{
+		Number:    github.Ptr(40),
+		Title:     github.Ptr("Add docs"),
+		State:     github.Ptr("closed"),
+		Merged:    github.Ptr(true),
+		User:      hubot,
+		Head:      &github.PullRequestBranch{Ref: github.Ptr("docs")},
+		Base:      &github.PullRequestBranch{Ref: github.Ptr("main")},
+		UpdatedAt: markdownTestTime(1),
+		MergedAt:  markdownTestTime(1),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/40"),
+	}
+	draftPR := &github.PullRequest{
+		Number:    github.Ptr(44),
+		Title:     github.Ptr("WIP: refactor"),
+		State:     github.Ptr("open"),
+		Draft:     github.Ptr(true),
+		User:      octocat,
+		Head:      &github.PullRequestBranch{Ref: github.Ptr("refactor")},
+		Base:      &github.PullRequestBranch{Ref: github.Ptr("main")},
+		UpdatedAt: markdownTestTime(6),
+		HTMLURL:   github.Ptr("https://github.com/owner/repo/pull/44"),
+	}
+
+	reviews := []*github.PullRequestReview{
+		{
+			User:        hubot,
+			State:       github.Ptr("CHANGES_REQUESTED"),
+			Body:        github.Ptr("Please add a test.\n\nThe crash also happens with tabs."),
+			SubmittedAt: markdownTestTime(4),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/43#pullrequestreview-1"),
+		},
+		{
+			User:        octocat,
+			State:       github.Ptr("APPROVED"),
+			SubmittedAt: markdownTestTime(5),
+			HTMLURL:     github.Ptr("https://github.com/owner/repo/pull/43#pullrequestreview-2"),
+		},
+	}
+
+	release := &github.RepositoryRelease{
+		TagName:         github.Ptr("v1.0.0"),
+		Name:            github.Ptr("First release"),
+		TargetCommitish: github.Ptr("main"),
+		Body:            github.Ptr("## Changes\n\n- Fix the crash"),
+		Author:          octocat,
+		PublishedAt:     markdownTestTime(7),
+		HTMLURL:         github.Ptr("https://github.com/owner/repo/releases/tag/v1.0.0"),
+		Assets: []*github.ReleaseAsset{
+			{Name: github.Ptr("tool_linux_amd64.tar.gz"), BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.0.0/tool_linux_amd64.tar.gz")},
+		},
+	}
+	prerelease := &github.RepositoryRelease{
+		TagName:     github.Ptr("v1.1.0-rc.1"),
+		Prerelease:  github.Ptr(true),
+		Author:      hubot,
+		PublishedAt: markdownTestTime(9),
+		HTMLURL:     github.Ptr("https://github.com/owner/repo/releases/tag/v1.1.0-rc.1"),
+	}
+
+	codeAlert := &github.Alert{
+		Number:  github.Ptr(5),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/security/code-scanning/5"),
+		Rule: &github.Rule{
+			ID:                    github.Ptr("go/sql-injection"),
+			Description:           github.Ptr("Database query built from user-controlled sources"),
+			Severity:              github.Ptr("error"),
+			SecuritySeverityLevel: github.Ptr("high"),
+		},
+		Tool: &github.Tool{Name: github.Ptr("CodeQL")},
+		MostRecentInstance: &github.MostRecentInstance{
+			Ref:      github.Ptr("refs/heads/main"),
+			Location: &github.Location{Path: github.Ptr("db/query.go"), StartLine: github.Ptr(27)},
+			Message:  &github.Message{Text: github.Ptr("This query depends on a user-provided value.")},
+		},
+		CreatedAt: markdownTestTime(2),
+	}
+	dismissedCodeAlert := &github.Alert{
+		Number:          github.Ptr(3),
+		State:           github.Ptr("dismissed"),
+		DismissedReason: github.Ptr("false positive"),
+		HTMLURL:         github.Ptr("https://github.com/owner/other/security/code-scanning/3"),
+		Rule:            &github.Rule{Description: github.Ptr("Unused variable"), Severity: github.Ptr("note")},
+		Repository:      &github.Repository{FullName: github.Ptr("owner/other")},
+		CreatedAt:       markdownTestTime(1),
+		DismissedAt:     markdownTestTime(3),
+	}
+
+	secretAlert := &github.SecretScanningAlert{
+		Number:                github.Ptr(2),
+		State:                 github.Ptr("resolved"),
+		Resolution:            github.Ptr("revoked"),
+		ResolutionComment:     github.Ptr("Rotated the token."),
+		SecretType:            github.Ptr("github_personal_access_token"),
+		SecretTypeDisplayName: github.Ptr("GitHub Personal Access Token"),
+		Secret:                github.Ptr("ghp_should_never_be_shown"),
+		Validity:              github.Ptr("inactive"),
+		PubliclyLeaked:        github.Ptr(true),
+		Repository:            repo,
+		CreatedAt:             markdownTestTime(1),
+		ResolvedAt:            markdownTestTime(2),
+		ResolvedBy:            octocat,
+		HTMLURL:               github.Ptr("https://github.com/owner/repo/security/secret-scanning/2"),
+	}
+	openSecretAlert := &github.SecretScanningAlert{
+		Number:     github.Ptr(4),
+		State:      github.Ptr("open"),
+		SecretType: github.Ptr("aws_access_key_id"),
+		Secret:     github.Ptr("AKIA_should_never_be_shown"),
+		Validity:   github.Ptr("unknown"),
+		Repository: repo,
+		CreatedAt:  markdownTestTime(8),
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/security/secret-scanning/4"),
+	}
+
+	notification := &github.Notification{
+		ID:         github.Ptr("1001"),
+		Unread:     github.Ptr(true),
+		Reason:     github.Ptr("review_requested"),
+		Repository: repo,
+		Subject:    &github.NotificationSubject{Title: github.Ptr("Fix the crash"), Type: github.Ptr("PullRequest")},
+		UpdatedAt:  markdownTestTime(4),
+	}
+	readNotification := &github.Notification{
+		ID:         github.Ptr("1000"),
+		Unread:     github.Ptr(false),
+		Reason:     github.Ptr("mention"),
+		Repository: repo,
+		Subject:    &github.NotificationSubject{Title: github.Ptr("Crash when | is in a title"), Type: github.Ptr("Issue")},
+		UpdatedAt:  markdownTestTime(2),
+	}
+
+	tests := []struct {
+		name     string
+		rendered string
+	}{
+		{"issue", renderIssue(issue)},
+		{"issues", renderIssues([]*github.Issue{issue, closedIssue})},
+		{"issues_search_result", renderIssuesSearchResult(&github.IssuesSearchResult{
+			Total:             github.Ptr(2),
+			IncompleteResults: github.Ptr(false),
+			Issues:            []*github.Issue{issue, closedIssue},
+		})},
+		{"pull_request", renderPullRequest(pr)},
+		{"pull_requests", renderPullRequests([]*github.PullRequest{pr, mergedPR, draftPR})},
+		{"pull_request_reviews", renderPullRequestReviews(reviews)},
+		{"release", renderRelease(release)},
+		{"releases", renderReleases([]*github.RepositoryRelease{prerelease, release})},
+		{"code_scanning_alert", renderCodeScanningAlert(codeAlert)},
+		{"code_scanning_alerts", renderCodeScanningAlerts([]*github.Alert{codeAlert, dismissedCodeAlert})},
+		{"secret_scanning_alert", renderSecretScanningAlert(secretAlert)},
+		{"secret_scanning_alerts", renderSecretScanningAlerts([]*github.SecretScanningAlert{secretAlert, openSecretAlert})},
+		{"notification", renderNotification(notification)},
+		{"notifications", renderNotifications([]*github.Notification{notification, readNotification})},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertMarkdownSnapshot(t, tc.name, tc.rendered)
+			assert.NotContains(t, tc.rendered, "should_never_be_shown")
+		})
+	}
+}
+
+func Test_MarkdownRenderers_EmptyLists(t *testing.T) {
+	assert.Equal(t, "No issues found.\n", renderIssues(nil))
+	assert.Equal(t, "No pull requests found.\n", renderPullRequests([]*github.PullRequest{}))
+	assert.Equal(t, "No code scanning alerts found.\n", renderCodeScanningAlerts(nil))
+}
+
+func Test_MarkdownOutput(t *testing.T) {
+	markdownCtx := ContextWithOutputFormat(context.Background(), OutputFormatMarkdown)
+
+	tests := []struct {
+		name           string
+		ctx            context.Context
+		args           map[string]any
+		expected       bool
+		expectedErrMsg string
+	}{
+		{name: "default is json", ctx: context.Background(), expected: false},
+		{name: "server default", ctx: markdownCtx, expected: true},
+		{name: "call asks for markdown", ctx: context.Background(), args: map[string]any{"outputFormat": "markdown"}, expected: true},
+		{name: "call overrides server default", ctx: markdownCtx, args: map[string]any{"outputFormat": "json"}, expected: false},
+		{name: "invalid format", ctx: context.Background(), args: map[string]any{"outputFormat": "html"}, expectedErrMsg: `invalid output format "html"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			markdown, err := markdownOutput(tc.ctx, createMCPRequest(tc.args))
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, markdown)
+		})
+	}
+}
+
+func Test_ListIssues_Markdown(t *testing.T) {
+	mockIssues := []*github.Issue{
+		{Number: github.Ptr(1), Title: github.Ptr("First"), State: github.Ptr("open"), HTMLURL: github.Ptr("https://github.com/owner/repo/issues/1")},
+		{Number: github.Ptr(2), Title: github.Ptr("Second"), State: github.Ptr("open"), HTMLURL: github.Ptr("https://github.com/owner/repo/issues/2")},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo, mockIssues),
+	))
+	tool, handler := ListIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+	assert.Contains(t, tool.InputSchema.Properties, "outputFormat")
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"outputFormat": "markdown",
+	}))
+	require.NoError(t, err)
+	text := getTextResult(t, result).Text
+	assert.Equal(t, fmt.Sprintf("| # | Title | State | Comments |\n| --- | --- | --- | --- |\n| 1 | %s | open | 0 |\n| 2 | %s | open | 0 |\n",
+		"[First](https://github.com/owner/repo/issues/1)", "[Second](https://github.com/owner/repo/issues/2)"), text)
+}
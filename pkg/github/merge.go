@@ -0,0 +1,272 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	fileMergeUnchanged = "unchanged"
+	fileMergeMerged    = "merged"
+	fileMergeConflict  = "conflict"
+)
+
+// FileMerge is the outcome of merging changes made to a file from an older version with the file on a branch.
+type FileMerge struct {
+	Path string `json:"path"`
+	// Status is unchanged when the file did not change on the branch, merged when both changes were
+	// merged and committed, and conflict when nothing was committed because the changes conflict.
+	Status      string          `json:"status"`
+	BaseSHA     string          `json:"base_sha"`
+	UpstreamSHA string          `json:"upstream_sha"`
+	Conflicts   []MergeConflict `json:"conflicts,omitempty"`
+	Message     string          `json:"message,omitempty"`
+	// Result is the commit of the merged file.
+	Result *github.RepositoryContentResponse `json:"result,omitempty"`
+
+	content string
+}
+
+// MergeConflict is a region of a file that was changed differently by both sides of a three-way merge.
+type MergeConflict struct {
+	// Line is the line of the file on the branch where the conflicting region starts.
+	Line int `json:"line"`
+	// Base holds the lines of the region in the version both sides started from.
+	Base []string `json:"base"`
+	// Yours holds the lines the caller replaced the region with.
+	Yours []string `json:"yours"`
+	// Upstream holds the lines the region was replaced with on the branch.
+	Upstream []string `json:"upstream"`
+}
+
+// mergeFileChanges merges content, edited from the version of the file with blob SHA baseSHA, with the
+// version of the file currently on the branch. The merged content is only set when there are no conflicts.
+func mergeFileChanges(ctx context.Context, client *github.Client, owner, repo, path, branch, baseSHA, content string) (*FileMerge, *mcp.CallToolResult, error) {
+	fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get file contents: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if fileContent == nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("%s is a directory", path)), nil
+	}
+
+	merge := &FileMerge{
+		Path:        path,
+		BaseSHA:     baseSHA,
+		UpstreamSHA: fileContent.GetSHA(),
+	}
+	if merge.UpstreamSHA == baseSHA {
+		merge.Status = fileMergeUnchanged
+		merge.content = content
+		return merge, nil, nil
+	}
+
+	base, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, baseSHA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base blob: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	upstream, resp, err := client.Git.GetBlobRaw(ctx, owner, repo, merge.UpstreamSHA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get upstream blob: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !isTextContent(base) || !isTextContent(upstream) {
+		return nil, newToolError(ErrorConflict, fmt.Sprintf("%s changed on %s since %s and binary files can't be merged", path, branch, baseSHA)).result(), nil
+	}
+
+	merged, conflicts := mergeLines(string(base), content, string(upstream))
+	if len(conflicts) > 0 {
+		merge.Status = fileMergeConflict
+		merge.Conflicts = conflicts
+		merge.Message = fmt.Sprintf("%s was not updated, as it changed on %s in ways that conflict with the content. Resolve the conflicts and retry with baseSha %s", path, branch, merge.UpstreamSHA)
+		return merge, nil, nil
+	}
+	merge.Status = fileMergeMerged
+	merge.content = merged
+	return merge, nil, nil
+}
+
+// splitLines splits text into lines, keeping their line endings so that joining them gives back the text.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// trimLineEndings returns the lines without their line endings, as shown in conflicts.
+func trimLineEndings(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = strings.TrimSuffix(strings.TrimSuffix(l, "\n"), "\r")
+	}
+	return out
+}
+
+// matchLines returns, for each line of a, the index of the line of b it is matched with by a shortest edit
+// script between them, or -1 when the line was removed. It implements the Myers diff algorithm.
+func matchLines(a, b []string) []int {
+	matches := make([]int, len(a))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	// Common prefixes and suffixes are matched directly, which keeps the search small for typical edits.
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		matches[start] = start
+		start++
+	}
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && a[endA-1] == b[endB-1] {
+		endA--
+		endB--
+		matches[endA] = endB
+	}
+
+	n, m := endA-start, endB-start
+	if n == 0 || m == 0 {
+		return matches
+	}
+
+	// v[k+offset] holds the furthest x reached on diagonal k, and trace keeps a copy of v for each edit
+	// distance d to backtrack through.
+	offset := n + m
+	v := make([]int, 2*offset+2)
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset]
+			} else {
+				x = v[k-1+offset] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[start+x] == b[start+y] {
+				x++
+				y++
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v...))
+				backtrackMatches(trace, a, b, start, n, m, offset, matches)
+				return matches
+			}
+		}
+		trace = append(trace, append([]int(nil), v...))
+	}
+
+	return matches
+}
+
+// backtrackMatches walks the trace of matchLines back from the end of both sequences, recording the lines
+// matched along the diagonals of the edit script.
+func backtrackMatches(trace [][]int, a, b []string, start, n, m, offset int, matches []int) {
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		k := x - y
+		var prevK int
+		switch {
+		case d == 0:
+			prevK = 0
+		case k == -d || (k != d && trace[d-1][k-1+offset] < trace[d-1][k+1+offset]):
+			prevK = k + 1
+		default:
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = trace[d-1][prevK+offset]
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			matches[start+x] = start + y
+		}
+		if d > 0 {
+			x, y = prevX, prevY
+		}
+	}
+}
+
+// mergeLines performs a line-based three-way merge of yours and upstream, which both started from base.
+// Regions changed by only one side, or identically by both, are merged. Regions changed differently by
+// both sides are returned as conflicts, in which case the merged text keeps the upstream lines.
+func mergeLines(base, yours, upstream string) (string, []MergeConflict) {
+	o, a, b := splitLines(base), splitLines(yours), splitLines(upstream)
+	matchA, matchB := matchLines(o, a), matchLines(o, b)
+
+	var merged strings.Builder
+	var conflicts []MergeConflict
+	i, ia, ib := 0, 0, 0
+	for {
+		// Lines unchanged on both sides are kept as is.
+		for i < len(o) && ia < len(a) && ib < len(b) && matchA[i] == ia && matchB[i] == ib {
+			merged.WriteString(o[i])
+			i++
+			ia++
+			ib++
+		}
+		if i == len(o) && ia == len(a) && ib == len(b) {
+			break
+		}
+
+		// The changed region extends up to the next base line both sides kept.
+		j, ja, jb := i, len(a), len(b)
+		for ; j < len(o); j++ {
+			if matchA[j] >= 0 && matchB[j] >= 0 {
+				ja, jb = matchA[j], matchB[j]
+				break
+			}
+		}
+
+		baseChunk, yourChunk, upstreamChunk := o[i:j], a[ia:ja], b[ib:jb]
+		switch {
+		case equalLines(yourChunk, baseChunk):
+			writeLines(&merged, upstreamChunk)
+		case equalLines(upstreamChunk, baseChunk), equalLines(yourChunk, upstreamChunk):
+			writeLines(&merged, yourChunk)
+		default:
+			conflicts = append(conflicts, MergeConflict{
+				Line:     ib + 1,
+				Base:     trimLineEndings(baseChunk),
+				Yours:    trimLineEndings(yourChunk),
+				Upstream: trimLineEndings(upstreamChunk),
+			})
+			writeLines(&merged, upstreamChunk)
+		}
+		i, ia, ib = j, ja, jb
+	}
+
+	return merged.String(), conflicts
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(l)
+	}
+}
@@ -0,0 +1,804 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// MergeRequirement is a condition that has to be met before a pull request can be merged.
+type MergeRequirement struct {
+	// Name is one of draft, conflicts, reviews, checks, up_to_date, conversation_resolution or branch_protection.
+	Name      string `json:"name"`
+	Satisfied bool   `json:"satisfied"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// MergeRule is a rule from a ruleset that applies to the base branch of a pull request.
+type MergeRule struct {
+	Type    string `json:"type"`
+	Ruleset string `json:"ruleset,omitempty"`
+}
+
+// MergeQueueEntry is the position of a pull request in the merge queue of its base branch.
+type MergeQueueEntry struct {
+	Position int    `json:"position"`
+	State    string `json:"state"`
+}
+
+// AutoMerge describes auto-merge being enabled on a pull request.
+type AutoMerge struct {
+	MergeMethod string `json:"merge_method"`
+	EnabledBy   string `json:"enabled_by,omitempty"`
+}
+
+// MergeReadiness explains whether a pull request can be merged and how.
+type MergeReadiness struct {
+	State            string             `json:"state"`
+	HeadSHA          string             `json:"head_sha"`
+	BaseBranch       string             `json:"base_branch"`
+	Mergeable        string             `json:"mergeable"`
+	MergeStateStatus string             `json:"merge_state_status"`
+	ReviewDecision   string             `json:"review_decision,omitempty"`
+	Ready            bool               `json:"ready"`
+	Requirements     []MergeRequirement `json:"requirements"`
+	Rules            []MergeRule        `json:"rules,omitempty"`
+	// RequiresMergeQueue is set when the base branch has to be merged into through a merge queue.
+	RequiresMergeQueue bool             `json:"requires_merge_queue"`
+	MergeQueueEntry    *MergeQueueEntry `json:"merge_queue_entry,omitempty"`
+	AutoMerge          *AutoMerge       `json:"auto_merge,omitempty"`
+	NextStep           string           `json:"next_step"`
+}
+
+// mergeReadinessContext is a check run or a commit status in the status check rollup of a commit.
+type mergeReadinessContext struct {
+	Typename githubv4.String `graphql:"__typename"`
+	CheckRun struct {
+		Name       githubv4.String
+		Status     githubv4.String
+		Conclusion githubv4.String
+		IsRequired githubv4.Boolean `graphql:"isRequired(pullRequestNumber: $prNum)"`
+	} `graphql:"... on CheckRun"`
+	StatusContext struct {
+		Context    githubv4.String
+		State      githubv4.String
+		IsRequired githubv4.Boolean `graphql:"isRequired(pullRequestNumber: $prNum)"`
+	} `graphql:"... on StatusContext"`
+}
+
+// mergeReadinessPageInfo locates the following items of a connection of the merge readiness query.
+type mergeReadinessPageInfo struct {
+	HasNextPage githubv4.Boolean
+	EndCursor   githubv4.String
+}
+
+// mergeReadinessThreads are review threads of a pull request.
+type mergeReadinessThreads struct {
+	Nodes []struct {
+		IsResolved githubv4.Boolean
+	}
+	PageInfo mergeReadinessPageInfo
+}
+
+// mergeReadinessContexts are the check runs and commit statuses of the status check rollup of a commit.
+type mergeReadinessContexts struct {
+	Nodes    []mergeReadinessContext
+	PageInfo mergeReadinessPageInfo
+}
+
+// mergeReadinessRules are the rules from rulesets that apply to a branch.
+type mergeReadinessRules struct {
+	Nodes []struct {
+		Type              githubv4.RepositoryRuleType
+		RepositoryRuleset *struct {
+			Name githubv4.String
+		}
+		Parameters struct {
+			PullRequestParameters struct {
+				RequiredApprovingReviewCount   githubv4.Int
+				RequireCodeOwnerReview         githubv4.Boolean
+				RequiredReviewThreadResolution githubv4.Boolean
+			} `graphql:"... on PullRequestParameters"`
+			RequiredStatusChecksParameters struct {
+				StrictRequiredStatusChecksPolicy githubv4.Boolean
+			} `graphql:"... on RequiredStatusChecksParameters"`
+		}
+	}
+	PageInfo mergeReadinessPageInfo
+}
+
+// mergeReadinessQuery selects everything needed to tell whether a pull request can be merged. Review threads,
+// status check contexts and rules beyond the first 100 are fetched with the following queries.
+type mergeReadinessQuery struct {
+	Repository struct {
+		PullRequest struct {
+			State            githubv4.PullRequestState
+			IsDraft          githubv4.Boolean
+			Mergeable        githubv4.MergeableState
+			MergeStateStatus githubv4.MergeStateStatus
+			ReviewDecision   githubv4.String
+			BaseRefName      githubv4.String
+			HeadRefOid       githubv4.GitObjectID
+			MergeQueueEntry  *struct {
+				Position githubv4.Int
+				State    githubv4.MergeQueueEntryState
+			}
+			AutoMergeRequest *struct {
+				MergeMethod githubv4.PullRequestMergeMethod
+				EnabledBy   *struct {
+					Login githubv4.String
+				}
+			}
+			ReviewThreads mergeReadinessThreads `graphql:"reviewThreads(first: 100)"`
+			Commits       struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts mergeReadinessContexts `graphql:"contexts(first: 100)"`
+						}
+					}
+				}
+			} `graphql:"commits(last: 1)"`
+			BaseRef *struct {
+				BranchProtectionRule *struct {
+					RequiresApprovingReviews       githubv4.Boolean
+					RequiredApprovingReviewCount   githubv4.Int
+					RequiresCodeOwnerReviews       githubv4.Boolean
+					RequiresStrictStatusChecks     githubv4.Boolean
+					RequiresConversationResolution githubv4.Boolean
+				}
+				Rules mergeReadinessRules `graphql:"rules(first: 100)"`
+			}
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// mergeReadinessThreadsQuery selects the following review threads of a pull request.
+type mergeReadinessThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads mergeReadinessThreads `graphql:"reviewThreads(first: 100, after: $after)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// mergeReadinessContextsQuery selects the following status check contexts of the head of a pull request.
+type mergeReadinessContextsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts mergeReadinessContexts `graphql:"contexts(first: 100, after: $after)"`
+						}
+					}
+				}
+			} `graphql:"commits(last: 1)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// mergeReadinessRulesQuery selects the following rules applying to the base branch of a pull request.
+type mergeReadinessRulesQuery struct {
+	Repository struct {
+		PullRequest struct {
+			BaseRef *struct {
+				Rules mergeReadinessRules `graphql:"rules(first: 100, after: $after)"`
+			}
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// queryMergeReadiness runs a mergeReadinessQuery, and follows the connections it selects to their last page, so
+// that the verdict covers all review threads, status check contexts and rules.
+func queryMergeReadiness(ctx context.Context, client *githubv4.Client, vars map[string]any) (mergeReadinessQuery, error) {
+	var query mergeReadinessQuery
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return query, err
+	}
+	pr := &query.Repository.PullRequest
+	pageVars := func(after githubv4.String) map[string]any {
+		v := map[string]any{"after": after}
+		for k, value := range vars {
+			v[k] = value
+		}
+		return v
+	}
+
+	for info := pr.ReviewThreads.PageInfo; info.HasNextPage; {
+		var page mergeReadinessThreadsQuery
+		if err := client.Query(ctx, &page, pageVars(info.EndCursor)); err != nil {
+			return query, err
+		}
+		threads := page.Repository.PullRequest.ReviewThreads
+		pr.ReviewThreads.Nodes = append(pr.ReviewThreads.Nodes, threads.Nodes...)
+		info = threads.PageInfo
+	}
+
+	if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		contexts := &pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts
+		for info := contexts.PageInfo; info.HasNextPage; {
+			var page mergeReadinessContextsQuery
+			if err := client.Query(ctx, &page, pageVars(info.EndCursor)); err != nil {
+				return query, err
+			}
+			commits := page.Repository.PullRequest.Commits.Nodes
+			if len(commits) == 0 || commits[0].Commit.StatusCheckRollup == nil {
+				break
+			}
+			more := commits[0].Commit.StatusCheckRollup.Contexts
+			contexts.Nodes = append(contexts.Nodes, more.Nodes...)
+			info = more.PageInfo
+		}
+	}
+
+	if pr.BaseRef != nil {
+		for info := pr.BaseRef.Rules.PageInfo; info.HasNextPage; {
+			var page mergeReadinessRulesQuery
+			if err := client.Query(ctx, &page, pageVars(info.EndCursor)); err != nil {
+				return query, err
+			}
+			if page.Repository.PullRequest.BaseRef == nil {
+				break
+			}
+			rules := page.Repository.PullRequest.BaseRef.Rules
+			pr.BaseRef.Rules.Nodes = append(pr.BaseRef.Rules.Nodes, rules.Nodes...)
+			info = rules.PageInfo
+		}
+	}
+
+	return query, nil
+}
+
+// mergeReadiness works out the requirements to merge a pull request from the result of a mergeReadinessQuery.
+func mergeReadiness(query mergeReadinessQuery) MergeReadiness {
+	pr := query.Repository.PullRequest
+	result := MergeReadiness{
+		State:            string(pr.State),
+		HeadSHA:          string(pr.HeadRefOid),
+		BaseBranch:       string(pr.BaseRefName),
+		Mergeable:        string(pr.Mergeable),
+		MergeStateStatus: string(pr.MergeStateStatus),
+		ReviewDecision:   string(pr.ReviewDecision),
+	}
+	if pr.MergeQueueEntry != nil {
+		result.MergeQueueEntry = &MergeQueueEntry{
+			Position: int(pr.MergeQueueEntry.Position),
+			State:    string(pr.MergeQueueEntry.State),
+		}
+	}
+	if pr.AutoMergeRequest != nil {
+		result.AutoMerge = &AutoMerge{MergeMethod: string(pr.AutoMergeRequest.MergeMethod)}
+		if pr.AutoMergeRequest.EnabledBy != nil {
+			result.AutoMerge.EnabledBy = string(pr.AutoMergeRequest.EnabledBy.Login)
+		}
+	}
+
+	// Branch protection and rulesets can both require reviews, up to date branches and resolved conversations,
+	// in which case the strictest requirement wins.
+	var (
+		approvals           int
+		codeOwners          bool
+		strict              bool
+		resolveConversation bool
+	)
+	if pr.BaseRef != nil {
+		if rule := pr.BaseRef.BranchProtectionRule; rule != nil {
+			if rule.RequiresApprovingReviews {
+				approvals = int(rule.RequiredApprovingReviewCount)
+			}
+			codeOwners = bool(rule.RequiresCodeOwnerReviews)
+			strict = bool(rule.RequiresStrictStatusChecks)
+			resolveConversation = bool(rule.RequiresConversationResolution)
+		}
+		for _, rule := range pr.BaseRef.Rules.Nodes {
+			mergeRule := MergeRule{Type: strings.ToLower(string(rule.Type))}
+			if rule.RepositoryRuleset != nil {
+				mergeRule.Ruleset = string(rule.RepositoryRuleset.Name)
+			}
+			result.Rules = append(result.Rules, mergeRule)
+
+			switch rule.Type {
+			case githubv4.RepositoryRuleTypeMergeQueue:
+				result.RequiresMergeQueue = true
+			case githubv4.RepositoryRuleTypePullRequest:
+				params := rule.Parameters.PullRequestParameters
+				approvals = max(approvals, int(params.RequiredApprovingReviewCount))
+				codeOwners = codeOwners || bool(params.RequireCodeOwnerReview)
+				resolveConversation = resolveConversation || bool(params.RequiredReviewThreadResolution)
+			case githubv4.RepositoryRuleTypeRequiredStatusChecks:
+				strict = strict || bool(rule.Parameters.RequiredStatusChecksParameters.StrictRequiredStatusChecksPolicy)
+			}
+		}
+	}
+
+	add := func(name string, satisfied bool, detail string) {
+		result.Requirements = append(result.Requirements, MergeRequirement{Name: name, Satisfied: satisfied, Detail: detail})
+	}
+
+	if pr.IsDraft {
+		add("draft", false, "the pull request is a draft and has to be marked ready for review")
+	} else {
+		add("draft", true, "")
+	}
+
+	switch pr.Mergeable {
+	case githubv4.MergeableStateMergeable:
+		add("conflicts", true, "")
+	case githubv4.MergeableStateConflicting:
+		add("conflicts", false, fmt.Sprintf("the pull request has merge conflicts with %s", pr.BaseRefName))
+	default:
+		add("conflicts", false, "GitHub is still computing whether the pull request has merge conflicts, try again shortly")
+	}
+
+	if approvals > 0 || codeOwners || pr.ReviewDecision != "" {
+		var detail []string
+		if approvals > 0 {
+			detail = append(detail, fmt.Sprintf("%d approving review(s) required", approvals))
+		}
+		if codeOwners {
+			detail = append(detail, "review from code owners required")
+		}
+		switch githubv4.PullRequestReviewDecision(pr.ReviewDecision) {
+		case githubv4.PullRequestReviewDecisionChangesRequested:
+			detail = append(detail, "changes were requested")
+		case githubv4.PullRequestReviewDecisionReviewRequired:
+			detail = append(detail, "required reviews are missing")
+		}
+		satisfied := pr.ReviewDecision == "" || githubv4.PullRequestReviewDecision(pr.ReviewDecision) == githubv4.PullRequestReviewDecisionApproved
+		add("reviews", satisfied, strings.Join(detail, "; "))
+	}
+
+	var failing, pending []string
+	required := 0
+	if len(pr.Commits.Nodes) > 0 && pr.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		for _, c := range pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+			var name, state string
+			switch {
+			case c.Typename == "CheckRun" && bool(c.CheckRun.IsRequired):
+				name = string(c.CheckRun.Name)
+				state = checkRunState(strings.ToLower(string(c.CheckRun.Status)), strings.ToLower(string(c.CheckRun.Conclusion)))
+			case c.Typename == "StatusContext" && bool(c.StatusContext.IsRequired):
+				name = string(c.StatusContext.Context)
+				state = commitStatusState(strings.ToLower(string(c.StatusContext.State)))
+			default:
+				continue
+			}
+			required++
+			switch state {
+			case checkStateFailing:
+				failing = append(failing, name)
+			case checkStatePending:
+				pending = append(pending, name)
+			}
+		}
+	}
+	if required > 0 {
+		var detail []string
+		if len(failing) > 0 {
+			detail = append(detail, "failing: "+strings.Join(failing, ", "))
+		}
+		if len(pending) > 0 {
+			detail = append(detail, "pending: "+strings.Join(pending, ", "))
+		}
+		add("checks", len(failing) == 0 && len(pending) == 0, strings.Join(detail, "; "))
+	}
+
+	if strict {
+		if pr.MergeStateStatus == githubv4.MergeStateStatusBehind {
+			add("up_to_date", false, fmt.Sprintf("the branch is behind %s, which must be merged in first (see update_pull_request_branch)", pr.BaseRefName))
+		} else {
+			add("up_to_date", true, "")
+		}
+	}
+
+	if resolveConversation {
+		unresolved := 0
+		for _, thread := range pr.ReviewThreads.Nodes {
+			if !thread.IsResolved {
+				unresolved++
+			}
+		}
+		if unresolved > 0 {
+			add("conversation_resolution", false, fmt.Sprintf("%d review thread(s) are unresolved", unresolved))
+		} else {
+			add("conversation_resolution", true, "")
+		}
+	}
+
+	result.Ready = true
+	for _, r := range result.Requirements {
+		result.Ready = result.Ready && r.Satisfied
+	}
+	if result.Ready && pr.MergeStateStatus == githubv4.MergeStateStatusBlocked {
+		add("branch_protection", false, "merging is blocked by branch protection or rulesets for a reason not listed above, for example a required check that has not reported yet")
+		result.Ready = false
+	}
+
+	switch {
+	case pr.State == githubv4.PullRequestStateMerged:
+		result.Ready = false
+		result.NextStep = "the pull request is already merged"
+	case pr.State == githubv4.PullRequestStateClosed:
+		result.Ready = false
+		result.NextStep = "the pull request is closed"
+	case result.MergeQueueEntry != nil:
+		result.NextStep = fmt.Sprintf("the pull request is in the merge queue at position %d, remove it with dequeue_pull_request", result.MergeQueueEntry.Position)
+	case result.Ready && result.RequiresMergeQueue:
+		result.NextStep = "add the pull request to the merge queue with enqueue_pull_request"
+	case result.Ready:
+		result.NextStep = "merge the pull request with merge_pull_request"
+	case result.AutoMerge != nil:
+		result.NextStep = "auto-merge is enabled, so the pull request will be merged once the unsatisfied requirements are met"
+	default:
+		result.NextStep = "meet the unsatisfied requirements, or enable auto-merge with enable_pull_request_auto_merge to merge once they are met"
+	}
+
+	return result
+}
+
+// getPullRequestNodeID returns the GraphQL node ID of a pull request.
+func getPullRequestNodeID(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int32) (githubv4.ID, error) {
+	var query struct {
+		Repository struct {
+			PullRequest struct {
+				ID githubv4.ID
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(pullNumber),
+	}
+
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+
+	return query.Repository.PullRequest.ID, nil
+}
+
+// GetPullRequestMergeReadiness creates a tool to explain whether a pull request can be merged.
+func GetPullRequestMergeReadiness(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_merge_readiness",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_MERGE_READINESS_DESCRIPTION", "Explain whether a pull request can be merged: draft state, merge conflicts, required reviews, required checks, whether the branch has to be up to date, conversation resolution and the rulesets applying to the base branch. Also reports whether the base branch requires a merge queue, the pull request's position in it and whether auto-merge is enabled, along with the next step to take. Check this before merging.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_MERGE_READINESS_USER_TITLE", "Get pull request merge readiness"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			WithOutputSchema[MergeReadiness](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			vars := map[string]any{
+				"owner": githubv4.String(params.Owner),
+				"repo":  githubv4.String(params.Repo),
+				"prNum": githubv4.Int(params.PullNumber),
+			}
+			query, err := queryMergeReadiness(ctx, client, vars)
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return structuredTextResult(mergeReadiness(query)), nil
+		}
+}
+
+// EnqueuePullRequest creates a tool to add a pull request to the merge queue of its base branch.
+func EnqueuePullRequest(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("enqueue_pull_request",
+			mcp.WithDescription(t("TOOL_ENQUEUE_PULL_REQUEST_DESCRIPTION", "Add a pull request to the merge queue of its base branch. Use this instead of merge_pull_request when the base branch requires a merge queue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ENQUEUE_PULL_REQUEST_USER_TITLE", "Add pull request to merge queue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("jump",
+				mcp.Description("Add the pull request to the front of the queue, if allowed"),
+			),
+			mcp.WithString("expectedHeadSha",
+				mcp.Description("Only enqueue if the head of the pull request is still this commit SHA"),
+			),
+			WithOutputSchema[MergeQueueEntry](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner           string
+				Repo            string
+				PullNumber      int32
+				Jump            bool
+				ExpectedHeadSha string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			var mutation struct {
+				EnqueuePullRequest struct {
+					MergeQueueEntry struct {
+						Position githubv4.Int
+						State    githubv4.MergeQueueEntryState
+					}
+				} `graphql:"enqueuePullRequest(input: $input)"`
+			}
+
+			input := githubv4.EnqueuePullRequestInput{
+				PullRequestID:   id,
+				ExpectedHeadOid: newGQLStringlike[githubv4.GitObjectID](params.ExpectedHeadSha),
+			}
+			if params.Jump {
+				input.Jump = githubv4.NewBoolean(true)
+			}
+
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return structuredTextResult(MergeQueueEntry{
+				Position: int(mutation.EnqueuePullRequest.MergeQueueEntry.Position),
+				State:    string(mutation.EnqueuePullRequest.MergeQueueEntry.State),
+			}), nil
+		}
+}
+
+// DequeuePullRequest creates a tool to remove a pull request from the merge queue.
+func DequeuePullRequest(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("dequeue_pull_request",
+			mcp.WithDescription(t("TOOL_DEQUEUE_PULL_REQUEST_DESCRIPTION", "Remove a pull request from the merge queue of its base branch.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DEQUEUE_PULL_REQUEST_USER_TITLE", "Remove pull request from merge queue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			var mutation struct {
+				DequeuePullRequest struct {
+					MergeQueueEntry struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"dequeuePullRequest(input: $input)"`
+			}
+
+			if err := client.Mutate(ctx, &mutation, githubv4.DequeuePullRequestInput{ID: id}, nil); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return actionResult("pull request removed from the merge queue"), nil
+		}
+}
+
+// EnablePullRequestAutoMerge creates a tool to merge a pull request automatically once its requirements are met.
+func EnablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("enable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Enable auto-merge on a pull request, so that it is merged, or added to the merge queue, as soon as all of its merge requirements are met.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Enable pull request auto-merge"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("mergeMethod",
+				mcp.Description("Merge method. Ignored when the base branch uses a merge queue"),
+				mcp.Enum("merge", "squash", "rebase"),
+			),
+			mcp.WithString("commitHeadline",
+				mcp.Description("Title for the merge commit. Ignored when the base branch uses a merge queue"),
+			),
+			mcp.WithString("commitBody",
+				mcp.Description("Body for the merge commit. Ignored when the base branch uses a merge queue"),
+			),
+			mcp.WithString("expectedHeadSha",
+				mcp.Description("Only enable auto-merge if the head of the pull request is still this commit SHA"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner           string
+				Repo            string
+				PullNumber      int32
+				MergeMethod     string
+				CommitHeadline  string
+				CommitBody      string
+				ExpectedHeadSha string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			var mutation struct {
+				EnablePullRequestAutoMerge struct {
+					PullRequest struct {
+						AutoMergeRequest *struct {
+							MergeMethod githubv4.PullRequestMergeMethod
+						}
+					}
+				} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+			}
+
+			input := githubv4.EnablePullRequestAutoMergeInput{
+				PullRequestID:   id,
+				MergeMethod:     newGQLStringlike[githubv4.PullRequestMergeMethod](strings.ToUpper(params.MergeMethod)),
+				CommitHeadline:  newGQLStringlike[githubv4.String](params.CommitHeadline),
+				CommitBody:      newGQLStringlike[githubv4.String](params.CommitBody),
+				ExpectedHeadOid: newGQLStringlike[githubv4.GitObjectID](params.ExpectedHeadSha),
+			}
+
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			method := githubv4.PullRequestMergeMethodMerge
+			if autoMerge := mutation.EnablePullRequestAutoMerge.PullRequest.AutoMergeRequest; autoMerge != nil {
+				method = autoMerge.MergeMethod
+			}
+
+			return actionResult(fmt.Sprintf("auto-merge enabled with merge method %s", strings.ToLower(string(method)))), nil
+		}
+}
+
+// DisablePullRequestAutoMerge creates a tool to turn off auto-merge on a pull request.
+func DisablePullRequestAutoMerge(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("disable_pull_request_auto_merge",
+			mcp.WithDescription(t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Disable auto-merge on a pull request.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Disable pull request auto-merge"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			id, err := getPullRequestNodeID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			var mutation struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+			}
+
+			if err := client.Mutate(ctx, &mutation, githubv4.DisablePullRequestAutoMergeInput{PullRequestID: id}, nil); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return actionResult("auto-merge disabled"), nil
+		}
+}
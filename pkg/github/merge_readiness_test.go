@@ -0,0 +1,559 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pullRequestNodeIDQuery(owner, repo string, prNum int32, id string) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				PullRequest struct {
+					ID githubv4.ID
+				} `graphql:"pullRequest(number: $prNum)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner": githubv4.String(owner),
+			"repo":  githubv4.String(repo),
+			"prNum": githubv4.Int(prNum),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"id": id,
+				},
+			},
+		}),
+	)
+}
+
+func Test_GetPullRequestMergeReadiness(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetPullRequestMergeReadiness(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_pull_request_merge_readiness", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	vars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+
+	pullRequest := func(fields map[string]any) githubv4mock.GQLResponse {
+		pr := map[string]any{
+			"state":            "OPEN",
+			"isDraft":          false,
+			"mergeable":        "MERGEABLE",
+			"mergeStateStatus": "CLEAN",
+			"reviewDecision":   nil,
+			"baseRefName":      "main",
+			"headRefOid":       "abcd1234",
+			"mergeQueueEntry":  nil,
+			"autoMergeRequest": nil,
+			"reviewThreads":    map[string]any{"nodes": []any{}},
+			"commits":          map[string]any{"nodes": []any{}},
+			"baseRef":          nil,
+		}
+		for k, v := range fields {
+			pr[k] = v
+		}
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"pullRequest": pr},
+		})
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		expectToolError    bool
+		expectedToolErrMsg string
+		verify             func(t *testing.T, readiness MergeReadiness)
+	}{
+		{
+			name: "ready to merge through a merge queue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, pullRequest(map[string]any{
+					"reviewDecision": "APPROVED",
+					"commits": map[string]any{
+						"nodes": []any{
+							map[string]any{
+								"commit": map[string]any{
+									"statusCheckRollup": map[string]any{
+										"contexts": map[string]any{
+											"nodes": []any{
+												map[string]any{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS", "isRequired": true},
+												map[string]any{"__typename": "StatusContext", "context": "lint", "state": "FAILURE", "isRequired": false},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					"baseRef": map[string]any{
+						"branchProtectionRule": nil,
+						"rules": map[string]any{
+							"nodes": []any{
+								map[string]any{
+									"type":              "PULL_REQUEST",
+									"repositoryRuleset": map[string]any{"name": "main"},
+									"parameters":        map[string]any{"requiredApprovingReviewCount": 1, "requireCodeOwnerReview": false, "requiredReviewThreadResolution": false},
+								},
+								map[string]any{
+									"type":              "MERGE_QUEUE",
+									"repositoryRuleset": map[string]any{"name": "main"},
+									"parameters":        map[string]any{},
+								},
+							},
+						},
+					},
+				})),
+			),
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.True(t, readiness.Ready)
+				assert.True(t, readiness.RequiresMergeQueue)
+				assert.Equal(t, []MergeRequirement{
+					{Name: "draft", Satisfied: true},
+					{Name: "conflicts", Satisfied: true},
+					{Name: "reviews", Satisfied: true, Detail: "1 approving review(s) required"},
+					{Name: "checks", Satisfied: true},
+				}, readiness.Requirements)
+				assert.Equal(t, []MergeRule{{Type: "pull_request", Ruleset: "main"}, {Type: "merge_queue", Ruleset: "main"}}, readiness.Rules)
+				assert.Equal(t, "add the pull request to the merge queue with enqueue_pull_request", readiness.NextStep)
+			},
+		},
+		{
+			name: "blocked by branch protection",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, pullRequest(map[string]any{
+					"isDraft":          true,
+					"mergeable":        "CONFLICTING",
+					"mergeStateStatus": "BEHIND",
+					"reviewDecision":   "CHANGES_REQUESTED",
+					"autoMergeRequest": map[string]any{"mergeMethod": "SQUASH", "enabledBy": map[string]any{"login": "octocat"}},
+					"reviewThreads": map[string]any{
+						"nodes": []any{
+							map[string]any{"isResolved": true},
+							map[string]any{"isResolved": false},
+						},
+					},
+					"commits": map[string]any{
+						"nodes": []any{
+							map[string]any{
+								"commit": map[string]any{
+									"statusCheckRollup": map[string]any{
+										"contexts": map[string]any{
+											"nodes": []any{
+												map[string]any{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "FAILURE", "isRequired": true},
+												map[string]any{"__typename": "StatusContext", "context": "deploy", "state": "PENDING", "isRequired": true},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					"baseRef": map[string]any{
+						"branchProtectionRule": map[string]any{
+							"requiresApprovingReviews":       true,
+							"requiredApprovingReviewCount":   2,
+							"requiresCodeOwnerReviews":       true,
+							"requiresStrictStatusChecks":     true,
+							"requiresConversationResolution": true,
+						},
+						"rules": map[string]any{"nodes": []any{}},
+					},
+				})),
+			),
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.False(t, readiness.Ready)
+				assert.Equal(t, []MergeRequirement{
+					{Name: "draft", Satisfied: false, Detail: "the pull request is a draft and has to be marked ready for review"},
+					{Name: "conflicts", Satisfied: false, Detail: "the pull request has merge conflicts with main"},
+					{Name: "reviews", Satisfied: false, Detail: "2 approving review(s) required; review from code owners required; changes were requested"},
+					{Name: "checks", Satisfied: false, Detail: "failing: build; pending: deploy"},
+					{Name: "up_to_date", Satisfied: false, Detail: "the branch is behind main, which must be merged in first (see update_pull_request_branch)"},
+					{Name: "conversation_resolution", Satisfied: false, Detail: "1 review thread(s) are unresolved"},
+				}, readiness.Requirements)
+				assert.Equal(t, &AutoMerge{MergeMethod: "SQUASH", EnabledBy: "octocat"}, readiness.AutoMerge)
+				assert.Equal(t, "auto-merge is enabled, so the pull request will be merged once the unsatisfied requirements are met", readiness.NextStep)
+			},
+		},
+		{
+			name: "review threads and checks beyond the first page",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, pullRequest(map[string]any{
+					"reviewThreads": map[string]any{
+						"nodes":    []any{map[string]any{"isResolved": true}},
+						"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "threads1"},
+					},
+					"commits": map[string]any{
+						"nodes": []any{
+							map[string]any{
+								"commit": map[string]any{
+									"statusCheckRollup": map[string]any{
+										"contexts": map[string]any{
+											"nodes": []any{
+												map[string]any{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS", "isRequired": true},
+											},
+											"pageInfo": map[string]any{"hasNextPage": true, "endCursor": "contexts1"},
+										},
+									},
+								},
+							},
+						},
+					},
+					"baseRef": map[string]any{
+						"branchProtectionRule": map[string]any{"requiresConversationResolution": true},
+						"rules":                map[string]any{"nodes": []any{}},
+					},
+				})),
+				githubv4mock.NewQueryMatcher(mergeReadinessThreadsQuery{}, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"prNum": githubv4.Int(42),
+					"after": githubv4.String("threads1"),
+				}, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"pullRequest": map[string]any{
+							"reviewThreads": map[string]any{
+								"nodes":    []any{map[string]any{"isResolved": false}},
+								"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "threads2"},
+							},
+						},
+					},
+				})),
+				githubv4mock.NewQueryMatcher(mergeReadinessContextsQuery{}, map[string]any{
+					"owner": githubv4.String("owner"),
+					"repo":  githubv4.String("repo"),
+					"prNum": githubv4.Int(42),
+					"after": githubv4.String("contexts1"),
+				}, githubv4mock.DataResponse(map[string]any{
+					"repository": map[string]any{
+						"pullRequest": map[string]any{
+							"commits": map[string]any{
+								"nodes": []any{
+									map[string]any{
+										"commit": map[string]any{
+											"statusCheckRollup": map[string]any{
+												"contexts": map[string]any{
+													"nodes": []any{
+														map[string]any{"__typename": "StatusContext", "context": "deploy", "state": "FAILURE", "isRequired": true},
+													},
+													"pageInfo": map[string]any{"hasNextPage": false, "endCursor": "contexts2"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})),
+			),
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.False(t, readiness.Ready)
+				assert.Equal(t, []MergeRequirement{
+					{Name: "draft", Satisfied: true},
+					{Name: "conflicts", Satisfied: true},
+					{Name: "checks", Satisfied: false, Detail: "failing: deploy"},
+					{Name: "conversation_resolution", Satisfied: false, Detail: "1 review thread(s) are unresolved"},
+				}, readiness.Requirements)
+			},
+		},
+		{
+			name: "blocked for an unlisted reason",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, pullRequest(map[string]any{
+					"mergeStateStatus": "BLOCKED",
+				})),
+			),
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.False(t, readiness.Ready)
+				require.Len(t, readiness.Requirements, 3)
+				assert.Equal(t, "branch_protection", readiness.Requirements[2].Name)
+				assert.False(t, readiness.Requirements[2].Satisfied)
+			},
+		},
+		{
+			name: "already in the merge queue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, pullRequest(map[string]any{
+					"mergeQueueEntry": map[string]any{"position": 3, "state": "AWAITING_CHECKS"},
+				})),
+			),
+			verify: func(t *testing.T, readiness MergeReadiness) {
+				assert.Equal(t, &MergeQueueEntry{Position: 3, State: "AWAITING_CHECKS"}, readiness.MergeQueueEntry)
+				assert.Equal(t, "the pull request is in the merge queue at position 3, remove it with dequeue_pull_request", readiness.NextStep)
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(mergeReadinessQuery{}, vars, githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 42.")),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "Could not resolve to a PullRequest with the number of 42.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestMergeReadiness(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			}))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var readiness MergeReadiness
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &readiness))
+			assert.Equal(t, "abcd1234", readiness.HeadSHA)
+			assert.Equal(t, "main", readiness.BaseBranch)
+			tc.verify(t, readiness)
+		})
+	}
+}
+
+func Test_EnqueuePullRequest(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := EnqueuePullRequest(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "enqueue_pull_request", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "jump")
+	assert.Contains(t, tool.InputSchema.Properties, "expectedHeadSha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				EnqueuePullRequest struct {
+					MergeQueueEntry struct {
+						Position githubv4.Int
+						State    githubv4.MergeQueueEntryState
+					}
+				} `graphql:"enqueuePullRequest(input: $input)"`
+			}{},
+			githubv4.EnqueuePullRequestInput{
+				PullRequestID:   githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+				Jump:            githubv4.NewBoolean(true),
+				ExpectedHeadOid: githubv4mock.Ptr(githubv4.GitObjectID("abcd1234")),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"enqueuePullRequest": map[string]any{
+					"mergeQueueEntry": map[string]any{"position": 1, "state": "QUEUED"},
+				},
+			}),
+		),
+	)
+
+	_, handler := EnqueuePullRequest(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":           "owner",
+		"repo":            "repo",
+		"pullNumber":      float64(42),
+		"jump":            true,
+		"expectedHeadSha": "abcd1234",
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+
+	var entry MergeQueueEntry
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &entry))
+	assert.Equal(t, MergeQueueEntry{Position: 1, State: "QUEUED"}, entry)
+}
+
+func Test_DequeuePullRequest(t *testing.T) {
+	t.Parallel()
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DequeuePullRequest struct {
+					MergeQueueEntry struct {
+						ID githubv4.ID
+					}
+				} `graphql:"dequeuePullRequest(input: $input)"`
+			}{},
+			githubv4.DequeuePullRequestInput{ID: githubv4.ID("PR_kwDODKw3uc6WYN1T")},
+			nil,
+			githubv4mock.ErrorResponse("Pull request is not in the merge queue"),
+		),
+	)
+
+	_, handler := DequeuePullRequest(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	require.True(t, result.IsError)
+	assert.Contains(t, textContent.Text, "Pull request is not in the merge queue")
+}
+
+func Test_EnablePullRequestAutoMerge(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := EnablePullRequestAutoMerge(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "enable_pull_request_auto_merge", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "mergeMethod")
+	assert.Contains(t, tool.InputSchema.Properties, "commitHeadline")
+	assert.Contains(t, tool.InputSchema.Properties, "commitBody")
+	assert.Contains(t, tool.InputSchema.Properties, "expectedHeadSha")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				EnablePullRequestAutoMerge struct {
+					PullRequest struct {
+						AutoMergeRequest *struct {
+							MergeMethod githubv4.PullRequestMergeMethod
+						}
+					}
+				} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+			}{},
+			githubv4.EnablePullRequestAutoMergeInput{
+				PullRequestID:  githubv4.ID("PR_kwDODKw3uc6WYN1T"),
+				MergeMethod:    githubv4mock.Ptr(githubv4.PullRequestMergeMethodSquash),
+				CommitHeadline: githubv4.NewString("Add merge queue support (#42)"),
+			},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"enablePullRequestAutoMerge": map[string]any{
+					"pullRequest": map[string]any{
+						"autoMergeRequest": map[string]any{"mergeMethod": "SQUASH"},
+					},
+				},
+			}),
+		),
+	)
+
+	_, handler := EnablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":          "owner",
+		"repo":           "repo",
+		"pullNumber":     float64(42),
+		"mergeMethod":    "squash",
+		"commitHeadline": "Add merge queue support (#42)",
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+	assert.Equal(t, "auto-merge enabled with merge method squash", textContent.Text)
+
+	// A pull request that doesn't exist is not found, rather than a validation error.
+	mockedClient = githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(999),
+			},
+			githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 999."),
+		),
+	)
+	_, handler = EnablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err = handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(999),
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	toolErr, ok := result.Meta["error"].(*ToolError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorNotFound, toolErr.Code)
+}
+
+func Test_DisablePullRequestAutoMerge(t *testing.T) {
+	t.Parallel()
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		pullRequestNodeIDQuery("owner", "repo", 42, "PR_kwDODKw3uc6WYN1T"),
+		githubv4mock.NewMutationMatcher(
+			struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest struct {
+						ID githubv4.ID
+					}
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+			}{},
+			githubv4.DisablePullRequestAutoMergeInput{PullRequestID: githubv4.ID("PR_kwDODKw3uc6WYN1T")},
+			nil,
+			githubv4mock.DataResponse(map[string]any{
+				"disablePullRequestAutoMerge": map[string]any{
+					"pullRequest": map[string]any{"id": "PR_kwDODKw3uc6WYN1T"},
+				},
+			}),
+		),
+	)
+
+	_, handler := DisablePullRequestAutoMerge(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	}))
+	require.NoError(t, err)
+
+	textContent := getTextResult(t, result)
+	require.False(t, result.IsError, textContent.Text)
+	assert.Equal(t, "auto-merge disabled", textContent.Text)
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MatchLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected []int
+	}{
+		{
+			name:     "identical",
+			a:        "a b c",
+			b:        "a b c",
+			expected: []int{0, 1, 2},
+		},
+		{
+			name:     "line inserted",
+			a:        "a c",
+			b:        "a b c",
+			expected: []int{0, 2},
+		},
+		{
+			name:     "line removed",
+			a:        "a b c",
+			b:        "a c",
+			expected: []int{0, -1, 1},
+		},
+		{
+			name:     "lines replaced and moved",
+			a:        "a b c d e f",
+			b:        "x b c y e f a",
+			expected: []int{-1, 1, 2, -1, 4, 5},
+		},
+		{
+			name:     "nothing in common",
+			a:        "a b",
+			b:        "c d",
+			expected: []int{-1, -1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchLines(strings.Fields(tc.a), strings.Fields(tc.b)))
+		})
+	}
+}
+
+func Test_MergeLines(t *testing.T) {
+	base := "package main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n"
+
+	tests := []struct {
+		name              string
+		yours             string
+		upstream          string
+		expectedMerged    string
+		expectedConflicts []MergeConflict
+	}{
+		{
+			name:           "changes to different lines",
+			yours:          "package main\n\nfunc a() { println() }\n\nfunc b() {}\n\nfunc c() {}\n",
+			upstream:       "package main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() { panic() }\n",
+			expectedMerged: "package main\n\nfunc a() { println() }\n\nfunc b() {}\n\nfunc c() { panic() }\n",
+		},
+		{
+			name:           "insertions on both sides",
+			yours:          "// Package main is a test.\npackage main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n",
+			upstream:       "package main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n\nfunc d() {}\n",
+			expectedMerged: "// Package main is a test.\npackage main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n\nfunc d() {}\n",
+		},
+		{
+			name:           "same change on both sides",
+			yours:          "package main\n\nfunc a() {}\n\nfunc c() {}\n",
+			upstream:       "package main\n\nfunc a() {}\n\nfunc c() {}\n",
+			expectedMerged: "package main\n\nfunc a() {}\n\nfunc c() {}\n",
+		},
+		{
+			name:           "conflicting changes",
+			yours:          "package main\n\nfunc a() {}\n\nfunc b() { return }\n\nfunc c() { println() }\n",
+			upstream:       "package main\n\nfunc a() {}\n\nfunc b() { panic() }\n\nfunc c() {}\n",
+			expectedMerged: "package main\n\nfunc a() {}\n\nfunc b() { panic() }\n\nfunc c() { println() }\n",
+			expectedConflicts: []MergeConflict{
+				{
+					Line:     5,
+					Base:     []string{"func b() {}"},
+					Yours:    []string{"func b() { return }"},
+					Upstream: []string{"func b() { panic() }"},
+				},
+			},
+		},
+		{
+			name:           "missing newline at end of file",
+			yours:          "package main\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}",
+			upstream:       "package lib\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n",
+			expectedMerged: "package lib\n\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, conflicts := mergeLines(base, tc.yours, tc.upstream)
+			assert.Equal(t, tc.expectedMerged, merged)
+			assert.Equal(t, tc.expectedConflicts, conflicts)
+		})
+	}
+}
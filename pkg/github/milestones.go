@@ -0,0 +1,403 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ListMilestones creates a tool to list the milestones of a repository.
+func ListMilestones(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_milestones",
+			mcp.WithDescription(t("TOOL_LIST_MILESTONES_DESCRIPTION", "List the milestones of a GitHub repository. Use this to find the milestone number expected by create_issue and update_issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_MILESTONES_USER_TITLE", "List milestones"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state, defaults to open"),
+				mcp.Enum("open", "closed", "all"),
+			),
+			mcp.WithString("sort",
+				mcp.Description("Sort by due date or by the proportion of closed issues"),
+				mcp.Enum("due_on", "completeness"),
+			),
+			mcp.WithString("direction",
+				mcp.Description("Sort direction"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*github.Milestone]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			direction, err := OptionalParam[string](request, "direction")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.MilestoneListOptions{
+				State:     state,
+				Sort:      sort,
+				Direction: direction,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			milestones, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Milestone, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.Issues.ListMilestones(ctx, owner, repo, opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list milestones: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list milestones"), nil
+			}
+
+			return projectedListResult(request, milestones, listResult(milestones, next), next), nil
+		}
+}
+
+// GetMilestone creates a tool to get a single milestone of a repository.
+func GetMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("get_milestone",
+			mcp.WithDescription(t("TOOL_GET_MILESTONE_DESCRIPTION", "Get a milestone of a GitHub repository by number, including its open and closed issue counts.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_MILESTONE_USER_TITLE", "Get milestone"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+			WithOutputSchema[github.Milestone](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			milestone, resp, err := client.Issues.GetMilestone(ctx, owner, repo, number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get milestone: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get milestone"), nil
+			}
+
+			r, err := json.Marshal(milestone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), milestone), nil
+		}
+}
+
+// milestoneFromRequest builds a milestone holding only the fields present in the request.
+func milestoneFromRequest(request mcp.CallToolRequest) (*github.Milestone, error) {
+	milestone := &github.Milestone{}
+
+	title, err := OptionalParam[string](request, "title")
+	if err != nil {
+		return nil, err
+	}
+	if title != "" {
+		milestone.Title = github.Ptr(title)
+	}
+
+	description, ok, err := OptionalParamOK[string](request, "description")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		milestone.Description = github.Ptr(description)
+	}
+
+	state, err := OptionalParam[string](request, "state")
+	if err != nil {
+		return nil, err
+	}
+	if state != "" {
+		milestone.State = github.Ptr(state)
+	}
+
+	dueOn, err := OptionalParam[string](request, "due_on")
+	if err != nil {
+		return nil, err
+	}
+	if dueOn != "" {
+		dueTime, err := parseISOTimestamp(dueOn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse due_on: %w", err)
+		}
+		milestone.DueOn = &github.Timestamp{Time: dueTime}
+	}
+
+	return milestone, nil
+}
+
+// CreateMilestone creates a tool to create a milestone in a repository.
+func CreateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("create_milestone",
+			mcp.WithDescription(t("TOOL_CREATE_MILESTONE_DESCRIPTION", "Create a milestone in a GitHub repository.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_CREATE_MILESTONE_USER_TITLE", "Create milestone"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("title",
+				mcp.Required(),
+				mcp.Description("Milestone title"),
+			),
+			mcp.WithString("description",
+				mcp.Description("Milestone description"),
+			),
+			mcp.WithString("due_on",
+				mcp.Description("Due date in ISO 8601 format (YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ)"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Milestone state, defaults to open"),
+				mcp.Enum("open", "closed"),
+			),
+			WithOutputSchema[github.Milestone](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if _, err := requiredParam[string](request, "title"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			createdMilestone, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create milestone: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				return responseErrorResult(resp, "failed to create milestone"), nil
+			}
+
+			r, err := json.Marshal(createdMilestone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), createdMilestone), nil
+		}
+}
+
+// UpdateMilestone creates a tool to update a milestone in a repository.
+func UpdateMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("update_milestone",
+			mcp.WithDescription(t("TOOL_UPDATE_MILESTONE_DESCRIPTION", "Update a milestone in a GitHub repository, for example to move its due date or close it.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_UPDATE_MILESTONE_USER_TITLE", "Edit milestone"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+			mcp.WithString("title",
+				mcp.Description("New title"),
+			),
+			mcp.WithString("description",
+				mcp.Description("New description"),
+			),
+			mcp.WithString("due_on",
+				mcp.Description("New due date in ISO 8601 format (YYYY-MM-DD or YYYY-MM-DDThh:mm:ssZ)"),
+			),
+			mcp.WithString("state",
+				mcp.Description("New state"),
+				mcp.Enum("open", "closed"),
+			),
+			WithOutputSchema[github.Milestone](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			milestone, err := milestoneFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			updatedMilestone, resp, err := client.Issues.EditMilestone(ctx, owner, repo, number, milestone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update milestone: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to update milestone"), nil
+			}
+
+			r, err := json.Marshal(updatedMilestone)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), updatedMilestone), nil
+		}
+}
+
+// DeleteMilestone creates a tool to delete a milestone from a repository.
+func DeleteMilestone(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("delete_milestone",
+			mcp.WithDescription(t("TOOL_DELETE_MILESTONE_DESCRIPTION", "Delete a milestone from a GitHub repository. Its issues and pull requests are kept but no longer belong to a milestone.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_DELETE_MILESTONE_USER_TITLE", "Delete milestone"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("milestone_number",
+				mcp.Required(),
+				mcp.Description("Milestone number"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			number, err := RequiredInt(request, "milestone_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Issues.DeleteMilestone(ctx, owner, repo, number)
+			if err != nil {
+				return nil, fmt.Errorf("failed to delete milestone: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return responseErrorResult(resp, "failed to delete milestone"), nil
+			}
+
+			return actionResult(fmt.Sprintf("milestone %d deleted from %s/%s", number, owner, repo)), nil
+		}
+}
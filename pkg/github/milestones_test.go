@@ -0,0 +1,389 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListMilestones(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListMilestones(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_milestones", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "sort")
+	assert.Contains(t, tool.InputSchema.Properties, "direction")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo"})
+
+	mockMilestones := []*github.Milestone{
+		{Number: github.Ptr(1), Title: github.Ptr("v1.0"), State: github.Ptr("closed")},
+		{Number: github.Ptr(2), Title: github.Ptr("v1.1"), State: github.Ptr("open")},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]interface{}
+		expectError        bool
+		expectedMilestones []*github.Milestone
+		expectedErrMsg     string
+	}{
+		{
+			name: "all milestones by completeness",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepo,
+					expectQueryParams(t, map[string]string{
+						"state":     "all",
+						"sort":      "completeness",
+						"direction": "desc",
+						"page":      "1",
+						"per_page":  "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockMilestones),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":     "owner",
+				"repo":      "repo",
+				"state":     "all",
+				"sort":      "completeness",
+				"direction": "desc",
+			},
+			expectedMilestones: mockMilestones,
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposMilestonesByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list milestones",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListMilestones(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedMilestones []*github.Milestone
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMilestones)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMilestones, returnedMilestones)
+		})
+	}
+}
+
+func Test_GetMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "milestone_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+
+	mockMilestone := &github.Milestone{
+		Number:       github.Ptr(3),
+		Title:        github.Ptr("v2.0"),
+		OpenIssues:   github.Ptr(4),
+		ClosedIssues: github.Ptr(8),
+	}
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposMilestonesByOwnerByRepoByMilestoneNumber,
+			expectPath(t, "/repos/owner/repo/milestones/3").andThen(
+				mockResponse(t, http.StatusOK, mockMilestone),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := GetMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"milestone_number": float64(3),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedMilestone github.Milestone
+	err = json.Unmarshal([]byte(textContent.Text), &returnedMilestone)
+	require.NoError(t, err)
+	assert.Equal(t, mockMilestone, &returnedMilestone)
+}
+
+func Test_CreateMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := CreateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "create_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "title")
+	assert.Contains(t, tool.InputSchema.Properties, "description")
+	assert.Contains(t, tool.InputSchema.Properties, "due_on")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "title"})
+
+	dueOn := &github.Timestamp{Time: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]interface{}
+		expectToolError   bool
+		expectedMilestone *github.Milestone
+		expectedErrMsg    string
+	}{
+		{
+			name: "milestone with a due date",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposMilestonesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"title":       "v3.0",
+						"description": "Next major release",
+						"due_on":      "2025-07-01T00:00:00Z",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Milestone{
+							Number: github.Ptr(5),
+							Title:  github.Ptr("v3.0"),
+							DueOn:  dueOn,
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":       "owner",
+				"repo":        "repo",
+				"title":       "v3.0",
+				"description": "Next major release",
+				"due_on":      "2025-07-01",
+			},
+			expectedMilestone: &github.Milestone{
+				Number: github.Ptr(5),
+				Title:  github.Ptr("v3.0"),
+				DueOn:  dueOn,
+			},
+		},
+		{
+			name:         "invalid due date",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"title":  "v3.0",
+				"due_on": "next week",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "failed to parse due_on",
+		},
+		{
+			name:         "missing title",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: title",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedMilestone github.Milestone
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMilestone)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMilestone.GetNumber(), returnedMilestone.GetNumber())
+			assert.Equal(t, tc.expectedMilestone.GetTitle(), returnedMilestone.GetTitle())
+			assert.True(t, tc.expectedMilestone.GetDueOn().Equal(returnedMilestone.GetDueOn()))
+		})
+	}
+}
+
+func Test_UpdateMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := UpdateMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "update_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "milestone_number")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedState  string
+		expectedErrMsg string
+	}{
+		{
+			name: "close milestone",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					expectRequestBody(t, map[string]interface{}{
+						"state": "closed",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Milestone{
+							Number: github.Ptr(2),
+							State:  github.Ptr("closed"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(2),
+				"state":            "closed",
+			},
+			expectedState: "closed",
+		},
+		{
+			name: "milestone not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PatchReposMilestonesByOwnerByRepoByMilestoneNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"milestone_number": float64(99),
+				"title":            "renamed",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to update milestone",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := UpdateMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedMilestone github.Milestone
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMilestone)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedState, returnedMilestone.GetState())
+		})
+	}
+}
+
+func Test_DeleteMilestone(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := DeleteMilestone(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "delete_milestone", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "milestone_number"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposMilestonesByOwnerByRepoByMilestoneNumber,
+			expectPath(t, "/repos/owner/repo/milestones/4").andThen(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				},
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := DeleteMilestone(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"milestone_number": float64(4),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	assert.Equal(t, "milestone 4 deleted from owner/repo", textContent.Text)
+}
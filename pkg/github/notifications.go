@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -46,8 +45,16 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are listed."),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedNotification]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -86,10 +93,6 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 			opts := &github.NotificationListOptions{
 				All:           filter == FilterIncludeRead,
 				Participating: filter == FilterOnlyParticipating,
-				ListOptions: github.ListOptions{
-					Page:    paginationParams.page,
-					PerPage: paginationParams.perPage,
-				},
 			}
 
 			// Parse time parameters if provided
@@ -109,34 +112,29 @@ func ListNotifications(getClient GetClientFn, t translations.TranslationHelperFu
 				opts.Before = beforeTime
 			}
 
-			var notifications []*github.Notification
-			var resp *github.Response
-
-			if owner != "" && repo != "" {
-				notifications, resp, err = client.Activity.ListRepositoryNotifications(ctx, owner, repo, opts)
-			} else {
-				notifications, resp, err = client.Activity.ListNotifications(ctx, opts)
-			}
+			notifications, resp, next, err := collectPages(ctx, paginationParams, func(listOpts github.ListOptions) ([]*github.Notification, *github.Response, error) {
+				opts.ListOptions = listOpts
+				if owner != "" && repo != "" {
+					return client.Activity.ListRepositoryNotifications(ctx, owner, repo, opts)
+				}
+				return client.Activity.ListNotifications(ctx, opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get notifications: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get notifications: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get notifications"), nil
 			}
 
 			// Marshal response to JSON
-			r, err := json.Marshal(notifications)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			structured := listResult(cleanNotifications(notifications), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderNotifications(notifications)), next), structured), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, notifications, cleanNotifications), structured, next), nil
 		}
 }
 
@@ -153,6 +151,7 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 				mcp.Description("The ID of the notification thread"),
 			),
 			mcp.WithString("state", mcp.Description("The new state of the notification (read/done)"), mcp.Enum("read", "done")),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getclient(ctx)
@@ -192,14 +191,10 @@ func DismissNotification(getclient GetClientFn, t translations.TranslationHelper
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusResetContent && resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark notification as %s: %s", state, string(body))), nil
+				return responseErrorResult(resp, fmt.Sprintf("failed to mark notification as %s", state)), nil
 			}
 
-			return mcp.NewToolResultText(fmt.Sprintf("Notification marked as %s", state)), nil
+			return actionResult(fmt.Sprintf("Notification marked as %s", state)), nil
 		}
 }
 
@@ -220,6 +215,7 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 			mcp.WithString("repo",
 				mcp.Description("Optional repository name. If provided with owner, only notifications for this repository are marked as read."),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -267,14 +263,10 @@ func MarkAllNotificationsRead(getClient GetClientFn, t translations.TranslationH
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusResetContent && resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to mark all notifications as read: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to mark all notifications as read"), nil
 			}
 
-			return mcp.NewToolResultText("All notifications marked as read"), nil
+			return actionResult("All notifications marked as read"), nil
 		}
 }
 
@@ -290,8 +282,16 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 				mcp.Required(),
 				mcp.Description("The ID of the notification"),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedNotification](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -309,19 +309,24 @@ func GetNotificationDetails(getClient GetClientFn, t translations.TranslationHel
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get notification details: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get notification details"), nil
+			}
+
+			structured := cleanNotification(thread)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderNotification(thread)), structured), nil
 			}
 
-			r, err := json.Marshal(thread)
+			projected, err := projectResult(request, compactOr(ctx, thread, cleanNotification))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -349,6 +354,7 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 				mcp.Description("Action to perform: ignore, watch, or delete the notification subscription."),
 				mcp.Enum(NotificationActionIgnore, NotificationActionWatch, NotificationActionDelete),
 			),
+			WithOutputSchema[github.Subscription](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -390,20 +396,19 @@ func ManageNotificationSubscription(getClient GetClientFn, t translations.Transl
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-				body, _ := io.ReadAll(resp.Body)
-				return mcp.NewToolResultError(fmt.Sprintf("failed to %s notification subscription: %s", action, string(body))), nil
+				return responseErrorResult(resp, fmt.Sprintf("failed to %s notification subscription", action)), nil
 			}
 
 			if action == NotificationActionDelete {
-				// Special case for delete as there is no response body
-				return mcp.NewToolResultText("Notification subscription deleted"), nil
+				// Special case for delete as there is no response body, and no subscription left
+				return structuredResult(mcp.NewToolResultText("Notification subscription deleted"), &github.Subscription{}), nil
 			}
 
 			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), result), nil
 		}
 }
 
@@ -434,6 +439,7 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 				mcp.Description("Action to perform: ignore, watch, or delete the repository notification subscription."),
 				mcp.Enum(RepositorySubscriptionActionIgnore, RepositorySubscriptionActionWatch, RepositorySubscriptionActionDelete),
 			),
+			WithOutputSchema[github.Subscription](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			client, err := getClient(ctx)
@@ -482,19 +488,18 @@ func ManageRepositoryNotificationSubscription(getClient GetClientFn, t translati
 
 			// Handle non-2xx status codes
 			if resp != nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
-				body, _ := io.ReadAll(resp.Body)
-				return mcp.NewToolResultError(fmt.Sprintf("failed to %s repository subscription: %s", action, string(body))), nil
+				return responseErrorResult(resp, fmt.Sprintf("failed to %s repository subscription", action)), nil
 			}
 
 			if action == RepositorySubscriptionActionDelete {
-				// Special case for delete as there is no response body
-				return mcp.NewToolResultText("Repository subscription deleted"), nil
+				// Special case for delete as there is no response body, and no subscription left
+				return structuredResult(mcp.NewToolResultText("Repository subscription deleted"), &github.Subscription{}), nil
 			}
 
 			r, err := json.Marshal(result)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), result), nil
 		}
 }
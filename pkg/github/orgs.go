@@ -0,0 +1,724 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+)
+
+type MinimalOrganization struct {
+	Login       string `json:"login"`
+	ID          int64  `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+	ProfileURL  string `json:"profile_url,omitempty"`
+}
+
+type MinimalTeam struct {
+	ID          int64  `json:"id,omitempty"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Privacy     string `json:"privacy,omitempty"`
+	// ParentSlug is set for nested teams.
+	ParentSlug string `json:"parent_slug,omitempty"`
+}
+
+type MinimalTeamRepository struct {
+	FullName   string `json:"full_name"`
+	Private    bool   `json:"private"`
+	Permission string `json:"permission,omitempty"`
+}
+
+type MinimalOrgMember struct {
+	Login string `json:"login"`
+	Name  string `json:"name,omitempty"`
+	// Role is either ADMIN (an organization owner) or MEMBER.
+	Role string `json:"role"`
+}
+
+type OrgMembersResult struct {
+	TotalCount int                `json:"total_count"`
+	Members    []MinimalOrgMember `json:"members"`
+	// NextCursor is the cursor to the following members, if any.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func minimalUsers(users []*github.User) []MinimalUser {
+	minimal := make([]MinimalUser, 0, len(users))
+	for _, user := range users {
+		minimal = append(minimal, MinimalUser{
+			Login:      user.GetLogin(),
+			ID:         user.GetID(),
+			ProfileURL: user.GetHTMLURL(),
+			AvatarURL:  user.GetAvatarURL(),
+		})
+	}
+	return minimal
+}
+
+func minimalTeams(teams []*github.Team) []MinimalTeam {
+	minimal := make([]MinimalTeam, 0, len(teams))
+	for _, team := range teams {
+		minimal = append(minimal, MinimalTeam{
+			ID:          team.GetID(),
+			Slug:        team.GetSlug(),
+			Name:        team.GetName(),
+			Description: team.GetDescription(),
+			Privacy:     team.GetPrivacy(),
+			ParentSlug:  team.GetParent().GetSlug(),
+		})
+	}
+	return minimal
+}
+
+// teamRepositoryPermission returns the highest permission a team has on a repository.
+func teamRepositoryPermission(repo *github.Repository) string {
+	if repo.GetRoleName() != "" {
+		return repo.GetRoleName()
+	}
+	for _, permission := range []string{"admin", "maintain", "push", "triage", "pull"} {
+		if repo.Permissions[permission] {
+			return permission
+		}
+	}
+	return ""
+}
+
+// ListUserOrganizations creates a tool to list the organizations a user belongs to.
+func ListUserOrganizations(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_user_organizations",
+			mcp.WithDescription(t("TOOL_LIST_USER_ORGANIZATIONS_DESCRIPTION", "List the organizations a GitHub user is a member of. Defaults to the authenticated user.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_USER_ORGANIZATIONS_USER_TITLE", "List user organizations"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("username",
+				mcp.Description("The GitHub username. Omit to list the organizations of the authenticated user, including private memberships"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[MinimalOrganization]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			username, err := OptionalParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			orgs, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Organization, *github.Response, error) {
+				return client.Organizations.List(ctx, username, &opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list organizations: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list organizations"), nil
+			}
+
+			minimalOrgs := make([]MinimalOrganization, 0, len(orgs))
+			for _, org := range orgs {
+				minimalOrgs = append(minimalOrgs, MinimalOrganization{
+					Login:       org.GetLogin(),
+					ID:          org.GetID(),
+					Description: org.GetDescription(),
+					ProfileURL:  org.GetHTMLURL(),
+				})
+			}
+
+			return projectedListResult(request, minimalOrgs, listResult(minimalOrgs, next), next), nil
+		}
+}
+
+// ListOrgTeams creates a tool to list the teams in an organization.
+func ListOrgTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_teams",
+			mcp.WithDescription(t("TOOL_LIST_ORG_TEAMS_DESCRIPTION", "List the teams in a GitHub organization that are visible to the authenticated user. Use the returned slugs wherever a team is expected, such as team reviewers, rather than guessing them from the team name.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_TEAMS_USER_TITLE", "List organization teams"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[MinimalTeam]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			teams, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Team, *github.Response, error) {
+				return client.Teams.ListTeams(ctx, org, &opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list teams: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list teams"), nil
+			}
+
+			items := minimalTeams(teams)
+			return projectedListResult(request, items, listResult(items, next), next), nil
+		}
+}
+
+// ListChildTeams creates a tool to list the teams nested directly under a team.
+func ListChildTeams(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_child_teams",
+			mcp.WithDescription(t("TOOL_LIST_CHILD_TEAMS_DESCRIPTION", "List the teams nested directly under a team in a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_CHILD_TEAMS_USER_TITLE", "List child teams"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("The slug of the parent team"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[MinimalTeam]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			teams, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Team, *github.Response, error) {
+				return client.Teams.ListChildTeamsByParentSlug(ctx, org, teamSlug, &opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list child teams: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list child teams"), nil
+			}
+
+			items := minimalTeams(teams)
+			return projectedListResult(request, items, listResult(items, next), next), nil
+		}
+}
+
+// ListTeamMembers creates a tool to list the members of a team.
+func ListTeamMembers(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_members",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_MEMBERS_DESCRIPTION", "List the members of a team in a GitHub organization, including members of its child teams.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_MEMBERS_USER_TITLE", "List team members"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("The slug of the team"),
+			),
+			mcp.WithString("role",
+				mcp.Description("Filter members by their role in the team"),
+				mcp.Enum("all", "member", "maintainer"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[MinimalUser]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.TeamListTeamMembersOptions{
+				Role: role,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			members, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.User, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.Teams.ListTeamMembersBySlug(ctx, org, teamSlug, opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list team members: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list team members"), nil
+			}
+
+			items := minimalUsers(members)
+			return projectedListResult(request, items, listResult(items, next), next), nil
+		}
+}
+
+// ListTeamRepositories creates a tool to list the repositories a team has access to.
+func ListTeamRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_team_repositories",
+			mcp.WithDescription(t("TOOL_LIST_TEAM_REPOSITORIES_DESCRIPTION", "List the repositories a team in a GitHub organization has access to, along with the team's permission on each.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_TEAM_REPOSITORIES_USER_TITLE", "List team repositories"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("The slug of the team"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[MinimalTeamRepository]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			repos, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+				return client.Teams.ListTeamReposBySlug(ctx, org, teamSlug, &opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list team repositories: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list team repositories"), nil
+			}
+
+			minimalRepos := make([]MinimalTeamRepository, 0, len(repos))
+			for _, repo := range repos {
+				minimalRepos = append(minimalRepos, MinimalTeamRepository{
+					FullName:   repo.GetFullName(),
+					Private:    repo.GetPrivate(),
+					Permission: teamRepositoryPermission(repo),
+				})
+			}
+
+			return projectedListResult(request, minimalRepos, listResult(minimalRepos, next), next), nil
+		}
+}
+
+// ListOrgMembers creates a tool to list the members of an organization along with their roles.
+func ListOrgMembers(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_org_members",
+			mcp.WithDescription(t("TOOL_LIST_ORG_MEMBERS_DESCRIPTION", "List the members of a GitHub organization along with their role (ADMIN for owners, MEMBER otherwise). Results are paginated with a cursor: pass the returned next_cursor as 'cursor' to fetch the next page.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_MEMBERS_USER_TITLE", "List organization members"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			WithCursorPagination(),
+			WithFields(),
+			WithOutputSchema[OrgMembersResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Org string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Org == "" {
+				return mcp.NewToolResultError("missing required parameter: org"), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			type memberEdge struct {
+				Role githubv4.OrganizationMemberRole
+				Node struct {
+					Login githubv4.String
+					Name  githubv4.String
+				}
+			}
+			var totalCount int
+			edges, next, err := collectGQLPages(ctx, pagination, func(first int, after string) ([]memberEdge, gqlPageInfo, error) {
+				var query struct {
+					Organization struct {
+						MembersWithRole struct {
+							TotalCount int
+							PageInfo   struct {
+								HasNextPage bool
+								EndCursor   string
+							}
+							Edges []memberEdge
+						} `graphql:"membersWithRole(first: $first, after: $after)"`
+					} `graphql:"organization(login: $org)"`
+				}
+
+				vars := map[string]any{
+					"org":   githubv4.String(params.Org),
+					"first": githubv4.Int(first),
+					"after": newGQLStringlike[githubv4.String](after),
+				}
+
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return nil, gqlPageInfo{}, err
+				}
+
+				members := query.Organization.MembersWithRole
+				totalCount = members.TotalCount
+				return members.Edges, gqlPageInfo(members.PageInfo), nil
+			})
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			result := OrgMembersResult{
+				TotalCount: totalCount,
+				Members:    make([]MinimalOrgMember, 0, len(edges)),
+				NextCursor: next,
+			}
+			for _, edge := range edges {
+				result.Members = append(result.Members, MinimalOrgMember{
+					Login: string(edge.Node.Login),
+					Name:  string(edge.Node.Name),
+					Role:  string(edge.Role),
+				})
+			}
+
+			return projectedTextResult(request, result), nil
+		}
+}
+
+// ListOutsideCollaborators creates a tool to list the outside collaborators of an organization.
+func ListOutsideCollaborators(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_outside_collaborators",
+			mcp.WithDescription(t("TOOL_LIST_OUTSIDE_COLLABORATORS_DESCRIPTION", "List users who have access to at least one repository in a GitHub organization without being a member of it. Requires organization owner access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_OUTSIDE_COLLABORATORS_USER_TITLE", "List outside collaborators"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("filter",
+				mcp.Description("Filter the list of outside collaborators"),
+				mcp.Enum("all", "2fa_disabled"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[MinimalUser]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			filter, err := OptionalParam[string](request, "filter")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.ListOutsideCollaboratorsOptions{
+				Filter: filter,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			users, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.User, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.Organizations.ListOutsideCollaborators(ctx, org, opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list outside collaborators: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list outside collaborators"), nil
+			}
+
+			items := minimalUsers(users)
+			return projectedListResult(request, items, listResult(items, next), next), nil
+		}
+}
+
+// AddTeamMember creates a tool to add a user to a team, or change their role in it.
+func AddTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_team_member",
+			mcp.WithDescription(t("TOOL_ADD_TEAM_MEMBER_DESCRIPTION", "Add a user to a team in a GitHub organization, or change their role if they are already a member. Users who are not yet organization members are invited.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_TEAM_MEMBER_USER_TITLE", "Add team member"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("The slug of the team"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("The GitHub username to add"),
+			),
+			mcp.WithString("role",
+				mcp.Description("The role of the user in the team, defaults to member"),
+				mcp.Enum("member", "maintainer"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			role, err := OptionalParam[string](request, "role")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			membership, resp, err := client.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{Role: role})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add team member: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to add team member"), nil
+			}
+
+			// The membership state is "pending" until an invited user accepts the invitation.
+			return actionResult(fmt.Sprintf("%s is a %s of team %s with membership state %s", username, membership.GetRole(), teamSlug, membership.GetState())), nil
+		}
+}
+
+// RemoveTeamMember creates a tool to remove a user from a team.
+func RemoveTeamMember(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_team_member",
+			mcp.WithDescription(t("TOOL_REMOVE_TEAM_MEMBER_DESCRIPTION", "Remove a user from a team in a GitHub organization. The user remains a member of the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           t("TOOL_REMOVE_TEAM_MEMBER_USER_TITLE", "Remove team member"),
+				ReadOnlyHint:    toBoolPtr(false),
+				DestructiveHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("The slug of the team"),
+			),
+			mcp.WithString("username",
+				mcp.Required(),
+				mcp.Description("The GitHub username to remove"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			username, err := requiredParam[string](request, "username")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Teams.RemoveTeamMembershipBySlug(ctx, org, teamSlug, username)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove team member: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return responseErrorResult(resp, "failed to remove team member"), nil
+			}
+
+			return actionResult(fmt.Sprintf("%s removed from team %s", username, teamSlug)), nil
+		}
+}
+
+// SetTeamRepositoryPermission creates a tool to grant a team access to a repository, or change its permission.
+func SetTeamRepositoryPermission(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("set_team_repository_permission",
+			mcp.WithDescription(t("TOOL_SET_TEAM_REPOSITORY_PERMISSION_DESCRIPTION", "Grant a team in a GitHub organization access to a repository, or change the permission it already has. The repository must belong to the organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_SET_TEAM_REPOSITORY_PERMISSION_USER_TITLE", "Set team repository permission"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The organization name"),
+			),
+			mcp.WithString("team_slug",
+				mcp.Required(),
+				mcp.Description("The slug of the team"),
+			),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithString("permission",
+				mcp.Required(),
+				mcp.Description("The permission to grant the team on the repository"),
+				mcp.Enum("pull", "triage", "push", "maintain", "admin"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			teamSlug, err := requiredParam[string](request, "team_slug")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			permission, err := requiredParam[string](request, "permission")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			resp, err := client.Teams.AddTeamRepoBySlug(ctx, org, teamSlug, owner, repo, &github.TeamAddTeamRepoOptions{Permission: permission})
+			if err != nil {
+				return nil, fmt.Errorf("failed to set team repository permission: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return responseErrorResult(resp, "failed to set team repository permission"), nil
+			}
+
+			return actionResult(fmt.Sprintf("team %s now has %s permission on %s/%s", teamSlug, permission, owner, repo)), nil
+		}
+}
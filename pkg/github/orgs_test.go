@@ -0,0 +1,861 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListUserOrganizations(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListUserOrganizations(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_user_organizations", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockOrgs := []*github.Organization{
+		{
+			Login:       github.Ptr("octo-org"),
+			ID:          github.Ptr(int64(1)),
+			Description: github.Ptr("The octo org"),
+			HTMLURL:     github.Ptr("https://github.com/octo-org"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedOrgs   []MinimalOrganization
+		expectedErrMsg string
+	}{
+		{
+			name: "authenticated user organizations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUserOrgs,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockOrgs),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{},
+			expectedOrgs: []MinimalOrganization{
+				{Login: "octo-org", ID: 1, Description: "The octo org", ProfileURL: "https://github.com/octo-org"},
+			},
+		},
+		{
+			name: "organizations of another user",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetUsersOrgsByUsername,
+					mockOrgs,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "octocat",
+			},
+			expectedOrgs: []MinimalOrganization{
+				{Login: "octo-org", ID: 1, Description: "The octo org", ProfileURL: "https://github.com/octo-org"},
+			},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetUsersOrgsByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"username": "nobody",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organizations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListUserOrganizations(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedOrgs []MinimalOrganization
+			err = json.Unmarshal([]byte(textContent.Text), &returnedOrgs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOrgs, returnedOrgs)
+		})
+	}
+}
+
+func Test_ListOrgTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockTeams := []*github.Team{
+		{
+			ID:      github.Ptr(int64(1)),
+			Slug:    github.Ptr("engineering"),
+			Name:    github.Ptr("Engineering"),
+			Privacy: github.Ptr("closed"),
+		},
+		{
+			ID:      github.Ptr(int64(2)),
+			Slug:    github.Ptr("platform"),
+			Name:    github.Ptr("Platform"),
+			Privacy: github.Ptr("closed"),
+			Parent:  &github.Team{Slug: github.Ptr("engineering")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedTeams  []MinimalTeam
+		expectedErrMsg string
+	}{
+		{
+			name: "successful teams listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					expectQueryParams(t, map[string]string{
+						"page":     "2",
+						"per_page": "10",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockTeams),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":     "octo-org",
+				"page":    float64(2),
+				"perPage": float64(10),
+			},
+			expectedTeams: []MinimalTeam{
+				{ID: 1, Slug: "engineering", Name: "Engineering", Privacy: "closed"},
+				{ID: 2, Slug: "platform", Name: "Platform", Privacy: "closed", ParentSlug: "engineering"},
+			},
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "octo-org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list teams",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedTeams []MinimalTeam
+			err = json.Unmarshal([]byte(textContent.Text), &returnedTeams)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTeams, returnedTeams)
+		})
+	}
+}
+
+func Test_ListChildTeams(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListChildTeams(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_child_teams", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsTeamsTeamsByOrgByTeamSlug,
+			expectPath(t, "/orgs/octo-org/teams/engineering/teams").andThen(
+				mockResponse(t, http.StatusOK, []*github.Team{
+					{
+						ID:     github.Ptr(int64(2)),
+						Slug:   github.Ptr("platform"),
+						Name:   github.Ptr("Platform"),
+						Parent: &github.Team{Slug: github.Ptr("engineering")},
+					},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListChildTeams(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "engineering",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedTeams []MinimalTeam
+	err = json.Unmarshal([]byte(textContent.Text), &returnedTeams)
+	require.NoError(t, err)
+	assert.Equal(t, []MinimalTeam{{ID: 2, Slug: "platform", Name: "Platform", ParentSlug: "engineering"}}, returnedTeams)
+}
+
+func Test_ListTeamMembers(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamMembers(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_team_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockUsers := []*github.User{
+		{
+			Login:     github.Ptr("octocat"),
+			ID:        github.Ptr(int64(1)),
+			HTMLURL:   github.Ptr("https://github.com/octocat"),
+			AvatarURL: github.Ptr("https://avatars.githubusercontent.com/u/1"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectedMembers []MinimalUser
+		expectedErrMsg  string
+	}{
+		{
+			name: "maintainers only",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					expectQueryParams(t, map[string]string{
+						"role":     "maintainer",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockUsers),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "engineering",
+				"role":      "maintainer",
+			},
+			expectedMembers: []MinimalUser{
+				{Login: "octocat", ID: 1, ProfileURL: "https://github.com/octocat", AvatarURL: "https://avatars.githubusercontent.com/u/1"},
+			},
+		},
+		{
+			name: "team not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsTeamsMembersByOrgByTeamSlug,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "missing",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list team members",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListTeamMembers(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedMembers []MinimalUser
+			err = json.Unmarshal([]byte(textContent.Text), &returnedMembers)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMembers, returnedMembers)
+		})
+	}
+}
+
+func Test_ListTeamRepositories(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListTeamRepositories(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_team_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(
+			mock.GetOrgsTeamsReposByOrgByTeamSlug,
+			[]*github.Repository{
+				{
+					FullName: github.Ptr("octo-org/api"),
+					Private:  github.Ptr(true),
+					RoleName: github.Ptr("maintain"),
+				},
+				{
+					FullName:    github.Ptr("octo-org/docs"),
+					Permissions: map[string]bool{"admin": false, "push": true, "pull": true},
+				},
+			},
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListTeamRepositories(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "engineering",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedRepos []MinimalTeamRepository
+	err = json.Unmarshal([]byte(textContent.Text), &returnedRepos)
+	require.NoError(t, err)
+	assert.Equal(t, []MinimalTeamRepository{
+		{FullName: "octo-org/api", Private: true, Permission: "maintain"},
+		{FullName: "octo-org/docs", Permission: "push"},
+	}, returnedRepos)
+}
+
+func Test_ListOrgMembers(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ListOrgMembers(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_members", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "cursor")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	membersQuery := struct {
+		Organization struct {
+			MembersWithRole struct {
+				TotalCount int
+				PageInfo   struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+				Edges []struct {
+					Role githubv4.OrganizationMemberRole
+					Node struct {
+						Login githubv4.String
+						Name  githubv4.String
+					}
+				}
+			} `graphql:"membersWithRole(first: $first, after: $after)"`
+		} `graphql:"organization(login: $org)"`
+	}{}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResult     OrgMembersResult
+	}{
+		{
+			name: "first page of members",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					membersQuery,
+					map[string]any{
+						"org":   githubv4.String("octo-org"),
+						"first": githubv4.Int(2),
+						"after": (*githubv4.String)(nil),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{
+							"membersWithRole": map[string]any{
+								"totalCount": 3,
+								"pageInfo": map[string]any{
+									"hasNextPage": true,
+									"endCursor":   "Y3Vyc29yOjI=",
+								},
+								"edges": []any{
+									map[string]any{"role": "ADMIN", "node": map[string]any{"login": "octocat", "name": "The Octocat"}},
+									map[string]any{"role": "MEMBER", "node": map[string]any{"login": "hubot", "name": ""}},
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":     "octo-org",
+				"perPage": float64(2),
+			},
+			expectedResult: OrgMembersResult{
+				TotalCount: 3,
+				Members: []MinimalOrgMember{
+					{Login: "octocat", Name: "The Octocat", Role: "ADMIN"},
+					{Login: "hubot", Role: "MEMBER"},
+				},
+				NextCursor: pageCursor{PerPage: 2, After: "Y3Vyc29yOjI="}.String(),
+			},
+		},
+		{
+			name: "following page",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					membersQuery,
+					map[string]any{
+						"org":   githubv4.String("octo-org"),
+						"first": githubv4.Int(30),
+						"after": githubv4mock.Ptr(githubv4.String("Y3Vyc29yOjI=")),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"organization": map[string]any{
+							"membersWithRole": map[string]any{
+								"totalCount": 3,
+								"pageInfo": map[string]any{
+									"hasNextPage": false,
+									"endCursor":   "Y3Vyc29yOjM=",
+								},
+								"edges": []any{
+									map[string]any{"role": "MEMBER", "node": map[string]any{"login": "monalisa", "name": "Mona"}},
+								},
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"org":    "octo-org",
+				"cursor": pageCursor{After: "Y3Vyc29yOjI="}.String(),
+			},
+			expectedResult: OrgMembersResult{
+				TotalCount: 3,
+				Members: []MinimalOrgMember{
+					{Login: "monalisa", Name: "Mona", Role: "MEMBER"},
+				},
+			},
+		},
+		{
+			name:               "missing org",
+			mockedClient:       githubv4mock.NewMockedHTTPClient(),
+			requestArgs:        map[string]any{},
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: org",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ListOrgMembers(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var returnedResult OrgMembersResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
+
+func Test_ListOutsideCollaborators(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOutsideCollaborators(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_outside_collaborators", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "filter")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetOrgsOutsideCollaboratorsByOrg,
+			expectQueryParams(t, map[string]string{
+				"filter":   "2fa_disabled",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, []*github.User{
+					{Login: github.Ptr("contractor"), ID: github.Ptr(int64(42))},
+				}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := ListOutsideCollaborators(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":    "octo-org",
+		"filter": "2fa_disabled",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedUsers []MinimalUser
+	err = json.Unmarshal([]byte(textContent.Text), &returnedUsers)
+	require.NoError(t, err)
+	assert.Equal(t, []MinimalUser{{Login: "contractor", ID: 42}}, returnedUsers)
+}
+
+func Test_AddTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "team_slug")
+	assert.Contains(t, tool.InputSchema.Properties, "username")
+	assert.Contains(t, tool.InputSchema.Properties, "role")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "add maintainer",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					expectRequestBody(t, map[string]interface{}{
+						"role": "maintainer",
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Membership{
+							Role:  github.Ptr("maintainer"),
+							State: github.Ptr("active"),
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "engineering",
+				"username":  "octocat",
+				"role":      "maintainer",
+			},
+			expectedText: "octocat is a maintainer of team engineering with membership state active",
+		},
+		{
+			name: "user cannot be added",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Forbidden"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "engineering",
+				"username":  "octocat",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add team member",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
+
+func Test_RemoveTeamMember(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveTeamMember(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_team_member", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "username"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.DeleteOrgsTeamsMembershipsByOrgByTeamSlugByUsername,
+			expectPath(t, "/orgs/octo-org/teams/engineering/memberships/octocat").andThen(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				},
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := RemoveTeamMember(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"org":       "octo-org",
+		"team_slug": "engineering",
+		"username":  "octocat",
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+	assert.Equal(t, "octocat removed from team engineering", textContent.Text)
+}
+
+func Test_SetTeamRepositoryPermission(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SetTeamRepositoryPermission(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "set_team_repository_permission", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "permission")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org", "team_slug", "owner", "repo", "permission"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedText    string
+		expectedErrMsg  string
+	}{
+		{
+			name: "grant push",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"permission": "push",
+					}).andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusNoContent)
+						},
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"team_slug":  "engineering",
+				"owner":      "octo-org",
+				"repo":       "api",
+				"permission": "push",
+			},
+			expectedText: "team engineering now has push permission on octo-org/api",
+		},
+		{
+			name:         "missing permission",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":       "octo-org",
+				"team_slug": "engineering",
+				"owner":     "octo-org",
+				"repo":      "api",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "missing required parameter: permission",
+		},
+		{
+			name: "repository outside the organization",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutOrgsTeamsReposByOrgByTeamSlugByOwnerByRepo,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "octo-org",
+				"team_slug":  "engineering",
+				"owner":      "someone-else",
+				"repo":       "api",
+				"permission": "pull",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to set team repository permission",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SetTeamRepositoryPermission(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
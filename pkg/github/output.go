@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// OutputMode selects how much of the GitHub API responses tools return.
+type OutputMode string
+
+const (
+	// OutputModeFull returns API objects as GitHub sends them, including all of their URL fields.
+	OutputModeFull OutputMode = "full"
+	// OutputModeCompact returns cleaned objects, keeping the fields useful to a model.
+	OutputModeCompact OutputMode = "compact"
+)
+
+// ParseOutputMode validates an output mode, as passed on the command line. It defaults to full.
+func ParseOutputMode(s string) (OutputMode, error) {
+	switch OutputMode(s) {
+	case "", OutputModeFull:
+		return OutputModeFull, nil
+	case OutputModeCompact:
+		return OutputModeCompact, nil
+	default:
+		return "", fmt.Errorf("invalid output mode %q, must be %s or %s", s, OutputModeFull, OutputModeCompact)
+	}
+}
+
+type outputModeKey struct{}
+
+// ContextWithOutputMode returns a context for tool handlers to return output in the given mode.
+func ContextWithOutputMode(ctx context.Context, mode OutputMode) context.Context {
+	return context.WithValue(ctx, outputModeKey{}, mode)
+}
+
+// compactOutput reports whether tool handlers should return cleaned objects rather than full API objects.
+func compactOutput(ctx context.Context) bool {
+	mode, _ := ctx.Value(outputModeKey{}).(OutputMode)
+	return mode == OutputModeCompact
+}
+
+// WithOutputMode is a server option making all tool handlers return output in the given mode.
+func WithOutputMode(mode OutputMode) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(ContextWithOutputMode(ctx, mode), request)
+		}
+	})
+}
+
+// compactOr returns v cleaned by clean when tools return compact output, and v as is otherwise.
+func compactOr[T, C any](ctx context.Context, v T, clean func(T) C) any {
+	if compactOutput(ctx) {
+		return clean(v)
+	}
+	return v
+}
+
+// OutputFormat selects how tools encode their results.
+type OutputFormat string
+
+const (
+	// OutputFormatJSON returns results as JSON.
+	OutputFormatJSON OutputFormat = "json"
+	// OutputFormatMarkdown renders results as concise Markdown tables and summaries, for clients showing
+	// them to people.
+	OutputFormatMarkdown OutputFormat = "markdown"
+)
+
+// ParseOutputFormat validates an output format, as passed on the command line or to a tool. It defaults to json.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch OutputFormat(s) {
+	case "", OutputFormatJSON:
+		return OutputFormatJSON, nil
+	case OutputFormatMarkdown:
+		return OutputFormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q, must be %s or %s", s, OutputFormatJSON, OutputFormatMarkdown)
+	}
+}
+
+type outputFormatKey struct{}
+
+// ContextWithOutputFormat returns a context for tool handlers to return results in the given format,
+// unless a call asks for another one.
+func ContextWithOutputFormat(ctx context.Context, format OutputFormat) context.Context {
+	return context.WithValue(ctx, outputFormatKey{}, format)
+}
+
+// WithOutputFormat is a server option making all tool handlers return results in the given format by default.
+func WithOutputFormat(format OutputFormat) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(ContextWithOutputFormat(ctx, format), request)
+		}
+	})
+}
+
+// markdownOutput reports whether a tool handler should render its result as Markdown, as asked by the
+// "outputFormat" parameter of the request, or by the server default otherwise.
+func markdownOutput(ctx context.Context, request mcp.CallToolRequest) (bool, error) {
+	s, err := OptionalParam[string](request, "outputFormat")
+	if err != nil {
+		return false, err
+	}
+	format, _ := ctx.Value(outputFormatKey{}).(OutputFormat)
+	if s != "" {
+		if format, err = ParseOutputFormat(s); err != nil {
+			return false, err
+		}
+	}
+	return format == OutputFormatMarkdown, nil
+}
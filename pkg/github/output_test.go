@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseOutputMode(t *testing.T) {
+	mode, err := ParseOutputMode("")
+	require.NoError(t, err)
+	assert.Equal(t, OutputModeFull, mode)
+
+	mode, err = ParseOutputMode("compact")
+	require.NoError(t, err)
+	assert.Equal(t, OutputModeCompact, mode)
+
+	_, err = ParseOutputMode("minimal")
+	assert.ErrorContains(t, err, `invalid output mode "minimal"`)
+}
+
+func Test_WithOutputMode(t *testing.T) {
+	s := NewServer("test", WithOutputMode(OutputModeCompact))
+
+	var compact bool
+	s.AddTool(mcp.NewTool("test_tool"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		compact = compactOutput(ctx)
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test_tool"}}`))
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+	assert.True(t, compact)
+}
+
+func Test_ParseOutputFormat(t *testing.T) {
+	format, err := ParseOutputFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatJSON, format)
+
+	format, err = ParseOutputFormat("markdown")
+	require.NoError(t, err)
+	assert.Equal(t, OutputFormatMarkdown, format)
+
+	_, err = ParseOutputFormat("html")
+	assert.ErrorContains(t, err, `invalid output format "html"`)
+}
+
+func Test_WithOutputFormat(t *testing.T) {
+	s := NewServer("test", WithOutputFormat(OutputFormatMarkdown))
+
+	var markdown bool
+	s.AddTool(mcp.NewTool("test_tool"), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		markdown, _ = markdownOutput(ctx, request)
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test_tool"}}`))
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+	assert.True(t, markdown)
+}
+
+func Test_CompactOutput(t *testing.T) {
+	mockIssue := &github.Issue{
+		Number:        github.Ptr(42),
+		Title:         github.Ptr("Test Issue"),
+		State:         github.Ptr("open"),
+		URL:           github.Ptr("https://api.github.com/repos/owner/repo/issues/42"),
+		HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/42"),
+		CommentsURL:   github.Ptr("https://api.github.com/repos/owner/repo/issues/42/comments"),
+		RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+		User:          &github.User{Login: github.Ptr("octocat"), ID: github.Ptr(int64(1)), AvatarURL: github.Ptr("https://avatars.githubusercontent.com/u/1")},
+		Labels:        []*github.Label{{Name: github.Ptr("bug"), URL: github.Ptr("https://api.github.com/repos/owner/repo/labels/bug")}},
+		Milestone:     &github.Milestone{Title: github.Ptr("v1.0"), URL: github.Ptr("https://api.github.com/repos/owner/repo/milestones/1")},
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue, mockIssue),
+	))
+	_, handler := GetIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+	request := createMCPRequest(map[string]interface{}{
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	})
+
+	// Full output is the default
+	result, err := handler(context.Background(), request)
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, `"comments_url"`)
+
+	result, err = handler(ContextWithOutputMode(context.Background(), OutputModeCompact), request)
+	require.NoError(t, err)
+	var issue map[string]any
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &issue))
+	assert.Equal(t, map[string]any{
+		"number":    float64(42),
+		"title":     "Test Issue",
+		"state":     "open",
+		"user":      map[string]any{"login": "octocat", "id": float64(1)},
+		"labels":    []any{"bug"},
+		"milestone": "v1.0",
+		"html_url":  "https://github.com/owner/repo/issues/42",
+	}, issue)
+}
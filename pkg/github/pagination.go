@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxPaginatedItems is the most items a single call collects across pages.
+const maxPaginatedItems = 1000
+
+// moreItemsNote starts the content telling the model how to fetch the items following a result.
+const moreItemsNote = "More items are available."
+
+// pageCursor is the position following the items returned by a call, passed back as the opaque "cursor" parameter.
+// REST results continue at item Skip of page Page, and GraphQL results at item Skip after the connection cursor After.
+type pageCursor struct {
+	Page    int    `json:"page,omitempty"`
+	PerPage int    `json:"per_page,omitempty"`
+	Skip    int    `json:"skip,omitempty"`
+	After   string `json:"after,omitempty"`
+}
+
+func (c pageCursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageCursor(s string) (pageCursor, error) {
+	var c pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil || c.Page < 0 || c.PerPage < 0 || c.Skip < 0 {
+		return pageCursor{}, fmt.Errorf("invalid cursor %q, pass a cursor returned by a previous call", s)
+	}
+	return c, nil
+}
+
+// continuationKey is the context key of the *continuation of a tool call, which WithMaxResponseSize attaches
+// so that results cut to the response size limit can tell how to fetch the items that were left out.
+type continuationKey struct{}
+
+// continuation locates the items of a tool call's result list. It is only set by tools accepting a cursor.
+type continuation struct {
+	// cursorAfter returns the cursor to the items following the first n items of the list, or "" when
+	// there are none.
+	cursorAfter func(n int) string
+}
+
+// pageSpan is a run of collected items taken from a single page, the first of which is at cursor.
+type pageSpan struct {
+	cursor pageCursor
+	count  int
+}
+
+// setContinuation sets the continuation of the tool call of ctx, if any, to the items collected over spans,
+// which are followed by the items at next.
+func setContinuation(ctx context.Context, spans []pageSpan, next string) {
+	c, ok := ctx.Value(continuationKey{}).(*continuation)
+	if !ok {
+		return
+	}
+	c.cursorAfter = func(n int) string {
+		for _, span := range spans {
+			if n < span.count {
+				cursor := span.cursor
+				cursor.Skip += n
+				return cursor.String()
+			}
+			n -= span.count
+		}
+		return next
+	}
+}
+
+// collectPages fetches the page of items the pagination parameters start from. When maxItems is set, it
+// follows the next pages from the Link headers of the responses until that many items are collected, or
+// the collected items exceed the response size limit. It returns the last response, whose body the caller
+// closes, and the cursor to the following items, or "" when there are none.
+func collectPages[T any](ctx context.Context, pagination PaginationParams, fetch func(opts github.ListOptions) ([]T, *github.Response, error)) ([]T, *github.Response, string, error) {
+	limit := pagination.maxItems
+	if limit == 0 {
+		limit = pagination.perPage
+	}
+	maxBytes := responseSizeLimit(ctx)
+
+	items := make([]T, 0)
+	var spans []pageSpan
+	size := 0
+	page, skip := pagination.page, pagination.skip
+	for {
+		pageItems, resp, err := fetch(github.ListOptions{Page: page, PerPage: pagination.perPage})
+		if err != nil {
+			return nil, resp, "", err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, resp, "", nil
+		}
+
+		pageItems = pageItems[min(skip, len(pageItems)):]
+		start := pageCursor{Page: page, PerPage: pagination.perPage, Skip: skip}
+		if remaining := limit - len(items); len(pageItems) > remaining {
+			items = append(items, pageItems[:remaining]...)
+			spans = append(spans, pageSpan{cursor: start, count: remaining})
+			next := pageCursor{Page: page, PerPage: pagination.perPage, Skip: skip + remaining}.String()
+			setContinuation(ctx, spans, next)
+			return items, resp, next, nil
+		}
+		items = append(items, pageItems...)
+		spans = append(spans, pageSpan{cursor: start, count: len(pageItems)})
+		if resp.NextPage == 0 {
+			setContinuation(ctx, spans, "")
+			return items, resp, "", nil
+		}
+
+		if maxBytes > 0 {
+			data, err := json.Marshal(pageItems)
+			if err != nil {
+				return nil, resp, "", fmt.Errorf("failed to marshal items: %w", err)
+			}
+			size += len(data)
+		}
+		if len(items) == limit || (maxBytes > 0 && size >= maxBytes) {
+			next := pageCursor{Page: resp.NextPage, PerPage: pagination.perPage}.String()
+			setContinuation(ctx, spans, next)
+			return items, resp, next, nil
+		}
+		_ = resp.Body.Close()
+		page, skip = resp.NextPage, 0
+	}
+}
+
+// nextPageOf returns the page a cursor from collectPages starts at, or 0 when it is empty or starts within
+// a page, for results that also report the next page number.
+func nextPageOf(cursor string) int {
+	if c, err := decodePageCursor(cursor); cursor != "" && err == nil && c.Skip == 0 {
+		return c.Page
+	}
+	return 0
+}
+
+// gqlPageInfo is the pageInfo of a GraphQL connection.
+type gqlPageInfo struct {
+	HasNextPage bool
+	EndCursor   string
+}
+
+// collectGQLPages is collectPages for GraphQL connections, following their pageInfo cursors. As each query
+// asks for no more items than are left to collect, the last pageInfo usually locates the following items.
+// A cursor continuing within a page skips items of the page fetched again with perPage items, which starts
+// with the items of the page it was made from. fetch may filter the items of a connection, in which case
+// the following pages are fetched until a page of items is collected.
+func collectGQLPages[T any](ctx context.Context, pagination PaginationParams, fetch func(first int, after string) ([]T, gqlPageInfo, error)) ([]T, string, error) {
+	limit := pagination.maxItems
+	if limit == 0 {
+		limit = pagination.perPage
+	}
+	maxBytes := responseSizeLimit(ctx)
+
+	items := make([]T, 0)
+	var spans []pageSpan
+	size := 0
+	after, skip := pagination.after, pagination.skip
+	for {
+		first := min(pagination.perPage, limit-len(items))
+		if skip > 0 {
+			first = pagination.perPage
+		}
+		pageItems, pageInfo, err := fetch(first, after)
+		if err != nil {
+			return nil, "", err
+		}
+
+		pageItems = pageItems[min(skip, len(pageItems)):]
+		start := pageCursor{PerPage: pagination.perPage, Skip: skip, After: after}
+		if remaining := limit - len(items); len(pageItems) > remaining {
+			items = append(items, pageItems[:remaining]...)
+			spans = append(spans, pageSpan{cursor: start, count: remaining})
+			next := pageCursor{PerPage: pagination.perPage, Skip: skip + remaining, After: after}.String()
+			setContinuation(ctx, spans, next)
+			return items, next, nil
+		}
+		items = append(items, pageItems...)
+		spans = append(spans, pageSpan{cursor: start, count: len(pageItems)})
+		if !pageInfo.HasNextPage {
+			setContinuation(ctx, spans, "")
+			return items, "", nil
+		}
+		next := pageCursor{PerPage: pagination.perPage, After: pageInfo.EndCursor}.String()
+		if len(items) >= limit {
+			setContinuation(ctx, spans, next)
+			return items, next, nil
+		}
+
+		if maxBytes > 0 {
+			data, err := json.Marshal(pageItems)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to marshal items: %w", err)
+			}
+			size += len(data)
+			if size >= maxBytes {
+				setContinuation(ctx, spans, next)
+				return items, next, nil
+			}
+		}
+		after, skip = pageInfo.EndCursor, 0
+	}
+}
+
+// withNextCursor appends a note with the cursor to the following items to result, unless cursor is empty.
+func withNextCursor(result *mcp.CallToolResult, cursor string) *mcp.CallToolResult {
+	if cursor != "" {
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("%s Pass cursor %q to fetch the following items.", moreItemsNote, cursor)))
+	}
+	return result
+}
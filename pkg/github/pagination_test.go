@@ -0,0 +1,254 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PageCursor(t *testing.T) {
+	c := pageCursor{Page: 3, PerPage: 50, Skip: 7}
+	decoded, err := decodePageCursor(c.String())
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+
+	_, err = decodePageCursor("not a cursor")
+	assert.EqualError(t, err, `invalid cursor "not a cursor", pass a cursor returned by a previous call`)
+}
+
+func Test_OptionalPaginationParams_Cursor(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expected       PaginationParams
+		expectedErrMsg string
+	}{
+		{
+			name:     "maxItems defaults perPage to 100",
+			args:     map[string]any{"maxItems": float64(250)},
+			expected: PaginationParams{page: 1, perPage: 100, maxItems: 250},
+		},
+		{
+			name:     "maxItems is capped",
+			args:     map[string]any{"maxItems": float64(5000), "perPage": float64(50)},
+			expected: PaginationParams{page: 1, perPage: 50, maxItems: maxPaginatedItems},
+		},
+		{
+			name:     "cursor replaces page and perPage",
+			args:     map[string]any{"cursor": pageCursor{Page: 4, PerPage: 20, Skip: 5}.String(), "perPage": float64(100)},
+			expected: PaginationParams{page: 4, perPage: 20, skip: 5},
+		},
+		{
+			name:     "GraphQL cursor",
+			args:     map[string]any{"cursor": pageCursor{PerPage: 50, After: "Y3Vyc29yOjI="}.String()},
+			expected: PaginationParams{page: 1, perPage: 50, after: "Y3Vyc29yOjI="},
+		},
+		{
+			name:           "page and cursor",
+			args:           map[string]any{"cursor": pageCursor{Page: 4}.String(), "page": float64(2)},
+			expectedErrMsg: "only one of page and cursor can be set",
+		},
+		{
+			name:           "invalid cursor",
+			args:           map[string]any{"cursor": "abc!"},
+			expectedErrMsg: `invalid cursor "abc!"`,
+		},
+		{
+			name:           "negative maxItems",
+			args:           map[string]any{"maxItems": float64(-1)},
+			expectedErrMsg: "maxItems must be at least 1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pagination, err := OptionalPaginationParams(createMCPRequest(tc.args))
+			if tc.expectedErrMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, pagination)
+		})
+	}
+}
+
+func Test_CollectPages(t *testing.T) {
+	// 3 pages of numbered items, the last one partial.
+	const total = 25
+	fetch := func(requested *[]int) func(opts github.ListOptions) ([]int, *github.Response, error) {
+		return func(opts github.ListOptions) ([]int, *github.Response, error) {
+			*requested = append(*requested, opts.Page)
+			var items []int
+			for i := (opts.Page-1)*opts.PerPage + 1; i <= min(opts.Page*opts.PerPage, total); i++ {
+				items = append(items, i)
+			}
+			resp := &github.Response{Response: &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}}
+			if opts.Page*opts.PerPage < total {
+				resp.NextPage = opts.Page + 1
+			}
+			return items, resp, nil
+		}
+	}
+
+	tests := []struct {
+		name               string
+		pagination         PaginationParams
+		maxBytes           int
+		expectedFirst      int
+		expectedCount      int
+		expectedPages      []int
+		expectedNextCursor pageCursor
+	}{
+		{
+			name:               "single page",
+			pagination:         PaginationParams{page: 1, perPage: 10},
+			expectedFirst:      1,
+			expectedCount:      10,
+			expectedPages:      []int{1},
+			expectedNextCursor: pageCursor{Page: 2, PerPage: 10},
+		},
+		{
+			name:               "maxItems across pages ends within a page",
+			pagination:         PaginationParams{page: 1, perPage: 10, maxItems: 15},
+			expectedFirst:      1,
+			expectedCount:      15,
+			expectedPages:      []int{1, 2},
+			expectedNextCursor: pageCursor{Page: 2, PerPage: 10, Skip: 5},
+		},
+		{
+			name:          "continues from a cursor to the last page",
+			pagination:    PaginationParams{page: 2, perPage: 10, skip: 5, maxItems: 100},
+			expectedFirst: 16,
+			expectedCount: 10,
+			expectedPages: []int{2, 3},
+		},
+		{
+			name:               "response size limit stops between pages",
+			pagination:         PaginationParams{page: 1, perPage: 10, maxItems: 100},
+			maxBytes:           10,
+			expectedFirst:      1,
+			expectedCount:      10,
+			expectedPages:      []int{1},
+			expectedNextCursor: pageCursor{Page: 2, PerPage: 10},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.maxBytes > 0 {
+				ctx = context.WithValue(ctx, responseSizeLimitKey{}, tc.maxBytes)
+			}
+			var requested []int
+			items, _, next, err := collectPages(ctx, tc.pagination, fetch(&requested))
+			require.NoError(t, err)
+
+			require.Len(t, items, tc.expectedCount)
+			assert.Equal(t, tc.expectedFirst, items[0])
+			assert.Equal(t, tc.expectedPages, requested)
+			if tc.expectedNextCursor == (pageCursor{}) {
+				assert.Empty(t, next)
+			} else {
+				assert.Equal(t, tc.expectedNextCursor.String(), next)
+			}
+		})
+	}
+}
+
+func Test_CollectGQLPages(t *testing.T) {
+	// Connection of 25 numbered items, whose cursors are the numbers of the last items of pages.
+	var firsts []int
+	fetch := func(first int, after string) ([]int, gqlPageInfo, error) {
+		firsts = append(firsts, first)
+		start := 0
+		if after != "" {
+			_, _ = fmt.Sscan(after, &start)
+		}
+		var items []int
+		for i := start + 1; i <= min(start+first, 25); i++ {
+			items = append(items, i)
+		}
+		end := start + len(items)
+		return items, gqlPageInfo{HasNextPage: end < 25, EndCursor: fmt.Sprint(end)}, nil
+	}
+
+	items, next, err := collectGQLPages(context.Background(), PaginationParams{perPage: 10, maxItems: 15, after: "2"}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 5}, firsts)
+	require.Len(t, items, 15)
+	assert.Equal(t, 3, items[0])
+	assert.Equal(t, pageCursor{PerPage: 10, After: "17"}.String(), next)
+
+	firsts = nil
+	items, next, err = collectGQLPages(context.Background(), PaginationParams{perPage: 10, maxItems: 100, after: "17"}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []int{10}, firsts)
+	assert.Equal(t, []int{18, 19, 20, 21, 22, 23, 24, 25}, items)
+	assert.Empty(t, next)
+
+	// A cursor within a page fetches the whole page again, and can end within it.
+	firsts = nil
+	items, next, err = collectGQLPages(context.Background(), PaginationParams{perPage: 10, maxItems: 4, after: "2", skip: 3}, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []int{10}, firsts)
+	assert.Equal(t, []int{6, 7, 8, 9}, items)
+	assert.Equal(t, pageCursor{PerPage: 10, Skip: 7, After: "2"}.String(), next)
+}
+
+func Test_ListLabels_MaxItems(t *testing.T) {
+	label := func(name string) *github.Label { return &github.Label{Name: github.Ptr(name)} }
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepo,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Query().Get("page") {
+				case "1":
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/labels?page=2&per_page=2>; rel="next"`)
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode([]*github.Label{label("bug"), label("docs")})
+				case "2":
+					w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/labels?page=3&per_page=2>; rel="next"`)
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode([]*github.Label{label("enhancement"), label("question")})
+				default:
+					t.Errorf("unexpected request for page %q", r.URL.Query().Get("page"))
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}),
+		),
+	))
+	tool, handler := ListLabels(stubGetClientFn(client), translations.NullTranslationHelper)
+	assert.Contains(t, tool.InputSchema.Properties, "maxItems")
+	assert.Contains(t, tool.InputSchema.Properties, "cursor")
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]any{
+		"owner":    "owner",
+		"repo":     "repo",
+		"perPage":  float64(2),
+		"maxItems": float64(3),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	require.Len(t, result.Content, 2)
+
+	var labels []*github.Label
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &labels))
+	require.Len(t, labels, 3)
+	assert.Equal(t, "enhancement", labels[2].GetName())
+
+	note := result.Content[1].(mcp.TextContent).Text
+	assert.Equal(t, fmt.Sprintf("More items are available. Pass cursor %q to fetch the following items.", pageCursor{Page: 2, PerPage: 2, Skip: 1}.String()), note)
+}
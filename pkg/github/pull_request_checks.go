@@ -0,0 +1,371 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+const (
+	checkStatePassing = "passing"
+	checkStateFailing = "failing"
+	checkStatePending = "pending"
+
+	mergeVerdictMergeable = "mergeable"
+	mergeVerdictPending   = "pending"
+	mergeVerdictBlocked   = "blocked"
+	mergeVerdictMerged    = "merged"
+	mergeVerdictClosed    = "closed"
+
+	// maxCheckAnnotations is the number of annotations fetched for each failing check run.
+	maxCheckAnnotations = 10
+)
+
+// CheckAnnotation is an annotation a check run left on a line of a file.
+type CheckAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Message   string `json:"message"`
+}
+
+// PullRequestCheck is a check run or a commit status on the head commit of a pull request.
+type PullRequestCheck struct {
+	Name string `json:"name"`
+	// Kind is check_run for the Checks API, used by GitHub Actions and most apps, or status for commit statuses.
+	Kind string `json:"kind"`
+	App  string `json:"app,omitempty"`
+	// State is one of passing, failing or pending, regardless of kind.
+	State string `json:"state"`
+	// Conclusion is the raw check run conclusion or commit status state, such as success, failure or skipped.
+	Conclusion       string            `json:"conclusion,omitempty"`
+	Required         bool              `json:"required"`
+	DetailsURL       string            `json:"details_url,omitempty"`
+	Summary          string            `json:"summary,omitempty"`
+	AnnotationsCount int               `json:"annotations_count,omitempty"`
+	Annotations      []CheckAnnotation `json:"annotations,omitempty"`
+	// AnnotationsError is why the annotations of a failing check run couldn't be fetched.
+	AnnotationsError string `json:"annotations_error,omitempty"`
+}
+
+// PullRequestCheckSuite is a check suite on the head commit of a pull request, one per app.
+type PullRequestCheckSuite struct {
+	App        string `json:"app,omitempty"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion,omitempty"`
+}
+
+// PullRequestChecks is the combined state of the checks of a pull request, along with whether it can be merged.
+type PullRequestChecks struct {
+	HeadSHA string `json:"head_sha"`
+	// Verdict is one of mergeable, pending, blocked, merged or closed. Reasons explain a pending or blocked verdict.
+	Verdict               string                  `json:"verdict"`
+	Reasons               []string                `json:"reasons,omitempty"`
+	Warnings              []string                `json:"warnings,omitempty"`
+	Checks                []PullRequestCheck      `json:"checks"`
+	Suites                []PullRequestCheckSuite `json:"suites,omitempty"`
+	MissingRequiredChecks []string                `json:"missing_required_checks,omitempty"`
+}
+
+// checkRunState maps the status and conclusion of a check run to a passing, failing or pending state.
+// Skipped and neutral checks satisfy required checks, so they count as passing.
+func checkRunState(status, conclusion string) string {
+	if status != "completed" {
+		return checkStatePending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return checkStatePassing
+	default:
+		return checkStateFailing
+	}
+}
+
+// commitStatusState maps the state of a commit status to a passing, failing or pending state.
+func commitStatusState(state string) string {
+	switch state {
+	case "success":
+		return checkStatePassing
+	case "failure", "error":
+		return checkStateFailing
+	default:
+		return checkStatePending
+	}
+}
+
+// requiredCheckNames returns the names of the checks required to merge into a branch, by branch protection
+// or by rulesets.
+func requiredCheckNames(branch *github.Branch, rules *github.BranchRules) map[string]bool {
+	required := map[string]bool{}
+	if checks := branch.GetProtection().GetRequiredStatusChecks(); checks != nil {
+		if checks.Checks != nil {
+			for _, c := range *checks.Checks {
+				required[c.Context] = true
+			}
+		}
+		if checks.Contexts != nil {
+			for _, c := range *checks.Contexts {
+				required[c] = true
+			}
+		}
+	}
+	if rules != nil {
+		for _, rule := range rules.RequiredStatusChecks {
+			for _, c := range rule.Parameters.RequiredStatusChecks {
+				required[c.Context] = true
+			}
+		}
+	}
+	return required
+}
+
+// mergeVerdict decides whether a pull request can be merged given its checks.
+func mergeVerdict(pr *github.PullRequest, checks []PullRequestCheck, missingRequired []string, strict bool) (string, []string, []string) {
+	switch {
+	case pr.GetMerged():
+		return mergeVerdictMerged, nil, nil
+	case pr.GetState() == "closed":
+		return mergeVerdictClosed, nil, nil
+	}
+
+	var blocked, pending, warnings []string
+	base := pr.GetBase().GetRef()
+
+	if pr.GetDraft() {
+		blocked = append(blocked, "the pull request is a draft")
+	}
+	switch {
+	case pr.Mergeable == nil:
+		pending = append(pending, "GitHub is still computing whether the pull request has conflicts")
+	case !pr.GetMergeable() || pr.GetMergeableState() == "dirty":
+		blocked = append(blocked, fmt.Sprintf("the pull request has merge conflicts with %s", base))
+	}
+
+	for _, c := range checks {
+		switch {
+		case c.Required && c.State == checkStateFailing:
+			blocked = append(blocked, fmt.Sprintf("required check %q is failing (%s)", c.Name, c.Conclusion))
+		case c.Required && c.State == checkStatePending:
+			pending = append(pending, fmt.Sprintf("required check %q is still running", c.Name))
+		case c.State == checkStateFailing:
+			warnings = append(warnings, fmt.Sprintf("check %q is failing (%s) but is not required", c.Name, c.Conclusion))
+		}
+	}
+	for _, name := range missingRequired {
+		pending = append(pending, fmt.Sprintf("required check %q has not reported yet", name))
+	}
+
+	if pr.GetMergeableState() == "behind" && strict {
+		blocked = append(blocked, fmt.Sprintf("the branch is behind %s, which must be merged in first", base))
+	}
+	if pr.GetMergeableState() == "blocked" && len(blocked) == 0 && len(pending) == 0 {
+		blocked = append(blocked, "blocked by branch protection or rulesets, for example by missing approving reviews")
+	}
+
+	switch {
+	case len(blocked) > 0:
+		return mergeVerdictBlocked, append(blocked, pending...), warnings
+	case len(pending) > 0:
+		return mergeVerdictPending, pending, warnings
+	default:
+		return mergeVerdictMergeable, nil, warnings
+	}
+}
+
+// GetPullRequestChecks creates a tool to get the checks of a pull request along with whether it can be merged.
+func GetPullRequestChecks(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_checks",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_CHECKS_DESCRIPTION", "Get all checks of a pull request: GitHub Actions and other Checks API runs as well as commit statuses, with whether each is required by branch protection, its details URL and annotations of failing runs. Also returns an overall verdict of whether the pull request can be merged and, if not, why. Prefer this over get_pull_request_status, which only covers commit statuses.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_CHECKS_USER_TITLE", "Get pull request checks"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			WithOutputSchema[PullRequestChecks](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pullNumber, err := RequiredInt(request, "pullNumber")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get pull request"), nil
+			}
+			sha := pr.GetHead().GetSHA()
+
+			// Branch protection and rulesets may not be readable with the token in use, in which case
+			// no check is reported as required rather than failing the whole call.
+			required := map[string]bool{}
+			strict := false
+			if branch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, pr.GetBase().GetRef(), 1); err == nil {
+				_ = resp.Body.Close()
+				var rules *github.BranchRules
+				if r, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, pr.GetBase().GetRef(), nil); err == nil {
+					_ = resp.Body.Close()
+					rules = r
+					for _, rule := range r.RequiredStatusChecks {
+						strict = strict || rule.Parameters.StrictRequiredStatusChecksPolicy
+					}
+				}
+				required = requiredCheckNames(branch, rules)
+				if checks := branch.GetProtection().GetRequiredStatusChecks(); checks != nil {
+					strict = strict || checks.Strict
+				}
+			}
+
+			var checks []PullRequestCheck
+			reported := map[string]bool{}
+
+			runOpts := &github.ListCheckRunsOptions{
+				Filter:      github.Ptr("latest"),
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for {
+				runs, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, runOpts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list check runs: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				for _, run := range runs.CheckRuns {
+					check := PullRequestCheck{
+						Name:             run.GetName(),
+						Kind:             "check_run",
+						App:              run.GetApp().GetSlug(),
+						State:            checkRunState(run.GetStatus(), run.GetConclusion()),
+						Conclusion:       run.GetConclusion(),
+						Required:         required[run.GetName()],
+						DetailsURL:       run.GetDetailsURL(),
+						Summary:          run.GetOutput().GetTitle(),
+						AnnotationsCount: run.GetOutput().GetAnnotationsCount(),
+					}
+					if check.Conclusion == "" {
+						check.Conclusion = run.GetStatus()
+					}
+					if check.State == checkStateFailing && check.AnnotationsCount > 0 {
+						// Annotations are a best effort, the check is still worth returning without them.
+						annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, run.GetID(), &github.ListOptions{PerPage: maxCheckAnnotations})
+						if err != nil {
+							check.AnnotationsError = fmt.Sprintf("failed to list check run annotations: %s", err)
+						} else {
+							_ = resp.Body.Close()
+						}
+						for _, a := range annotations {
+							check.Annotations = append(check.Annotations, CheckAnnotation{
+								Path:      a.GetPath(),
+								StartLine: a.GetStartLine(),
+								EndLine:   a.GetEndLine(),
+								Level:     a.GetAnnotationLevel(),
+								Title:     a.GetTitle(),
+								Message:   a.GetMessage(),
+							})
+						}
+					}
+					checks = append(checks, check)
+					reported[check.Name] = true
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				runOpts.Page = resp.NextPage
+			}
+
+			statusOpts := &github.ListOptions{PerPage: 100}
+			for {
+				status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, sha, statusOpts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get combined status: %w", err)
+				}
+				_ = resp.Body.Close()
+
+				for _, s := range status.Statuses {
+					checks = append(checks, PullRequestCheck{
+						Name:       s.GetContext(),
+						Kind:       "status",
+						State:      commitStatusState(s.GetState()),
+						Conclusion: s.GetState(),
+						Required:   required[s.GetContext()],
+						DetailsURL: s.GetTargetURL(),
+						Summary:    s.GetDescription(),
+					})
+					reported[s.GetContext()] = true
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				statusOpts.Page = resp.NextPage
+			}
+
+			suites, resp, err := client.Checks.ListCheckSuitesForRef(ctx, owner, repo, sha, &github.ListCheckSuiteOptions{ListOptions: github.ListOptions{PerPage: 100}})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list check suites: %w", err)
+			}
+			_ = resp.Body.Close()
+
+			result := PullRequestChecks{
+				HeadSHA: sha,
+				Checks:  checks,
+			}
+			if result.Checks == nil {
+				result.Checks = []PullRequestCheck{}
+			}
+			for _, s := range suites.CheckSuites {
+				result.Suites = append(result.Suites, PullRequestCheckSuite{
+					App:        s.GetApp().GetSlug(),
+					Status:     s.GetStatus(),
+					Conclusion: s.GetConclusion(),
+				})
+			}
+			for name := range required {
+				if !reported[name] {
+					result.MissingRequiredChecks = append(result.MissingRequiredChecks, name)
+				}
+			}
+			sort.Strings(result.MissingRequiredChecks)
+
+			result.Verdict, result.Reasons, result.Warnings = mergeVerdict(pr, result.Checks, result.MissingRequiredChecks, strict)
+
+			return structuredTextResult(result), nil
+		}
+}
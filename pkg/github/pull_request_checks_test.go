@@ -0,0 +1,337 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestChecks(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := GetPullRequestChecks(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_pull_request_checks", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pullRequest := func(draft bool, mergeableState string) *github.PullRequest {
+		return &github.PullRequest{
+			Number:         github.Ptr(42),
+			State:          github.Ptr("open"),
+			Draft:          github.Ptr(draft),
+			Mergeable:      github.Ptr(true),
+			MergeableState: github.Ptr(mergeableState),
+			Head:           &github.PullRequestBranch{SHA: github.Ptr("abcd1234"), Ref: github.Ptr("feature")},
+			Base:           &github.PullRequestBranch{Ref: github.Ptr("main")},
+		}
+	}
+
+	protectedMain := &github.Branch{
+		Name: github.Ptr("main"),
+		Protection: &github.Protection{
+			RequiredStatusChecks: &github.RequiredStatusChecks{
+				Strict: true,
+				Checks: &[]*github.RequiredStatusCheck{{Context: "build"}},
+			},
+		},
+	}
+
+	checkRuns := func(runs ...*github.CheckRun) *github.ListCheckRunsResults {
+		return &github.ListCheckRunsResults{Total: github.Ptr(len(runs)), CheckRuns: runs}
+	}
+
+	lintStatus := func(state string) *github.CombinedStatus {
+		return &github.CombinedStatus{
+			State: github.Ptr(state),
+			Statuses: []*github.RepoStatus{
+				{
+					Context:     github.Ptr("lint"),
+					State:       github.Ptr(state),
+					Description: github.Ptr("golangci-lint"),
+					TargetURL:   github.Ptr("https://ci.example.com/lint/1"),
+				},
+			},
+		}
+	}
+
+	suites := &github.ListCheckSuiteResults{
+		Total: github.Ptr(1),
+		CheckSuites: []*github.CheckSuite{
+			{App: &github.App{Slug: github.Ptr("github-actions")}, Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		expectError     bool
+		expectedErrMsg  string
+		expectedVerdict string
+		expectedReasons []string
+		expectedMissing []string
+		verify          func(t *testing.T, result PullRequestChecks)
+	}{
+		{
+			name: "all required checks passing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(false, "clean")),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedMain),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsCheckRunsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/abcd1234/check-runs").andThen(
+						mockResponse(t, http.StatusOK, checkRuns(&github.CheckRun{
+							ID:         github.Ptr(int64(1)),
+							Name:       github.Ptr("build"),
+							Status:     github.Ptr("completed"),
+							Conclusion: github.Ptr("success"),
+							DetailsURL: github.Ptr("https://github.com/owner/repo/actions/runs/1"),
+							App:        &github.App{Slug: github.Ptr("github-actions")},
+							Output:     &github.CheckRunOutput{Title: github.Ptr("Build passed")},
+						})),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, lintStatus("success")),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, suites),
+			),
+			expectedVerdict: "mergeable",
+			verify: func(t *testing.T, result PullRequestChecks) {
+				assert.Equal(t, "abcd1234", result.HeadSHA)
+				require.Len(t, result.Checks, 2)
+				assert.Equal(t, PullRequestCheck{
+					Name:       "build",
+					Kind:       "check_run",
+					App:        "github-actions",
+					State:      "passing",
+					Conclusion: "success",
+					Required:   true,
+					DetailsURL: "https://github.com/owner/repo/actions/runs/1",
+					Summary:    "Build passed",
+				}, result.Checks[0])
+				assert.Equal(t, PullRequestCheck{
+					Name:       "lint",
+					Kind:       "status",
+					State:      "passing",
+					Conclusion: "success",
+					DetailsURL: "https://ci.example.com/lint/1",
+					Summary:    "golangci-lint",
+				}, result.Checks[1])
+				assert.Equal(t, []PullRequestCheckSuite{{App: "github-actions", Status: "completed", Conclusion: "success"}}, result.Suites)
+			},
+		},
+		{
+			name: "failing required check with annotations",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(false, "blocked")),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedMain),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(&github.CheckRun{
+					ID:         github.Ptr(int64(7)),
+					Name:       github.Ptr("build"),
+					Status:     github.Ptr("completed"),
+					Conclusion: github.Ptr("failure"),
+					Output:     &github.CheckRunOutput{Title: github.Ptr("1 error"), AnnotationsCount: github.Ptr(1)},
+				})),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					expectPath(t, "/repos/owner/repo/check-runs/7/annotations").andThen(
+						mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{
+							{
+								Path:            github.Ptr("main.go"),
+								StartLine:       github.Ptr(12),
+								EndLine:         github.Ptr(12),
+								AnnotationLevel: github.Ptr("failure"),
+								Message:         github.Ptr("undefined: foo"),
+							},
+						}),
+					),
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, lintStatus("failure")),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, suites),
+			),
+			expectedVerdict: "blocked",
+			expectedReasons: []string{`required check "build" is failing (failure)`},
+			verify: func(t *testing.T, result PullRequestChecks) {
+				require.Len(t, result.Checks, 2)
+				assert.Equal(t, []CheckAnnotation{
+					{Path: "main.go", StartLine: 12, EndLine: 12, Level: "failure", Message: "undefined: foo"},
+				}, result.Checks[0].Annotations)
+				assert.Equal(t, []string{`check "lint" is failing (failure) but is not required`}, result.Warnings)
+			},
+		},
+		{
+			name: "failing check whose annotations can't be fetched",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(false, "blocked")),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedMain),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []any{}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(&github.CheckRun{
+					ID:         github.Ptr(int64(7)),
+					Name:       github.Ptr("build"),
+					Status:     github.Ptr("completed"),
+					Conclusion: github.Ptr("failure"),
+					Output:     &github.CheckRunOutput{Title: github.Ptr("1 error"), AnnotationsCount: github.Ptr(1)},
+				})),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunId,
+					mockResponse(t, http.StatusInternalServerError, `{"message": "Server Error"}`),
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, lintStatus("success")),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, suites),
+			),
+			expectedVerdict: "blocked",
+			expectedReasons: []string{`required check "build" is failing (failure)`},
+			verify: func(t *testing.T, result PullRequestChecks) {
+				require.Len(t, result.Checks, 2)
+				assert.Empty(t, result.Checks[0].Annotations)
+				assert.Contains(t, result.Checks[0].AnnotationsError, "failed to list check run annotations")
+				assert.Equal(t, "lint", result.Checks[1].Name)
+			},
+		},
+		{
+			name: "required check from a ruleset not reported yet",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(false, "blocked")),
+				mock.WithRequestMatch(mock.GetReposBranchesByOwnerByRepoByBranch, protectedMain),
+				mock.WithRequestMatch(mock.GetReposRulesBranchesByOwnerByRepoByBranch, []map[string]any{
+					{
+						"type":                "required_status_checks",
+						"ruleset_source":      "owner/repo",
+						"ruleset_source_type": "Repository",
+						"ruleset_id":          1,
+						"parameters": map[string]any{
+							"required_status_checks":               []map[string]any{{"context": "deploy"}},
+							"strict_required_status_checks_policy": false,
+						},
+					},
+				}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(&github.CheckRun{
+					Name:       github.Ptr("build"),
+					Status:     github.Ptr("completed"),
+					Conclusion: github.Ptr("skipped"),
+				})),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, &github.ListCheckSuiteResults{}),
+			),
+			expectedVerdict: "pending",
+			expectedReasons: []string{`required check "deploy" has not reported yet`},
+			expectedMissing: []string{"deploy"},
+		},
+		{
+			name: "draft with unreadable branch protection",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(mock.GetReposPullsByOwnerByRepoByPullNumber, pullRequest(true, "draft")),
+				mock.WithRequestMatchHandler(
+					mock.GetReposBranchesByOwnerByRepoByBranch,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+					}),
+				),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckRunsByOwnerByRepoByRef, checkRuns(&github.CheckRun{
+					Name:   github.Ptr("build"),
+					Status: github.Ptr("in_progress"),
+				})),
+				mock.WithRequestMatch(mock.GetReposCommitsStatusByOwnerByRepoByRef, &github.CombinedStatus{}),
+				mock.WithRequestMatch(mock.GetReposCommitsCheckSuitesByOwnerByRepoByRef, &github.ListCheckSuiteResults{}),
+			),
+			expectedVerdict: "blocked",
+			expectedReasons: []string{"the pull request is a draft"},
+			verify: func(t *testing.T, result PullRequestChecks) {
+				require.Len(t, result.Checks, 1)
+				assert.Equal(t, "pending", result.Checks[0].State)
+				assert.Equal(t, "in_progress", result.Checks[0].Conclusion)
+				assert.False(t, result.Checks[0].Required)
+			},
+		},
+		{
+			name: "pull request fetch fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to get pull request",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestChecks(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returned PullRequestChecks
+			err = json.Unmarshal([]byte(textContent.Text), &returned)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVerdict, returned.Verdict)
+			assert.Equal(t, tc.expectedReasons, returned.Reasons)
+			assert.Equal(t, tc.expectedMissing, returned.MissingRequiredChecks)
+			if tc.verify != nil {
+				tc.verify(t, returned)
+			}
+		})
+	}
+}
+
+func Test_MergeVerdict(t *testing.T) {
+	pr := &github.PullRequest{
+		State:          github.Ptr("open"),
+		Mergeable:      github.Ptr(false),
+		MergeableState: github.Ptr("dirty"),
+		Base:           &github.PullRequestBranch{Ref: github.Ptr("main")},
+	}
+	verdict, reasons, _ := mergeVerdict(pr, nil, nil, false)
+	assert.Equal(t, "blocked", verdict)
+	assert.Equal(t, []string{"the pull request has merge conflicts with main"}, reasons)
+
+	pr.Mergeable = github.Ptr(true)
+	pr.MergeableState = github.Ptr("behind")
+	verdict, reasons, _ = mergeVerdict(pr, nil, nil, true)
+	assert.Equal(t, "blocked", verdict)
+	assert.Equal(t, []string{"the branch is behind main, which must be merged in first"}, reasons)
+
+	verdict, reasons, _ = mergeVerdict(pr, nil, nil, false)
+	assert.Equal(t, "mergeable", verdict)
+	assert.Nil(t, reasons)
+
+	pr.Merged = github.Ptr(true)
+	verdict, _, _ = mergeVerdict(pr, nil, nil, false)
+	assert.Equal(t, "merged", verdict)
+}
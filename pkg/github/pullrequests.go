@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v72/github"
@@ -36,6 +36,9 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedPullRequest](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -51,6 +54,11 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -62,19 +70,24 @@ func GetPullRequest(getClient GetClientFn, t translations.TranslationHelperFunc)
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get pull request"), nil
+			}
+
+			structured := cleanPullRequest(pr)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderPullRequest(pr)), structured), nil
 			}
 
-			r, err := json.Marshal(pr)
+			projected, err := projectResult(request, compactOr(ctx, pr, cleanPullRequest))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -115,6 +128,7 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			mcp.WithBoolean("maintainer_can_modify",
 				mcp.Description("Allow maintainer edits"),
 			),
+			WithOutputSchema[CleanedPullRequest](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -177,19 +191,15 @@ func CreatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create pull request: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create pull request"), nil
 			}
 
-			r, err := json.Marshal(pr)
+			r, err := json.Marshal(compactOr(ctx, pr, cleanPullRequest))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanPullRequest(pr)), nil
 		}
 }
 
@@ -229,6 +239,7 @@ func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			mcp.WithBoolean("maintainer_can_modify",
 				mcp.Description("Allow maintainer edits"),
 			),
+			WithOutputSchema[CleanedPullRequest](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -298,19 +309,15 @@ func UpdatePullRequest(getClient GetClientFn, t translations.TranslationHelperFu
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to update pull request"), nil
 			}
 
-			r, err := json.Marshal(pr)
+			r, err := json.Marshal(compactOr(ctx, pr, cleanPullRequest))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanPullRequest(pr)), nil
 		}
 }
 
@@ -349,6 +356,9 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedPullRequest]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -390,43 +400,152 @@ func ListPullRequests(getClient GetClientFn, t translations.TranslationHelperFun
 				Base:      base,
 				Sort:      sort,
 				Direction: direction,
-				ListOptions: github.ListOptions{
-					PerPage: pagination.perPage,
-					Page:    pagination.page,
-				},
+			}
+
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			prs, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+			prs, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.PullRequests.List(ctx, owner, repo, opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list pull requests: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list pull requests: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to list pull requests"), nil
 			}
 
-			r, err := json.Marshal(prs)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			structured := listResult(cleanPullRequests(prs), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderPullRequests(prs)), next), structured), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, prs, cleanPullRequests), structured, next), nil
+		}
+}
+
+// SearchPullRequests creates a tool to search for pull requests with typed filters.
+func SearchPullRequests(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("search_pull_requests",
+			mcp.WithDescription(t("TOOL_SEARCH_PULL_REQUESTS_DESCRIPTION", "Search for pull requests across GitHub repositories. Filters are combined into a search query, so no search syntax is needed; at least one of query or the filters is required.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_PULL_REQUESTS_USER_TITLE", "Search pull requests"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Description("Keywords to match in the title, body or comments"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Only match pull requests in repositories of this user or organization"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Only match pull requests in this repository of owner"),
+			),
+			mcp.WithString("author",
+				mcp.Description("Login of the author, or @me"),
+			),
+			mcp.WithString("assignee",
+				mcp.Description("Login of an assignee, or @me"),
+			),
+			mcp.WithString("mentions",
+				mcp.Description("Login of a mentioned user, or @me"),
+			),
+			mcp.WithString("reviewed_by",
+				mcp.Description("Login of a user who reviewed the pull request, or @me"),
+			),
+			mcp.WithString("review_requested",
+				mcp.Description("Login of a user whose review is requested, or @me"),
+			),
+			mcp.WithString("state",
+				mcp.Description("Filter by state"),
+				mcp.Enum("open", "closed", "merged", "unmerged"),
+			),
+			mcp.WithBoolean("draft",
+				mcp.Description("Only match draft pull requests when true, or ready ones when false"),
+			),
+			mcp.WithString("review",
+				mcp.Description("Filter by review status"),
+				mcp.Enum("none", "required", "approved", "changes_requested"),
+			),
+			mcp.WithArray("labels",
+				mcp.Description("Labels the pull requests all have"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+					},
+				),
+			),
+			mcp.WithString("base",
+				mcp.Description("Filter by base branch"),
+			),
+			mcp.WithString("head",
+				mcp.Description("Filter by head branch"),
+			),
+			WithDateRange("created", "created"),
+			WithDateRange("updated", "updated"),
+			WithDateRange("merged", "merged"),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, defaults to best match"),
+				mcp.Enum("comments", "reactions", "interactions", "created", "updated"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[SearchResult[*CleanedIssue]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := newSearchQuery(request, "is:pr").
+				keywords("query").
+				repository().
+				login("author", "author").
+				login("assignee", "assignee").
+				login("mentions", "mentions").
+				login("reviewed_by", "reviewed-by").
+				login("review_requested", "review-requested").
+				enum("state", map[string]string{
+					"open":     "is:open",
+					"closed":   "is:closed",
+					"merged":   "is:merged",
+					"unmerged": "is:unmerged",
+				}).
+				boolean("draft", "draft").
+				enum("review", map[string]string{
+					"none":              "review:none",
+					"required":          "review:required",
+					"approved":          "review:approved",
+					"changes_requested": "review:changes_requested",
+				}).
+				values("labels", "label").
+				value("base", "base").
+				value("head", "head").
+				dateRange("created", "created").
+				dateRange("updated", "updated").
+				dateRange("merged", "merged").
+				build()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			return searchIssues(ctx, getClient, request, query, "failed to search pull requests")
 		}
 }
 
 // MergePullRequest creates a tool to merge a pull request.
 func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("merge_pull_request",
-			mcp.WithDescription(t("TOOL_MERGE_PULL_REQUEST_DESCRIPTION", "Merge a pull request in a GitHub repository.")),
+			mcp.WithDescription(t("TOOL_MERGE_PULL_REQUEST_DESCRIPTION", "Merge a pull request in a GitHub repository. If the base branch requires a merge queue, use enqueue_pull_request instead.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_MERGE_PULL_REQUEST_USER_TITLE", "Merge pull request"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -453,6 +572,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Description("Merge method"),
 				mcp.Enum("merge", "squash", "rebase"),
 			),
+			WithOutputSchema[github.PullRequestMergeResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -491,16 +611,18 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 			}
 			result, resp, err := client.PullRequests.Merge(ctx, owner, repo, pullNumber, commitMessage, options)
 			if err != nil {
+				// Branches with a merge queue reject direct merges, which the queue has to perform instead.
+				if resp != nil && resp.StatusCode == http.StatusMethodNotAllowed && strings.Contains(strings.ToLower(err.Error()), "merge queue") {
+					toolErr := classifyError(fmt.Errorf("failed to merge pull request: %w", err))
+					toolErr.Hint = "The base branch requires a merge queue, use enqueue_pull_request instead."
+					return toolErr.result(), nil
+				}
 				return nil, fmt.Errorf("failed to merge pull request: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to merge pull request: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to merge pull request"), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -508,7 +630,7 @@ func MergePullRequest(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), result), nil
 		}
 }
 
@@ -532,6 +654,9 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*github.CommitFile]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -546,32 +671,28 @@ func GetPullRequestFiles(getClient GetClientFn, t translations.TranslationHelper
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			opts := &github.ListOptions{}
-			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+			files, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+				return client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, &opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request files: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request files: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get pull request files"), nil
 			}
 
-			r, err := json.Marshal(files)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, files, listResult(files, next), next), nil
 		}
 }
 
@@ -595,6 +716,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[github.CombinedStatus](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -621,11 +743,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get pull request"), nil
 			}
 
 			// Get combined status for the head SHA
@@ -636,11 +754,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get combined status: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get combined status"), nil
 			}
 
 			r, err := json.Marshal(status)
@@ -648,7 +762,7 @@ func GetPullRequestStatus(getClient GetClientFn, t translations.TranslationHelpe
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), status), nil
 		}
 }
 
@@ -675,6 +789,7 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 			mcp.WithString("expectedHeadSha",
 				mcp.Description("The expected SHA of the pull request's HEAD ref"),
 			),
+			WithOutputSchema[github.PullRequestBranchUpdateResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -707,18 +822,15 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Pull request branch update is in progress"), nil
+					message := "Pull request branch update is in progress"
+					return structuredResult(mcp.NewToolResultText(message), &github.PullRequestBranchUpdateResponse{Message: &message}), nil
 				}
 				return nil, fmt.Errorf("failed to update pull request branch: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusAccepted {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update pull request branch: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to update pull request branch"), nil
 			}
 
 			r, err := json.Marshal(result)
@@ -726,7 +838,7 @@ func UpdatePullRequestBranch(getClient GetClientFn, t translations.TranslationHe
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), result), nil
 		}
 }
 
@@ -750,6 +862,9 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*CleanedPullRequestComment]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -765,36 +880,28 @@ func GetPullRequestComments(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.PullRequestListCommentsOptions{
-				ListOptions: github.ListOptions{
-					PerPage: 100,
-				},
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			comments, resp, err := client.PullRequests.ListComments(ctx, owner, repo, pullNumber, opts)
+			comments, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.PullRequestComment, *github.Response, error) {
+				return client.PullRequests.ListComments(ctx, owner, repo, pullNumber, &github.PullRequestListCommentsOptions{ListOptions: opts})
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request comments: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request comments: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(comments)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return responseErrorResult(resp, "failed to get pull request comments"), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, comments, cleanPullRequestComments), listResult(cleanPullRequestComments(comments), next), next), nil
 		}
 }
 
@@ -818,6 +925,10 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedPullRequestReview]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -832,31 +943,37 @@ func GetPullRequestReviews(getClient GetClientFn, t translations.TranslationHelp
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			reviews, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, nil)
+			reviews, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+				return client.PullRequests.ListReviews(ctx, owner, repo, pullNumber, &opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get pull request reviews: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request reviews: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get pull request reviews"), nil
 			}
 
-			r, err := json.Marshal(reviews)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			structured := listResult(cleanPullRequestReviews(reviews), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderPullRequestReviews(reviews)), next), structured), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, reviews, cleanPullRequestReviews), structured, next), nil
 		}
 }
 
@@ -894,6 +1011,7 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 			mcp.WithString("commitID",
 				mcp.Description("SHA of commit to review"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -911,7 +1029,7 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 			// Given our owner, repo and PR number, lookup the GQL ID of the PR.
 			client, err := getGQLClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+				return toolErrorResult(fmt.Errorf("failed to get GitHub GQL client: %w", err)), nil
 			}
 
 			var getPullRequestQuery struct {
@@ -926,7 +1044,7 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 				"repo":  githubv4.String(params.Repo),
 				"prNum": githubv4.Int(params.PullNumber),
 			}); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Now we have the GQL ID, we can create a review
@@ -949,13 +1067,13 @@ func CreateAndSubmitPullRequestReview(getGQLClient GetGQLClientFn, t translation
 				},
 				nil,
 			); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pull request review submitted successfully"), nil
+			return actionResult("pull request review submitted successfully"), nil
 		}
 }
 
@@ -987,6 +1105,7 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			),
 			// Event is omitted here because we always want to create a pending review.
 			// Threads are omitted for the moment, and we'll see if the LLM can use the appropriate tool.
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1002,7 +1121,7 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			// Given our owner, repo and PR number, lookup the GQL ID of the PR.
 			client, err := getGQLClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil
+				return toolErrorResult(fmt.Errorf("failed to get GitHub GQL client: %w", err)), nil
 			}
 
 			var getPullRequestQuery struct {
@@ -1017,7 +1136,7 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				"repo":  githubv4.String(params.Repo),
 				"prNum": githubv4.Int(params.PullNumber),
 			}); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Now we have the GQL ID, we can create a pending review
@@ -1038,14 +1157,63 @@ func CreatePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				},
 				nil,
 			); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pending pull request created"), nil
+			return actionResult("pending pull request created"), nil
+		}
+}
+
+// getViewerPendingReviewID returns the ID of the pending review of the authenticated user on a pull request.
+func getViewerPendingReviewID(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int32) (githubv4.ID, error) {
+	var getViewerQuery struct {
+		Viewer struct {
+			Login githubv4.String
 		}
+	}
+
+	if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
+		return nil, err
+	}
+
+	var getLatestReviewForViewerQuery struct {
+		Repository struct {
+			PullRequest struct {
+				Reviews struct {
+					Nodes []struct {
+						ID    githubv4.ID
+						State githubv4.PullRequestReviewState
+						URL   githubv4.URI
+					}
+				} `graphql:"reviews(first: 1, author: $author)"`
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	vars := map[string]any{
+		"author": githubv4.String(getViewerQuery.Viewer.Login),
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"prNum":  githubv4.Int(pullNumber),
+	}
+
+	if err := client.Query(ctx, &getLatestReviewForViewerQuery, vars); err != nil {
+		return nil, err
+	}
+
+	if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
+		return nil, newToolError(ErrorNotFound, "No pending review found for the viewer")
+	}
+
+	review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
+	if review.State != githubv4.PullRequestReviewStatePending {
+		return nil, newToolError(ErrorConflict, fmt.Sprintf("The latest review, found at %s is not pending", review.URL))
+	}
+
+	return review.ID, nil
 }
 
 // AddPullRequestReviewCommentToPendingReview creates a tool to add a comment to a pull request review.
@@ -1104,6 +1272,7 @@ func AddPullRequestReviewCommentToPendingReview(getGQLClient GetGQLClientFn, t t
 				mcp.Description("For multi-line comments, the starting side of the diff that the comment applies to. LEFT indicates the previous state, RIGHT indicates the new state"),
 				mcp.Enum("LEFT", "RIGHT"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1127,51 +1296,9 @@ func AddPullRequestReviewCommentToPendingReview(getGQLClient GetGQLClientFn, t t
 				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
 
-			// First we'll get the current user
-			var getViewerQuery struct {
-				Viewer struct {
-					Login githubv4.String
-				}
-			}
-
-			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			var getLatestReviewForViewerQuery struct {
-				Repository struct {
-					PullRequest struct {
-						Reviews struct {
-							Nodes []struct {
-								ID    githubv4.ID
-								State githubv4.PullRequestReviewState
-								URL   githubv4.URI
-							}
-						} `graphql:"reviews(first: 1, author: $author)"`
-					} `graphql:"pullRequest(number: $prNum)"`
-				} `graphql:"repository(owner: $owner, name: $name)"`
-			}
-
-			vars := map[string]any{
-				"author": githubv4.String(getViewerQuery.Viewer.Login),
-				"owner":  githubv4.String(params.Owner),
-				"name":   githubv4.String(params.Repo),
-				"prNum":  githubv4.Int(params.PullNumber),
-			}
-
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Validate there is one review and the state is pending
-			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
-				return mcp.NewToolResultError("No pending review found for the viewer"), nil
-			}
-
-			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
-			if review.State != githubv4.PullRequestReviewStatePending {
-				errText := fmt.Sprintf("The latest review, found at %s is not pending", review.URL)
-				return mcp.NewToolResultError(errText), nil
+			reviewID, err := getViewerPendingReviewID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
 			}
 
 			// Then we can create a new review thread comment on the review.
@@ -1194,17 +1321,17 @@ func AddPullRequestReviewCommentToPendingReview(getGQLClient GetGQLClientFn, t t
 					Side:                newGQLStringlikePtr[githubv4.DiffSide](params.Side),
 					StartLine:           newGQLIntPtr(params.StartLine),
 					StartSide:           newGQLStringlikePtr[githubv4.DiffSide](params.StartSide),
-					PullRequestReviewID: &review.ID,
+					PullRequestReviewID: &reviewID,
 				},
 				nil,
 			); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pull request review comment successfully added to pending review"), nil
+			return actionResult("pull request review comment successfully added to pending review"), nil
 		}
 }
 
@@ -1240,6 +1367,7 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 			mcp.WithString("body",
 				mcp.Description("The text of the review comment"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1258,51 +1386,9 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
 
-			// First we'll get the current user
-			var getViewerQuery struct {
-				Viewer struct {
-					Login githubv4.String
-				}
-			}
-
-			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			var getLatestReviewForViewerQuery struct {
-				Repository struct {
-					PullRequest struct {
-						Reviews struct {
-							Nodes []struct {
-								ID    githubv4.ID
-								State githubv4.PullRequestReviewState
-								URL   githubv4.URI
-							}
-						} `graphql:"reviews(first: 1, author: $author)"`
-					} `graphql:"pullRequest(number: $prNum)"`
-				} `graphql:"repository(owner: $owner, name: $name)"`
-			}
-
-			vars := map[string]any{
-				"author": githubv4.String(getViewerQuery.Viewer.Login),
-				"owner":  githubv4.String(params.Owner),
-				"name":   githubv4.String(params.Repo),
-				"prNum":  githubv4.Int(params.PullNumber),
-			}
-
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Validate there is one review and the state is pending
-			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
-				return mcp.NewToolResultError("No pending review found for the viewer"), nil
-			}
-
-			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
-			if review.State != githubv4.PullRequestReviewStatePending {
-				errText := fmt.Sprintf("The latest review, found at %s is not pending", review.URL)
-				return mcp.NewToolResultError(errText), nil
+			reviewID, err := getViewerPendingReviewID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
 			}
 
 			// Prepare the mutation
@@ -1318,19 +1404,19 @@ func SubmitPendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				ctx,
 				&submitPullRequestReviewMutation,
 				githubv4.SubmitPullRequestReviewInput{
-					PullRequestReviewID: &review.ID,
+					PullRequestReviewID: &reviewID,
 					Event:               githubv4.PullRequestReviewEvent(params.Event),
 					Body:                newGQLStringlikePtr[githubv4.String](params.Body),
 				},
 				nil,
 			); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pending pull request review successfully submitted"), nil
+			return actionResult("pending pull request review successfully submitted"), nil
 		}
 }
 
@@ -1357,6 +1443,7 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
@@ -1373,51 +1460,9 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
 			}
 
-			// First we'll get the current user
-			var getViewerQuery struct {
-				Viewer struct {
-					Login githubv4.String
-				}
-			}
-
-			if err := client.Query(ctx, &getViewerQuery, nil); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			var getLatestReviewForViewerQuery struct {
-				Repository struct {
-					PullRequest struct {
-						Reviews struct {
-							Nodes []struct {
-								ID    githubv4.ID
-								State githubv4.PullRequestReviewState
-								URL   githubv4.URI
-							}
-						} `graphql:"reviews(first: 1, author: $author)"`
-					} `graphql:"pullRequest(number: $prNum)"`
-				} `graphql:"repository(owner: $owner, name: $name)"`
-			}
-
-			vars := map[string]any{
-				"author": githubv4.String(getViewerQuery.Viewer.Login),
-				"owner":  githubv4.String(params.Owner),
-				"name":   githubv4.String(params.Repo),
-				"prNum":  githubv4.Int(params.PullNumber),
-			}
-
-			if err := client.Query(context.Background(), &getLatestReviewForViewerQuery, vars); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
-			}
-
-			// Validate there is one review and the state is pending
-			if len(getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes) == 0 {
-				return mcp.NewToolResultError("No pending review found for the viewer"), nil
-			}
-
-			review := getLatestReviewForViewerQuery.Repository.PullRequest.Reviews.Nodes[0]
-			if review.State != githubv4.PullRequestReviewStatePending {
-				errText := fmt.Sprintf("The latest review, found at %s is not pending", review.URL)
-				return mcp.NewToolResultError(errText), nil
+			reviewID, err := getViewerPendingReviewID(ctx, client, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
 			}
 
 			// Prepare the mutation
@@ -1433,23 +1478,31 @@ func DeletePendingPullRequestReview(getGQLClient GetGQLClientFn, t translations.
 				ctx,
 				&deletePullRequestReviewMutation,
 				githubv4.DeletePullRequestReviewInput{
-					PullRequestReviewID: &review.ID,
+					PullRequestReviewID: &reviewID,
 				},
 				nil,
 			); err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+				return toolErrorResult(err), nil
 			}
 
 			// Return nothing interesting, just indicate success for the time being.
 			// In future, we may want to return the review ID, but for the moment, we're not leaking
 			// API implementation details to the LLM.
-			return mcp.NewToolResultText("pending pull request review successfully deleted"), nil
+			return actionResult("pending pull request review successfully deleted"), nil
 		}
 }
 
+// PullRequestDiff is the structured content of get_pull_request_diff, holding the diff in the requested format.
+type PullRequestDiff struct {
+	// Diff is the unified diff, in the raw format.
+	Diff string `json:"diff,omitempty"`
+	// Structured is the page of parsed files, in the structured format.
+	Structured *StructuredDiff `json:"structured,omitempty"`
+}
+
 func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
 	return mcp.NewTool("get_pull_request_diff",
-			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request.")),
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_DIFF_DESCRIPTION", "Get the diff of a pull request. The raw format returns the whole unified diff. The structured format returns a page of files with parsed hunks, line numbers and change counts; prefer it for large pull requests, narrowing it down with a path pattern and a per-file line limit.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_GET_PULL_REQUEST_DIFF_USER_TITLE", "Get pull request diff"),
 				ReadOnlyHint: toBoolPtr(true),
@@ -1466,20 +1519,58 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			mcp.WithString("format",
+				mcp.Description("Output format, defaults to raw"),
+				mcp.Enum("raw", "structured"),
+			),
+			mcp.WithString("path",
+				mcp.Description("Structured format only: glob pattern of the files to include, such as 'pkg/github/*.go'. Patterns without a slash match file names in any directory, such as '*.go'"),
+			),
+			mcp.WithNumber("maxLinesPerFile",
+				mcp.Description("Structured format only: maximum number of diff lines to return per file. Files with more lines are marked as truncated"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("page",
+				mcp.Description("Structured format only: page number of files (min 1)"),
+				mcp.Min(1),
+			),
+			mcp.WithNumber("perPage",
+				mcp.Description("Structured format only: files per page (min 1, max 100)"),
+				mcp.Min(1),
+				mcp.Max(100),
+			),
+			WithOutputSchema[PullRequestDiff](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			var params struct {
-				Owner      string
-				Repo       string
-				PullNumber int32
+				Owner           string
+				Repo            string
+				PullNumber      int32
+				Format          string
+				Path            string
+				MaxLinesPerFile int
+				Page            int
+				PerPage         int
 			}
 			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			if params.Page == 0 {
+				params.Page = 1
+			}
+			if params.PerPage == 0 {
+				params.PerPage = 30
+			}
+			if params.Page < 1 {
+				return mcp.NewToolResultError("page must be at least 1"), nil
+			}
+			if params.PerPage < 1 || params.PerPage > 100 {
+				return mcp.NewToolResultError("perPage must be between 1 and 100"), nil
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get GitHub client: %v", err)), nil
+				return toolErrorResult(fmt.Errorf("failed to get GitHub client: %w", err)), nil
 			}
 
 			raw, resp, err := client.PullRequests.GetRaw(
@@ -1494,17 +1585,26 @@ func GetPullRequestDiff(getClient GetClientFn, t translations.TranslationHelperF
 			}
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get pull request diff: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get pull request diff"), nil
 			}
 
 			defer func() { _ = resp.Body.Close() }()
 
-			// Return the raw response
-			return mcp.NewToolResultText(string(raw)), nil
+			if params.Format != "structured" {
+				// Return the raw response
+				return structuredResult(mcp.NewToolResultText(string(raw)), PullRequestDiff{Diff: string(raw)}), nil
+			}
+
+			files, err := parseUnifiedDiff(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pull request diff: %w", err)
+			}
+			result, err := structuredDiff(files, params.Path, params.Page, params.PerPage, params.MaxLinesPerFile)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return structuredResult(MarshalledTextResult(result), PullRequestDiff{Structured: &result}), nil
 		}
 }
 
@@ -1530,6 +1630,7 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 				mcp.Required(),
 				mcp.Description("Pull request number"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -1568,15 +1669,11 @@ func RequestCopilotReview(getClient GetClientFn, t translations.TranslationHelpe
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to request copilot review: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to request copilot review"), nil
 			}
 
-			// Return nothing on success, as there's not much value in returning the Pull Request itself
-			return mcp.NewToolResultText(""), nil
+			// Only confirm the request, as there's not much value in returning the Pull Request itself
+			return actionResult("copilot review requested"), nil
 		}
 }
 
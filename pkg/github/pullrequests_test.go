@@ -470,6 +470,7 @@ func Test_MergePullRequest(t *testing.T) {
 		expectError         bool
 		expectedMergeResult *github.PullRequestMergeResult
 		expectedErrMsg      string
+		expectedToolErrMsg  string
 	}{
 		{
 			name: "successful merge",
@@ -515,6 +516,24 @@ func Test_MergePullRequest(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to merge pull request",
 		},
+		{
+			name: "base branch requires a merge queue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusMethodNotAllowed)
+						_, _ = w.Write([]byte(`{"message": "Changes must be made through the merge queue"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectedToolErrMsg: "The base branch requires a merge queue, use enqueue_pull_request instead",
+		},
 	}
 
 	for _, tc := range tests {
@@ -541,6 +560,12 @@ func Test_MergePullRequest(t *testing.T) {
 			// Parse the result and get the text content if no error
 			textContent := getTextResult(t, result)
 
+			if tc.expectedToolErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
 			// Unmarshal and verify the result
 			var returnedResult github.PullRequestMergeResult
 			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
@@ -1650,7 +1675,7 @@ func Test_RequestCopilotReview(t *testing.T) {
 			assert.Len(t, result.Content, 1)
 
 			textContent := getTextResult(t, result)
-			require.Equal(t, "", textContent.Text)
+			require.Equal(t, "copilot review requested", textContent.Text)
 		})
 	}
 }
@@ -2149,13 +2174,18 @@ func TestGetPullRequestDiff(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "owner")
 	assert.Contains(t, tool.InputSchema.Properties, "repo")
 	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "format")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "maxLinesPerFile")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
 
 	stubbedDiff := `diff --git a/README.md b/README.md
 index 5d6e7b2..8a4f5c3 100644
 --- a/README.md
 +++ b/README.md
-@@ -1,4 +1,6 @@
+@@ -1,4 +1,7 @@
  # Hello-World
 
  Hello World project for GitHub
@@ -2170,6 +2200,7 @@ index 5d6e7b2..8a4f5c3 100644
 		mockedClient       *http.Client
 		expectToolError    bool
 		expectedToolErrMsg string
+		expectedStructured *StructuredDiff
 	}{
 		{
 			name: "successful diff retrieval",
@@ -2189,6 +2220,96 @@ index 5d6e7b2..8a4f5c3 100644
 			),
 			expectToolError: false,
 		},
+		{
+			name: "structured diff",
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"pullNumber":      float64(42),
+				"format":          "structured",
+				"path":            "*.md",
+				"maxLinesPerFile": float64(4),
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+						mockResponse(t, http.StatusOK, stubbedDiff),
+					),
+				),
+			),
+			expectedStructured: &StructuredDiff{
+				TotalFiles: 1,
+				Files: []DiffFile{
+					{
+						Path:      "README.md",
+						Status:    "modified",
+						Additions: 3,
+						Deletions: 0,
+						Hunks: []DiffHunk{
+							{
+								Header:   "@@ -1,4 +1,7 @@",
+								OldStart: 1,
+								OldLines: 4,
+								NewStart: 1,
+								NewLines: 7,
+								Lines: []DiffLine{
+									{Type: "context", Content: "# Hello-World", OldLine: 1, NewLine: 1},
+									{Type: "context", Content: "", OldLine: 2, NewLine: 2},
+									{Type: "context", Content: "Hello World project for GitHub", OldLine: 3, NewLine: 3},
+									{Type: "context", Content: "", OldLine: 4, NewLine: 4},
+								},
+							},
+						},
+						Truncated: true,
+					},
+				},
+			},
+		},
+		{
+			name: "structured diff with invalid path pattern",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"format":     "structured",
+				"path":       "[",
+			},
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposPullsByOwnerByRepoByPullNumber,
+					mockResponse(t, http.StatusOK, stubbedDiff),
+				),
+			),
+			expectToolError:    true,
+			expectedToolErrMsg: "invalid path pattern",
+		},
+		{
+			name: "structured diff with negative page",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"format":     "structured",
+				"page":       float64(-1),
+			},
+			mockedClient:       mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "page must be at least 1",
+		},
+		{
+			name: "structured diff with too many files per page",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"format":     "structured",
+				"perPage":    float64(101),
+			},
+			mockedClient:       mock.NewMockedHTTPClient(),
+			expectToolError:    true,
+			expectedToolErrMsg: "perPage must be between 1 and 100",
+		},
 	}
 
 	for _, tc := range tests {
@@ -2214,6 +2335,14 @@ index 5d6e7b2..8a4f5c3 100644
 				return
 			}
 
+			if tc.expectedStructured != nil {
+				var returnedDiff StructuredDiff
+				err = json.Unmarshal([]byte(textContent.Text), &returnedDiff)
+				require.NoError(t, err)
+				assert.Equal(t, *tc.expectedStructured, returnedDiff)
+				return
+			}
+
 			// Parse the result and get the text content if no error
 			require.Equal(t, stubbedDiff, textContent.Text)
 		})
@@ -2291,3 +2420,97 @@ func getLatestPendingReviewQuery(p getLatestPendingReviewQueryParams) githubv4mo
 		),
 	)
 }
+
+func Test_SearchPullRequests(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchPullRequests(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "search_pull_requests", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "review")
+	assert.Contains(t, tool.InputSchema.Properties, "labels")
+	assert.Contains(t, tool.InputSchema.Properties, "merged_after")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockSearchResult := &github.IssuesSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Issues: []*github.Issue{
+			{
+				Number:           github.Ptr(42),
+				Title:            github.Ptr("Fix crash on startup"),
+				State:            github.Ptr("open"),
+				PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "filters composed into query",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchIssues,
+					expectQueryParams(t, map[string]string{
+						"q":        `is:pr repo:owner/repo author:octocat is:open draft:false review:approved label:bug label:"help wanted" base:main updated:2025-01-01..2025-02-01`,
+						"sort":     "updated",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":          "owner",
+				"repo":           "repo",
+				"author":         "octocat",
+				"state":          "open",
+				"draft":          false,
+				"review":         "approved",
+				"labels":         []interface{}{"bug", "help wanted"},
+				"base":           "main",
+				"updated_after":  "2025-01-01",
+				"updated_before": "2025-02-01",
+				"sort":           "updated",
+			},
+		},
+		{
+			name:           "invalid reviewer",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"reviewed_by": "octo cat"},
+			expectError:    true,
+			expectedErrMsg: `invalid reviewed_by: "octo cat" is not a GitHub login`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchPullRequests(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedResult github.IssuesSearchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+			require.Len(t, returnedResult.Issues, 1)
+			assert.Equal(t, 42, *returnedResult.Issues[0].Number)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	reactionSubjectIssue                    = "issue"
+	reactionSubjectIssueComment             = "issue_comment"
+	reactionSubjectPullRequestReviewComment = "pull_request_review_comment"
+)
+
+// withReactionSubject adds the parameters identifying the issue or comment a reaction belongs to.
+func withReactionSubject() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithString("subject_type",
+			mcp.Required(),
+			mcp.Description("What the reaction is on: an issue or pull request, a comment on one, or a pull request review comment on a line of the diff"),
+			mcp.Enum(reactionSubjectIssue, reactionSubjectIssueComment, reactionSubjectPullRequestReviewComment),
+		)(tool)
+		mcp.WithNumber("subject_id",
+			mcp.Required(),
+			mcp.Description("The issue or pull request number when subject_type is issue, otherwise the comment ID"),
+		)(tool)
+	}
+}
+
+// AddReaction creates a tool to react to an issue, pull request or comment.
+func AddReaction(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_reaction",
+			mcp.WithDescription(t("TOOL_ADD_REACTION_DESCRIPTION", "Add a reaction to an issue, pull request, issue comment or pull request review comment. Adding a reaction the authenticated user already made returns the existing one. The returned ID can be passed to remove_reaction.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_ADD_REACTION_USER_TITLE", "Add reaction"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			withReactionSubject(),
+			mcp.WithString("content",
+				mcp.Required(),
+				mcp.Description("The reaction"),
+				mcp.Enum("+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"),
+			),
+			WithOutputSchema[github.Reaction](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subjectType, err := requiredParam[string](request, "subject_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subjectID, err := RequiredInt(request, "subject_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			content, err := requiredParam[string](request, "content")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var reaction *github.Reaction
+			var resp *github.Response
+			switch subjectType {
+			case reactionSubjectIssue:
+				reaction, resp, err = client.Reactions.CreateIssueReaction(ctx, owner, repo, subjectID, content)
+			case reactionSubjectIssueComment:
+				reaction, resp, err = client.Reactions.CreateIssueCommentReaction(ctx, owner, repo, int64(subjectID), content)
+			case reactionSubjectPullRequestReviewComment:
+				reaction, resp, err = client.Reactions.CreatePullRequestCommentReaction(ctx, owner, repo, int64(subjectID), content)
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unknown subject_type: %s", subjectType)), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to add reaction: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			// 200 means the reaction already existed, 201 that it was created.
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+				return responseErrorResult(resp, "failed to add reaction"), nil
+			}
+
+			r, err := json.Marshal(reaction)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), reaction), nil
+		}
+}
+
+// RemoveReaction creates a tool to remove a reaction from an issue, pull request or comment.
+func RemoveReaction(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_reaction",
+			mcp.WithDescription(t("TOOL_REMOVE_REACTION_DESCRIPTION", "Remove a reaction from an issue, pull request, issue comment or pull request review comment. Only reactions made by the authenticated user can be removed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_REACTION_USER_TITLE", "Remove reaction"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withReactionSubject(),
+			mcp.WithNumber("reaction_id",
+				mcp.Required(),
+				mcp.Description("The ID of the reaction, as returned by add_reaction"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subjectType, err := requiredParam[string](request, "subject_type")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subjectID, err := RequiredInt(request, "subject_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			reactionID, err := RequiredInt(request, "reaction_id")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var resp *github.Response
+			switch subjectType {
+			case reactionSubjectIssue:
+				resp, err = client.Reactions.DeleteIssueReaction(ctx, owner, repo, subjectID, int64(reactionID))
+			case reactionSubjectIssueComment:
+				resp, err = client.Reactions.DeleteIssueCommentReaction(ctx, owner, repo, int64(subjectID), int64(reactionID))
+			case reactionSubjectPullRequestReviewComment:
+				resp, err = client.Reactions.DeletePullRequestCommentReaction(ctx, owner, repo, int64(subjectID), int64(reactionID))
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("unknown subject_type: %s", subjectType)), nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove reaction: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusNoContent {
+				return responseErrorResult(resp, "failed to remove reaction"), nil
+			}
+
+			return actionResult(fmt.Sprintf("reaction %d removed", reactionID)), nil
+		}
+}
@@ -0,0 +1,246 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddReaction(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "subject_type")
+	assert.Contains(t, tool.InputSchema.Properties, "subject_id")
+	assert.Contains(t, tool.InputSchema.Properties, "content")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "subject_id", "content"})
+
+	mockReaction := &github.Reaction{
+		ID:      github.Ptr(int64(1001)),
+		Content: github.Ptr("rocket"),
+		User:    &github.User{Login: github.Ptr("octocat")},
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]interface{}
+		expectError      bool
+		expectToolError  bool
+		expectedReaction *github.Reaction
+		expectedErrMsg   string
+	}{
+		{
+			name: "react to an issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/42/reactions").andThen(
+						mockResponse(t, http.StatusCreated, mockReaction),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"subject_id":   float64(42),
+				"content":      "rocket",
+			},
+			expectedReaction: mockReaction,
+		},
+		{
+			name: "reaction already exists on an issue comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesCommentsReactionsByOwnerByRepoByCommentId,
+					expectRequestBody(t, map[string]interface{}{
+						"content": "rocket",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockReaction),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue_comment",
+				"subject_id":   float64(123456),
+				"content":      "rocket",
+			},
+			expectedReaction: mockReaction,
+		},
+		{
+			name: "react to a pull request review comment",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposPullsCommentsReactionsByOwnerByRepoByCommentId,
+					expectPath(t, "/repos/owner/repo/pulls/comments/654321/reactions").andThen(
+						mockResponse(t, http.StatusCreated, mockReaction),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "pull_request_review_comment",
+				"subject_id":   float64(654321),
+				"content":      "rocket",
+			},
+			expectedReaction: mockReaction,
+		},
+		{
+			name:         "unknown subject type",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "commit_comment",
+				"subject_id":   float64(1),
+				"content":      "heart",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "unknown subject_type: commit_comment",
+		},
+		{
+			name: "invalid reaction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesReactionsByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": "issue",
+				"subject_id":   float64(42),
+				"content":      "thumbsup",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add reaction",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddReaction(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedReaction github.Reaction
+			err = json.Unmarshal([]byte(textContent.Text), &returnedReaction)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReaction, &returnedReaction)
+		})
+	}
+}
+
+func Test_RemoveReaction(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveReaction(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_reaction", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "reaction_id")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "subject_type", "subject_id", "reaction_id"})
+
+	noContent := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		subjectType  string
+	}{
+		{
+			name: "issue reaction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesReactionsByOwnerByRepoByIssueNumberByReactionId,
+					expectPath(t, "/repos/owner/repo/issues/7/reactions/1001").andThen(noContent),
+				),
+			),
+			subjectType: "issue",
+		},
+		{
+			name: "issue comment reaction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposIssuesCommentsReactionsByOwnerByRepoByCommentIdByReactionId,
+					expectPath(t, "/repos/owner/repo/issues/comments/7/reactions/1001").andThen(noContent),
+				),
+			),
+			subjectType: "issue_comment",
+		},
+		{
+			name: "pull request review comment reaction",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.DeleteReposPullsCommentsReactionsByOwnerByRepoByCommentIdByReactionId,
+					expectPath(t, "/repos/owner/repo/pulls/comments/7/reactions/1001").andThen(noContent),
+				),
+			),
+			subjectType: "pull_request_review_comment",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := RemoveReaction(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"subject_type": tc.subjectType,
+				"subject_id":   float64(7),
+				"reaction_id":  float64(1001),
+			}))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, "reaction 1001 removed", textContent.Text)
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -30,6 +29,9 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedRelease]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -46,9 +48,9 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.ListOptions{
-				Page:    pagination.page,
-				PerPage: pagination.perPage,
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
 			client, err := getClient(ctx)
@@ -56,26 +58,24 @@ func ListReleases(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			releases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+			releases, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+				return client.Repositories.ListReleases(ctx, owner, repo, &opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list releases: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list releases: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to list releases"), nil
 			}
 
-			r, err := json.Marshal(releases)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal releases: %w", err)
+			structured := listResult(cleanReleases(releases), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderReleases(releases)), next), structured), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, releases, cleanReleases), structured, next), nil
 		}
 }
 
@@ -124,6 +124,7 @@ func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Description("Specifies whether this release should be set as the latest release for the repository"),
 				mcp.Enum("true", "false", "legacy"),
 			),
+			WithOutputSchema[CleanedRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -198,19 +199,15 @@ func CreateRelease(getClient GetClientFn, t translations.TranslationHelperFunc)
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create release: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create release"), nil
 			}
 
-			r, err := json.Marshal(release)
+			r, err := json.Marshal(compactOr(ctx, release, cleanRelease))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanRelease(release)), nil
 		}
 }
 
@@ -230,6 +227,9 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				mcp.Required(),
 				mcp.Description("Repository name"),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -241,6 +241,11 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -253,19 +258,24 @@ func GetLatestRelease(getClient GetClientFn, t translations.TranslationHelperFun
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get latest release: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get latest release"), nil
 			}
 
-			r, err := json.Marshal(release)
+			structured := cleanRelease(release)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderRelease(release)), structured), nil
+			}
+
+			projected, err := projectResult(request, compactOr(ctx, release, cleanRelease))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -289,6 +299,9 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				mcp.Required(),
 				mcp.Description("Tag name"),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -304,6 +317,11 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -316,19 +334,24 @@ func GetReleaseByTag(getClient GetClientFn, t translations.TranslationHelperFunc
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get release by tag: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get release by tag"), nil
+			}
+
+			structured := cleanRelease(release)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderRelease(release)), structured), nil
 			}
 
-			r, err := json.Marshal(release)
+			projected, err := projectResult(request, compactOr(ctx, release, cleanRelease))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -352,6 +375,9 @@ func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Required(),
 				mcp.Description("The unique identifier of the release"),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -367,6 +393,11 @@ func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -379,19 +410,24 @@ func GetRelease(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get release: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get release"), nil
+			}
+
+			structured := cleanRelease(release)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderRelease(release)), structured), nil
 			}
 
-			r, err := json.Marshal(release)
+			projected, err := projectResult(request, compactOr(ctx, release, cleanRelease))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -440,6 +476,7 @@ func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Description("Specifies whether this release should be set as the latest release for the repository"),
 				mcp.Enum("true", "false", "legacy"),
 			),
+			WithOutputSchema[CleanedRelease](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -528,19 +565,15 @@ func UpdateRelease(getClient GetClientFn, t translations.TranslationHelperFunc)
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update release: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to update release"), nil
 			}
 
-			r, err := json.Marshal(release)
+			r, err := json.Marshal(compactOr(ctx, release, cleanRelease))
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanRelease(release)), nil
 		}
 }
 
@@ -564,6 +597,7 @@ func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc)
 				mcp.Required(),
 				mcp.Description("The unique identifier of the release"),
 			),
+			WithOutputSchema[ActionResult](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -591,14 +625,10 @@ func DeleteRelease(getClient GetClientFn, t translations.TranslationHelperFunc)
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusNoContent {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to delete release: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to delete release"), nil
 			}
 
-			return mcp.NewToolResultText("Release deleted successfully"), nil
+			return actionResult("Release deleted successfully"), nil
 		}
 }
 
@@ -628,6 +658,7 @@ func GenerateReleaseNotes(getClient GetClientFn, t translations.TranslationHelpe
 			mcp.WithString("previous_tag_name",
 				mcp.Description("The name of the previous tag to use as the starting point for the release notes"),
 			),
+			WithOutputSchema[github.RepositoryReleaseNotes](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -672,11 +703,7 @@ func GenerateReleaseNotes(getClient GetClientFn, t translations.TranslationHelpe
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to generate release notes: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to generate release notes"), nil
 			}
 
 			r, err := json.Marshal(releaseNotes)
@@ -684,6 +711,6 @@ func GenerateReleaseNotes(getClient GetClientFn, t translations.TranslationHelpe
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), releaseNotes), nil
 		}
 }
\ No newline at end of file
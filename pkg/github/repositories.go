@@ -2,10 +2,11 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"path"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -32,7 +33,9 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				mcp.Required(),
 				mcp.Description("Commit SHA, branch name, or tag name"),
 			),
-			WithPagination(),
+			WithPagePagination(),
+			WithFields(),
+			WithOutputSchema[CleanedCommit](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -68,19 +71,19 @@ func GetCommit(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get commit"), nil
 			}
 
-			r, err := json.Marshal(commit)
+			projected, err := projectResult(request, compactOr(ctx, commit, cleanCommit))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanCommit(commit)), nil
 		}
 }
 
@@ -104,6 +107,8 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Description("SHA or Branch name"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*CleanedCommit]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -125,36 +130,26 @@ func ListCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 
 			opts := &github.CommitsListOptions{
 				SHA: sha,
-				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
-				},
 			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			commits, resp, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+			commits, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.Repositories.ListCommits(ctx, owner, repo, opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list commits: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list commits: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(commits)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return responseErrorResult(resp, "failed to list commits"), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, compactOr(ctx, commits, cleanCommits), listResult(cleanCommits(commits), next), next), nil
 		}
 }
 
@@ -175,6 +170,8 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*github.Branch]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -190,45 +187,38 @@ func ListBranches(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.BranchListOptions{
-				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
-				},
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			branches, resp, err := client.Repositories.ListBranches(ctx, owner, repo, opts)
+			branches, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Branch, *github.Response, error) {
+				return client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{ListOptions: opts})
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list branches: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list branches: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(branches)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return responseErrorResult(resp, "failed to list branches"), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, branches, listResult(branches, next), next), nil
 		}
 }
 
+// FileChange is the structured content of create_or_update_file: the commit of the file, along with the outcome
+// of merging the changes when they were made to an older version of the file.
+type FileChange struct {
+	Commit *github.RepositoryContentResponse `json:"commit,omitempty"`
+	Merge  *FileMerge                        `json:"merge,omitempty"`
+}
+
 // CreateOrUpdateFile creates a tool to create or update a file in a GitHub repository.
 func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("create_or_update_file",
-			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update.")),
+			mcp.WithDescription(t("TOOL_CREATE_OR_UPDATE_FILE_DESCRIPTION", "Create or update a single file in a GitHub repository. If updating, you must provide the SHA of the file you want to update, or the SHA it was edited from as baseSha to merge with changes made on the branch since.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_CREATE_OR_UPDATE_FILE_USER_TITLE", "Create or update file"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -260,6 +250,10 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			mcp.WithString("sha",
 				mcp.Description("SHA of file being replaced (for updates)"),
 			),
+			mcp.WithString("baseSha",
+				mcp.Description("SHA of the version of the file the content was edited from, instead of sha. If the file changed on the branch since, both changes are merged line by line and the result committed, unless they conflict"),
+			),
+			WithOutputSchema[FileChange](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -305,12 +299,34 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			if sha != "" {
 				opts.SHA = github.Ptr(sha)
 			}
+			baseSHA, err := OptionalParam[string](request, "baseSha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if sha != "" && baseSHA != "" {
+				return mcp.NewToolResultError("only one of sha and baseSha can be set"), nil
+			}
 
 			// Create or update the file
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
+
+			var merge *FileMerge
+			if baseSHA != "" {
+				var result *mcp.CallToolResult
+				merge, result, err = mergeFileChanges(ctx, client, owner, repo, path, branch, baseSHA, content)
+				if result != nil || err != nil {
+					return result, err
+				}
+				if merge.Status == fileMergeConflict {
+					return structuredResult(MarshalledTextResult(merge), FileChange{Merge: merge}), nil
+				}
+				opts.SHA = github.Ptr(merge.UpstreamSHA)
+				opts.Content = []byte(merge.content)
+			}
+
 			fileContent, resp, err := client.Repositories.CreateFile(ctx, owner, repo, path, opts)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create/update file: %w", err)
@@ -318,11 +334,12 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 && resp.StatusCode != 201 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create/update file: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create/update file"), nil
+			}
+
+			if merge != nil && merge.Status == fileMergeMerged {
+				merge.Result = fileContent
+				return structuredResult(MarshalledTextResult(merge), FileChange{Commit: fileContent, Merge: merge}), nil
 			}
 
 			r, err := json.Marshal(fileContent)
@@ -330,7 +347,7 @@ func CreateOrUpdateFile(getClient GetClientFn, t translations.TranslationHelperF
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), FileChange{Commit: fileContent, Merge: merge}), nil
 		}
 }
 
@@ -355,6 +372,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			mcp.WithBoolean("autoInit",
 				mcp.Description("Initialize with README"),
 			),
+			WithOutputSchema[CleanedRepository](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			name, err := requiredParam[string](request, "name")
@@ -392,11 +410,7 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create repository: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create repository"), nil
 			}
 
 			r, err := json.Marshal(createdRepo)
@@ -404,10 +418,16 @@ func CreateRepository(getClient GetClientFn, t translations.TranslationHelperFun
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanRepository(createdRepo)), nil
 		}
 }
 
+// FileContents is the structured content of get_file_contents: either a file or the entries of a directory.
+type FileContents struct {
+	File    *CleanedRepositoryContent   `json:"file,omitempty"`
+	Entries []*CleanedRepositoryContent `json:"entries,omitempty"`
+}
+
 // GetFileContents creates a tool to get the contents of a file or directory from a GitHub repository.
 func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("get_file_contents",
@@ -431,6 +451,7 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 			mcp.WithString("branch",
 				mcp.Description("Branch to get contents from"),
 			),
+			WithOutputSchema[FileContents](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -462,14 +483,11 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get file contents: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get file contents"), nil
 			}
 
 			var result interface{}
+			var contents FileContents
 			if fileContent != nil {
 				// Clean file content and handle decoding
 				cleanedContent, err := cleanRepositoryContent(fileContent)
@@ -477,6 +495,7 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 					return nil, fmt.Errorf("failed to clean file content: %w", err)
 				}
 				result = cleanedContent
+				contents.File = cleanedContent
 			} else if dirContent != nil {
 				// Clean directory content
 				cleanedContents, err := cleanRepositoryContentSlice(dirContent)
@@ -484,6 +503,7 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 					return nil, fmt.Errorf("failed to clean directory content: %w", err)
 				}
 				result = cleanedContents
+				contents.Entries = cleanedContents
 			}
 
 			r, err := json.Marshal(result)
@@ -491,7 +511,7 @@ func GetFileContents(getClient GetClientFn, t translations.TranslationHelperFunc
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), contents), nil
 		}
 }
 
@@ -514,6 +534,7 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 			mcp.WithString("organization",
 				mcp.Description("Organization to fork to"),
 			),
+			WithOutputSchema[CleanedRepository](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -543,18 +564,14 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				// Check if it's an acceptedError. An acceptedError indicates that the update is in progress,
 				// and it's not a real error.
 				if resp != nil && resp.StatusCode == http.StatusAccepted && isAcceptedError(err) {
-					return mcp.NewToolResultText("Fork is in progress"), nil
+					return structuredResult(mcp.NewToolResultText("Fork is in progress"), cleanRepository(forkedRepo)), nil
 				}
 				return nil, fmt.Errorf("failed to fork repository: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusAccepted {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to fork repository: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to fork repository"), nil
 			}
 
 			r, err := json.Marshal(forkedRepo)
@@ -562,7 +579,7 @@ func ForkRepository(getClient GetClientFn, t translations.TranslationHelperFunc)
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanRepository(forkedRepo)), nil
 		}
 }
 
@@ -600,6 +617,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Required(),
 				mcp.Description("Branch to delete the file from"),
 			),
+			WithOutputSchema[github.RepositoryContentResponse](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -643,11 +661,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get commit: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get commit"), nil
 			}
 
 			// Create a tree entry for the file deletion by setting SHA to nil
@@ -668,11 +682,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create tree: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create tree"), nil
 			}
 
 			// Create a new commit with the new tree
@@ -688,11 +698,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusCreated {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to create commit: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to create commit"), nil
 			}
 
 			// Update the branch reference to point to the new commit
@@ -704,11 +710,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to update reference: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to update reference"), nil
 			}
 
 			// Create a response similar to what the DeleteFile API would return
@@ -722,7 +724,7 @@ func DeleteFile(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), &github.RepositoryContentResponse{Commit: *newCommit}), nil
 		}
 }
 
@@ -749,6 +751,7 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 			mcp.WithString("from_branch",
 				mcp.Description("Source branch (defaults to repo default)"),
 			),
+			WithOutputSchema[github.Reference](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -811,14 +814,90 @@ func CreateBranch(getClient GetClientFn, t translations.TranslationHelperFunc) (
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), createdRef), nil
+		}
+}
+
+// pushFile is a change to a single path of a push_files commit.
+type pushFile struct {
+	Path    string
+	Content *string
+	// Encoding is utf-8 or base64, for binary content.
+	Encoding string
+	// Mode is 100644 for regular files, 100755 for executables and 120000 for symlinks, whose content is their target.
+	Mode   string
+	Delete bool
+	// PreviousPath is set to rename or move a file. Without content, the file keeps its content and mode.
+	PreviousPath string
+}
+
+var pushFileModes = map[string]bool{
+	"100644": true,
+	"100755": true,
+	"120000": true,
+}
+
+// parsePushFile validates a file object of the push_files files parameter.
+func parsePushFile(file interface{}) (pushFile, error) {
+	fileMap, ok := file.(map[string]interface{})
+	if !ok {
+		return pushFile{}, fmt.Errorf("each file must be an object with path and content")
+	}
+
+	var f pushFile
+	f.Path, ok = fileMap["path"].(string)
+	if !ok || f.Path == "" {
+		return pushFile{}, fmt.Errorf("each file must have a path")
+	}
+	if content, ok := fileMap["content"].(string); ok {
+		f.Content = &content
+	}
+	for key, field := range map[string]*string{"encoding": &f.Encoding, "mode": &f.Mode, "previousPath": &f.PreviousPath} {
+		if v, ok := fileMap[key]; ok {
+			if *field, ok = v.(string); !ok {
+				return pushFile{}, fmt.Errorf("%s of %s must be a string", key, f.Path)
+			}
+		}
+	}
+	if v, ok := fileMap["delete"]; ok {
+		if f.Delete, ok = v.(bool); !ok {
+			return pushFile{}, fmt.Errorf("delete of %s must be a boolean", f.Path)
 		}
+	}
+
+	switch {
+	case f.Delete && (f.Content != nil || f.PreviousPath != ""):
+		return pushFile{}, fmt.Errorf("%s cannot be deleted and have content or a previous path", f.Path)
+	case !f.Delete && f.Content == nil && f.PreviousPath == "":
+		return pushFile{}, fmt.Errorf("each file must have content, unless it is deleted or renamed: %s", f.Path)
+	case f.PreviousPath == f.Path:
+		return pushFile{}, fmt.Errorf("previousPath of %s must differ from its path", f.Path)
+	}
+	switch f.Encoding {
+	case "":
+		f.Encoding = "utf-8"
+	case "utf-8":
+	case "base64":
+		if f.Content == nil {
+			return pushFile{}, fmt.Errorf("base64 encoding of %s requires content", f.Path)
+		}
+		if _, err := base64.StdEncoding.DecodeString(*f.Content); err != nil {
+			return pushFile{}, fmt.Errorf("content of %s is not valid base64: %w", f.Path, err)
+		}
+	default:
+		return pushFile{}, fmt.Errorf("encoding of %s must be utf-8 or base64", f.Path)
+	}
+	if f.Mode != "" && !pushFileModes[f.Mode] {
+		return pushFile{}, fmt.Errorf("mode of %s must be 100644, 100755 or 120000", f.Path)
+	}
+
+	return f, nil
 }
 
 // PushFiles creates a tool to push multiple files in a single commit to a GitHub repository.
 func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("push_files",
-			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple files to a GitHub repository in a single commit")),
+			mcp.WithDescription(t("TOOL_PUSH_FILES_DESCRIPTION", "Push multiple file changes to a GitHub repository in a single commit. Files can be added, updated, deleted or renamed, including executables, symlinks and binary files.")),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:        t("TOOL_PUSH_FILES_USER_TITLE", "Push files to repository"),
 				ReadOnlyHint: toBoolPtr(false),
@@ -841,7 +920,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 					map[string]interface{}{
 						"type":                 "object",
 						"additionalProperties": false,
-						"required":             []string{"path", "content"},
+						"required":             []string{"path"},
 						"properties": map[string]interface{}{
 							"path": map[string]interface{}{
 								"type":        "string",
@@ -849,16 +928,41 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 							},
 							"content": map[string]interface{}{
 								"type":        "string",
-								"description": "file content",
+								"description": "file content, or the target of a symlink. Required unless the file is deleted or renamed without changes",
+							},
+							"encoding": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"utf-8", "base64"},
+								"description": "encoding of content, base64 for binary files. Defaults to utf-8",
+							},
+							"mode": map[string]interface{}{
+								"type":        "string",
+								"enum":        []string{"100644", "100755", "120000"},
+								"description": "100644 for a regular file, 100755 for an executable and 120000 for a symlink. Defaults to 100644, or the mode of the previous path of a renamed file",
+							},
+							"delete": map[string]interface{}{
+								"type":        "boolean",
+								"description": "delete the file",
+							},
+							"previousPath": map[string]interface{}{
+								"type":        "string",
+								"description": "path the file is renamed from. Its content is kept when content is not set",
 							},
 						},
 					}),
-				mcp.Description("Array of file objects to push, each object with path (string) and content (string)"),
+				mcp.Description("Array of file changes to push, each object with a path and either content, delete or previousPath"),
 			),
 			mcp.WithString("message",
 				mcp.Required(),
 				mcp.Description("Commit message"),
 			),
+			mcp.WithString("expectedParentSha",
+				mcp.Description("SHA the branch is expected to point to. The push is refused if the branch moved"),
+			),
+			mcp.WithString("baseRef",
+				mcp.Description("Branch, tag or commit SHA to create the branch from when it does not exist yet"),
+			),
+			WithOutputSchema[github.Reference](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -877,58 +981,140 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			expectedParentSHA, err := OptionalParam[string](request, "expectedParentSha")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			baseRef, err := OptionalParam[string](request, "baseRef")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
 			// Parse files parameter - this should be an array of objects with path and content
 			filesObj, ok := request.GetArguments()["files"].([]interface{})
 			if !ok {
 				return mcp.NewToolResultError("files parameter must be an array of objects with path and content"), nil
 			}
+			files := make([]pushFile, 0, len(filesObj))
+			for _, file := range filesObj {
+				f, err := parsePushFile(file)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				files = append(files, f)
+			}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			// Get the reference for the branch
+			// Get the reference for the branch, falling back to the base ref for new branches
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
-			if err != nil {
+			createBranch := err != nil && resp != nil && resp.StatusCode == http.StatusNotFound && baseRef != ""
+			if err != nil && !createBranch {
 				return nil, fmt.Errorf("failed to get branch reference: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			var parentSHA string
+			if createBranch {
+				sha, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, baseRef, "")
+				if err != nil {
+					return nil, fmt.Errorf("failed to resolve base ref: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+				parentSHA = sha
+			} else {
+				parentSHA = ref.GetObject().GetSHA()
+			}
+			if expectedParentSHA != "" && parentSHA != expectedParentSHA {
+				return newToolError(ErrorConflict, fmt.Sprintf("branch %s points to %s, not the expected %s. It was changed since it was last read", branch, parentSHA, expectedParentSHA)).result(), nil
+			}
+
 			// Get the commit object that the branch points to
-			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, *ref.Object.SHA)
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get base commit: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
+			// Files renamed without changes keep the blob and mode of their previous path
+			baseEntries := make(map[string]*github.TreeEntry)
+			listedDirs := make(map[string]bool)
+			for _, f := range files {
+				if f.PreviousPath == "" || f.Content != nil {
+					continue
+				}
+				if err := listTreeDir(ctx, client, owner, repo, baseCommit.GetTree().GetSHA(), path.Dir(f.PreviousPath), baseEntries, listedDirs); err != nil {
+					return nil, err
+				}
+			}
+
 			// Create tree entries for all files
 			var entries []*github.TreeEntry
 
-			for _, file := range filesObj {
-				fileMap, ok := file.(map[string]interface{})
-				if !ok {
-					return mcp.NewToolResultError("each file must be an object with path and content"), nil
+			for _, f := range files {
+				mode := f.Mode
+				if mode == "" {
+					mode = "100644"
 				}
 
-				path, ok := fileMap["path"].(string)
-				if !ok || path == "" {
-					return mcp.NewToolResultError("each file must have a path"), nil
+				if f.Delete || f.PreviousPath != "" {
+					// Entries without content or SHA delete their path
+					deleted := f.Path
+					if f.PreviousPath != "" {
+						deleted = f.PreviousPath
+					}
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(deleted),
+						Mode: github.Ptr(mode),
+						Type: github.Ptr("blob"),
+					})
 				}
 
-				content, ok := fileMap["content"].(string)
-				if !ok {
-					return mcp.NewToolResultError("each file must have content"), nil
+				switch {
+				case f.Delete:
+					continue
+				case f.Content == nil:
+					previous, ok := baseEntries[f.PreviousPath]
+					if !ok || previous.GetType() != "blob" {
+						return mcp.NewToolResultError(fmt.Sprintf("previous path %s of %s is not a file on %s", f.PreviousPath, f.Path, branch)), nil
+					}
+					if f.Mode == "" {
+						mode = previous.GetMode()
+					}
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(f.Path),
+						Mode: github.Ptr(mode),
+						Type: github.Ptr("blob"),
+						SHA:  previous.SHA,
+					})
+				case f.Encoding == "base64":
+					// Binary content can't be inlined in the tree, so it is uploaded as a blob first
+					blob, resp, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+						Content:  f.Content,
+						Encoding: github.Ptr("base64"),
+					})
+					if err != nil {
+						return nil, fmt.Errorf("failed to create blob for %s: %w", f.Path, err)
+					}
+					defer func() { _ = resp.Body.Close() }()
+
+					entries = append(entries, &github.TreeEntry{
+						Path: github.Ptr(f.Path),
+						Mode: github.Ptr(mode),
+						Type: github.Ptr("blob"),
+						SHA:  blob.SHA,
+					})
+				default:
+					entries = append(entries, &github.TreeEntry{
+						Path:    github.Ptr(f.Path),
+						Mode:    github.Ptr(mode),
+						Type:    github.Ptr("blob"),
+						Content: f.Content,
+					})
 				}
-
-				// Create a tree entry for the file
-				entries = append(entries, &github.TreeEntry{
-					Path:    github.Ptr(path),
-					Mode:    github.Ptr("100644"), // Regular file mode
-					Type:    github.Ptr("blob"),
-					Content: github.Ptr(content),
-				})
 			}
 
 			// Create a new tree with the file entries
@@ -950,7 +1136,21 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 			}
 			defer func() { _ = resp.Body.Close() }()
 
-			// Update the reference to point to the new commit
+			if createBranch {
+				createdRef, resp, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+					Ref:    github.Ptr("refs/heads/" + branch),
+					Object: &github.GitObject{SHA: newCommit.SHA},
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to create branch: %w", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return structuredTextResult(createdRef), nil
+			}
+
+			// Update the reference to point to the new commit. As updates must be fast-forwards, this fails
+			// rather than losing commits if the branch moved since it was read.
 			ref.Object.SHA = newCommit.SHA
 			updatedRef, resp, err := client.Git.UpdateRef(ctx, owner, repo, ref, false)
 			if err != nil {
@@ -963,7 +1163,7 @@ func PushFiles(getClient GetClientFn, t translations.TranslationHelperFunc) (too
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), updatedRef), nil
 		}
 }
 
@@ -984,6 +1184,8 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				mcp.Description("Repository name"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputSchema[ListResult[*github.RepositoryTag]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -999,36 +1201,24 @@ func ListTags(getClient GetClientFn, t translations.TranslationHelperFunc) (tool
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.ListOptions{
-				Page:    pagination.page,
-				PerPage: pagination.perPage,
-			}
-
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			tags, resp, err := client.Repositories.ListTags(ctx, owner, repo, opts)
+			tags, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+				return client.Repositories.ListTags(ctx, owner, repo, &opts)
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to list tags: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list tags: %s", string(body))), nil
-			}
-
-			r, err := json.Marshal(tags)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return responseErrorResult(resp, "failed to list tags"), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return projectedListResult(request, tags, listResult(tags, next), next), nil
 		}
 }
 
@@ -1052,6 +1242,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				mcp.Required(),
 				mcp.Description("Tag name"),
 			),
+			WithOutputSchema[github.Tag](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -1080,11 +1271,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag reference: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get tag reference"), nil
 			}
 
 			// Then get the tag object
@@ -1095,11 +1282,7 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get tag object: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get tag object"), nil
 			}
 
 			r, err := json.Marshal(tagObj)
@@ -1107,6 +1290,45 @@ func GetTag(getClient GetClientFn, t translations.TranslationHelperFunc) (tool m
 				return nil, fmt.Errorf("failed to marshal response: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), tagObj), nil
+		}
+}
+
+// listTreeDir adds the entries of directory dir of a tree to entries, by their paths. The directories leading to
+// dir are listed one level at a time, as GitHub truncates recursive listings of the trees of large repositories.
+// Directories missing from the tree have no entries.
+func listTreeDir(ctx context.Context, client *github.Client, owner, repo, treeSHA, dir string, entries map[string]*github.TreeEntry, listed map[string]bool) error {
+	if listed[dir] {
+		return nil
+	}
+	listed[dir] = true
+
+	prefix := ""
+	if dir != "." {
+		if err := listTreeDir(ctx, client, owner, repo, treeSHA, path.Dir(dir), entries, listed); err != nil {
+			return err
+		}
+		entry, ok := entries[dir]
+		if !ok || entry.GetType() != "tree" {
+			return nil
 		}
+		treeSHA, prefix = entry.GetSHA(), dir+"/"
+	}
+
+	name := dir
+	if dir == "." {
+		name = "the repository root"
+	}
+	tree, resp, err := client.Git.GetTree(ctx, owner, repo, treeSHA, false)
+	if err != nil {
+		return fmt.Errorf("failed to get base tree of %s: %w", name, err)
+	}
+	_ = resp.Body.Close()
+	if tree.GetTruncated() {
+		return fmt.Errorf("base tree of %s has too many entries to list", name)
+	}
+	for _, e := range tree.Entries {
+		entries[prefix+e.GetPath()] = e
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -777,6 +778,7 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 	assert.Contains(t, tool.InputSchema.Properties, "message")
 	assert.Contains(t, tool.InputSchema.Properties, "branch")
 	assert.Contains(t, tool.InputSchema.Properties, "sha")
+	assert.Contains(t, tool.InputSchema.Properties, "baseSha")
 	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "path", "content", "message", "branch"})
 
 	// Setup mock file content response
@@ -926,6 +928,166 @@ func Test_CreateOrUpdateFile(t *testing.T) {
 	}
 }
 
+func Test_CreateOrUpdateFile_Merge(t *testing.T) {
+	base := "line 1\nline 2\nline 3\n"
+	blobs := map[string]string{
+		"/repos/owner/repo/git/blobs/base123":     base,
+		"/repos/owner/repo/git/blobs/upstream456": "line 1\nline 2\nline 3 changed upstream\n",
+	}
+	blobHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := blobs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	})
+	upstreamFile := &github.RepositoryContent{
+		Type: github.Ptr("file"),
+		Path: github.Ptr("docs/example.md"),
+		SHA:  github.Ptr("upstream456"),
+	}
+	mockFileResponse := &github.RepositoryContentResponse{
+		Content: &github.RepositoryContent{
+			Path: github.Ptr("docs/example.md"),
+			SHA:  github.Ptr("merged789"),
+		},
+		Commit: github.Commit{
+			SHA: github.Ptr("commit123"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+		expectedMerge   FileMerge
+	}{
+		{
+			name: "merges changes made upstream",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+						mockResponse(t, http.StatusOK, upstreamFile),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					blobHandler,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PutReposContentsByOwnerByRepoByPath,
+					expectRequestBody(t, map[string]interface{}{
+						"message": "Update first line",
+						"content": base64.StdEncoding.EncodeToString([]byte("line 1 changed\nline 2\nline 3 changed upstream\n")),
+						"branch":  "main",
+						"sha":     "upstream456",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockFileResponse),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "line 1 changed\nline 2\nline 3\n",
+				"message": "Update first line",
+				"branch":  "main",
+				"baseSha": "base123",
+			},
+			expectedMerge: FileMerge{
+				Path:        "docs/example.md",
+				Status:      "merged",
+				BaseSHA:     "base123",
+				UpstreamSHA: "upstream456",
+				Result:      mockFileResponse,
+			},
+		},
+		{
+			name: "returns conflicts without committing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposContentsByOwnerByRepoByPath,
+					upstreamFile,
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitBlobsByOwnerByRepoByFileSha,
+					blobHandler,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "line 1\nline 2\nline 3 changed by me\n",
+				"message": "Update last line",
+				"branch":  "main",
+				"baseSha": "base123",
+			},
+			expectedMerge: FileMerge{
+				Path:        "docs/example.md",
+				Status:      "conflict",
+				BaseSHA:     "base123",
+				UpstreamSHA: "upstream456",
+				Conflicts: []MergeConflict{
+					{
+						Line:     3,
+						Base:     []string{"line 3"},
+						Yours:    []string{"line 3 changed by me"},
+						Upstream: []string{"line 3 changed upstream"},
+					},
+				},
+				Message: "docs/example.md was not updated, as it changed on main in ways that conflict with the content. Resolve the conflicts and retry with baseSha upstream456",
+			},
+		},
+		{
+			name:         "sha and baseSha are exclusive",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":   "owner",
+				"repo":    "repo",
+				"path":    "docs/example.md",
+				"content": "line 1\n",
+				"message": "Update file",
+				"branch":  "main",
+				"sha":     "base123",
+				"baseSha": "base123",
+			},
+			expectToolError: true,
+			expectedErrMsg:  "only one of sha and baseSha can be set",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := CreateOrUpdateFile(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+
+			// Verify results
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var merge FileMerge
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &merge))
+			assert.Equal(t, tc.expectedMerge, merge)
+		})
+	}
+}
+
 func Test_CreateRepository(t *testing.T) {
 	// Verify tool definition once
 	mockClient := github.NewClient(nil)
@@ -1364,6 +1526,263 @@ func Test_PushFiles(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to create tree",
 		},
+		{
+			name: "delete, rename and add executables, symlinks and binary files",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				// Get the base trees of the directories leading to the renamed file, one level at a time
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						assert.Empty(t, r.URL.Query().Get("recursive"))
+						switch r.URL.Path {
+						case "/repos/owner/repo/git/trees/def456":
+							mockResponse(t, http.StatusOK, &github.Tree{
+								SHA: github.Ptr("def456"),
+								Entries: []*github.TreeEntry{
+									{Path: github.Ptr("script"), Mode: github.Ptr("040000"), Type: github.Ptr("tree"), SHA: github.Ptr("tree1")},
+								},
+							})(w, r)
+						case "/repos/owner/repo/git/trees/tree1":
+							mockResponse(t, http.StatusOK, &github.Tree{
+								SHA: github.Ptr("tree1"),
+								Entries: []*github.TreeEntry{
+									{Path: github.Ptr("build.sh"), Mode: github.Ptr("100755"), Type: github.Ptr("blob"), SHA: github.Ptr("blob1")},
+								},
+							})(w, r)
+						default:
+							t.Errorf("unexpected request for %s", r.URL.Path)
+							w.WriteHeader(http.StatusNotFound)
+						}
+					}),
+				),
+				// Upload binary content
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitBlobsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Blob{SHA: github.Ptr("blob2")}),
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitTreesByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"base_tree": "def456",
+						"tree": []interface{}{
+							map[string]interface{}{
+								"path": "old.txt",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+							map[string]interface{}{
+								"path": "script/build.sh",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  nil,
+							},
+							map[string]interface{}{
+								"path": "bin/build",
+								"mode": "100755",
+								"type": "blob",
+								"sha":  "blob1",
+							},
+							map[string]interface{}{
+								"path":    "latest",
+								"mode":    "120000",
+								"type":    "blob",
+								"content": "releases/v2",
+							},
+							map[string]interface{}{
+								"path": "logo.png",
+								"mode": "100644",
+								"type": "blob",
+								"sha":  "blob2",
+							},
+						},
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockTree),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PatchReposGitRefsByOwnerByRepoByRef,
+					mockUpdatedRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":   "old.txt",
+						"delete": true,
+					},
+					map[string]interface{}{
+						"path":         "bin/build",
+						"previousPath": "script/build.sh",
+					},
+					map[string]interface{}{
+						"path":    "latest",
+						"content": "releases/v2",
+						"mode":    "120000",
+					},
+					map[string]interface{}{
+						"path":     "logo.png",
+						"content":  "iVBORw0KGgo=",
+						"encoding": "base64",
+					},
+				},
+				"message":           "Reorganize files",
+				"expectedParentSha": "abc123",
+			},
+			expectError: false,
+			expectedRef: mockUpdatedRef,
+		},
+		{
+			name: "fails to look up a renamed file in a truncated tree",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitTreesByOwnerByRepoByTreeSha,
+					&github.Tree{SHA: github.Ptr("def456"), Truncated: github.Ptr(true)},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":         "build.sh",
+						"previousPath": "old.sh",
+					},
+				},
+				"message": "Rename file",
+			},
+			expectError:    true,
+			expectedErrMsg: "base tree of the repository root has too many entries to list",
+		},
+		{
+			name: "creates the branch from a base ref",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposCommitsByOwnerByRepoByRef,
+					expectPath(t, "/repos/owner/repo/commits/main").andThen(
+						mockResponse(t, http.StatusOK, "abc123"),
+					),
+				),
+				mock.WithRequestMatch(
+					mock.GetReposGitCommitsByOwnerByRepoByCommitSha,
+					mockCommit,
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitTreesByOwnerByRepo,
+					mockTree,
+				),
+				mock.WithRequestMatch(
+					mock.PostReposGitCommitsByOwnerByRepo,
+					mockNewCommit,
+				),
+				mock.WithRequestMatchHandler(
+					mock.PostReposGitRefsByOwnerByRepo,
+					expectRequestBody(t, map[string]interface{}{
+						"ref": "refs/heads/feature",
+						"sha": "jkl012",
+					}).andThen(
+						mockResponse(t, http.StatusCreated, &github.Reference{
+							Ref:    github.Ptr("refs/heads/feature"),
+							Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "feature",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message": "Start feature",
+				"baseRef": "main",
+			},
+			expectError: false,
+			expectedRef: &github.Reference{
+				Ref:    github.Ptr("refs/heads/feature"),
+				Object: &github.GitObject{SHA: github.Ptr("jkl012")},
+			},
+		},
+		{
+			name: "fails when the branch moved",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposGitRefByOwnerByRepoByRef,
+					mockRef,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+					},
+				},
+				"message":           "Update file",
+				"expectedParentSha": "stale789",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "branch main points to abc123, not the expected stale789",
+		},
+		{
+			name:         "fails when a file has an invalid mode",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":  "owner",
+				"repo":   "repo",
+				"branch": "main",
+				"files": []interface{}{
+					map[string]interface{}{
+						"path":    "README.md",
+						"content": "# README",
+						"mode":    "040000",
+					},
+				},
+				"message": "Update file",
+			},
+			expectError:    false, // This returns a tool error, not a Go error
+			expectedErrMsg: "mode of README.md must be 100644, 100755 or 120000",
+		},
 	}
 
 	for _, tc := range tests {
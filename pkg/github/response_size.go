@@ -0,0 +1,269 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMaxResponseSize is the default size limit of tool results in bytes, roughly 25,000 tokens.
+const DefaultMaxResponseSize = 100_000
+
+// stringTrimSizes are the lengths long strings in JSON results are trimmed to, in turn, until the result fits.
+var stringTrimSizes = []int{2000, 1000, 500, 250}
+
+// WithMaxResponseSize is a server option limiting the text of tool results to maxBytes. Larger results are
+// truncated and followed by a hint telling the model what was left out and how to fetch it. Structured
+// content is limited to maxBytes separately. A maxBytes of zero or less disables the limit.
+func WithMaxResponseSize(maxBytes int) server.ServerOption {
+	return server.WithToolHandlerMiddleware(func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var cont continuation
+			ctx = context.WithValue(ctx, responseSizeLimitKey{}, maxBytes)
+			result, err := next(context.WithValue(ctx, continuationKey{}, &cont), request)
+			if err != nil || result == nil || maxBytes <= 0 {
+				return result, err
+			}
+			limitResultSize(result, cont.cursorAfter, maxBytes)
+			limitStructuredContentSize(result, request, cont.cursorAfter, maxBytes)
+			return result, nil
+		}
+	})
+}
+
+type responseSizeLimitKey struct{}
+
+// responseSizeLimit returns the size limit of tool results in bytes, or zero when there is none.
+func responseSizeLimit(ctx context.Context) int {
+	maxBytes, _ := ctx.Value(responseSizeLimitKey{}).(int)
+	return max(maxBytes, 0)
+}
+
+// limitResultSize truncates the text contents of result so that together they fit in maxBytes, appending a
+// hint for each truncated content. When items of a list are left out, the hint replaces the note with the
+// cursor to the items following the list. The note itself is short and is never truncated. cursorAfter
+// locates the items following the kept ones, and is nil for tools that don't accept a cursor.
+func limitResultSize(result *mcp.CallToolResult, cursorAfter func(n int) string, maxBytes int) {
+	remaining := maxBytes
+	var hints []mcp.Content
+	itemsLeftOut := false
+	for i, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		if !ok || strings.HasPrefix(text.Text, moreItemsNote) {
+			continue
+		}
+		if len(text.Text) > remaining {
+			var hint string
+			var leftOut bool
+			text.Text, hint, leftOut = truncateText(text.Text, remaining, cursorAfter)
+			hint = fmt.Sprintf("The response was truncated to fit the %d byte response size limit. %s", maxBytes, hint)
+			result.Content[i] = text
+			hints = append(hints, mcp.NewTextContent(hint))
+			itemsLeftOut = itemsLeftOut || leftOut
+		}
+		remaining = max(remaining-len(text.Text), 0)
+	}
+
+	if itemsLeftOut {
+		contents := result.Content[:0]
+		for _, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); !ok || !strings.HasPrefix(text.Text, moreItemsNote) {
+				contents = append(contents, content)
+			}
+		}
+		result.Content = contents
+	}
+	result.Content = append(result.Content, hints...)
+}
+
+// limitStructuredContentSize truncates the structured content of result to maxBytes the way limitResultSize
+// truncates JSON text, keeping as many whole items of its main list as fit. When items are left out of a
+// list, its next_cursor is replaced with the cursor to the items following the kept ones. As the structured
+// content of a tool must match its output schema, results whose structured content can't be made small
+// enough that way are replaced with an error.
+func limitStructuredContentSize(result *mcp.CallToolResult, request mcp.CallToolRequest, cursorAfter func(n int) string, maxBytes int) {
+	if result.StructuredContent == nil {
+		return
+	}
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil || len(data) <= maxBytes {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return
+	}
+	truncated, _, _, ok := truncateJSON(v, maxBytes, cursorAfter)
+	if !ok {
+		*result = *newToolError(ErrorValidationFailed, fmt.Sprintf("the result of %s exceeds the %d byte response size limit. Narrow the request, or fetch fewer items or fields", request.Params.Name, maxBytes)).result()
+		return
+	}
+	result.StructuredContent = json.RawMessage(truncated)
+}
+
+// truncateText truncates text to at most maxBytes, returning the truncated text, a hint describing what
+// was left out and whether list items were left out. JSON keeps its structure by dropping list items and
+// trimming long strings. Anything else, or JSON that can't be made small enough that way, is cut at a line
+// boundary.
+func truncateText(text string, maxBytes int, cursorAfter func(n int) string) (string, string, bool) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		if truncated, hint, itemsLeftOut, ok := truncateJSON(v, maxBytes, cursorAfter); ok {
+			return truncated, hint, itemsLeftOut
+		}
+	}
+	truncated, hint := truncateLines(text, maxBytes)
+	return truncated, hint, false
+}
+
+// truncateJSON trims long strings in v and keeps as many whole items of its main list as fit in maxBytes.
+// The next_cursor of an object whose list is cut is replaced with the cursor following the kept items.
+func truncateJSON(v any, maxBytes int, cursorAfter func(n int) string) (string, string, bool, bool) {
+	for _, limit := range stringTrimSizes {
+		trimmed, trimmedCount := trimStrings(v, limit)
+		var notes []string
+		if trimmedCount > 0 {
+			notes = append(notes, fmt.Sprintf("Strings longer than %d bytes were trimmed.", limit))
+		}
+
+		data, err := json.Marshal(trimmed)
+		if err != nil {
+			return "", "", false, false
+		}
+		if len(data) <= maxBytes {
+			return string(data), strings.Join(notes, " "), false, true
+		}
+
+		items, set := mainList(trimmed)
+		if len(items) == 0 {
+			continue
+		}
+		keep := func(n int) any {
+			truncated := set(items[:n])
+			if m, ok := truncated.(map[string]any); ok {
+				// The cursor and page following the whole list no longer follow the kept items.
+				delete(m, "next_cursor")
+				delete(m, "next_page")
+				if cursorAfter != nil {
+					m["next_cursor"] = cursorAfter(n)
+				}
+			}
+			return truncated
+		}
+		// Find how many whole items fit.
+		kept := sort.Search(len(items), func(n int) bool {
+			data, err := json.Marshal(keep(n + 1))
+			return err != nil || len(data) > maxBytes
+		})
+		if kept == 0 {
+			continue
+		}
+		data, err = json.Marshal(keep(kept))
+		if err != nil {
+			return "", "", false, false
+		}
+		notes = append(notes, fmt.Sprintf("Only the first %d of %d items were returned.", kept, len(items)), continuationHint(cursorAfter, kept))
+		return string(data), strings.Join(notes, " "), true, true
+	}
+	return "", "", false, false
+}
+
+// mainList returns the list that makes up most of a JSON result, either the result itself or its longest
+// list field, with a function returning the result with the list replaced by the given items.
+func mainList(v any) ([]any, func([]any) any) {
+	switch v := v.(type) {
+	case []any:
+		return v, func(items []any) any { return items }
+	case map[string]any:
+		var key string
+		var longest []any
+		for k, field := range v {
+			items, ok := field.([]any)
+			if ok && (len(items) > len(longest) || (len(items) == len(longest) && k < key)) {
+				key, longest = k, items
+			}
+		}
+		if longest == nil {
+			return nil, nil
+		}
+		return longest, func(items []any) any {
+			v[key] = items
+			return v
+		}
+	default:
+		return nil, nil
+	}
+}
+
+// trimStrings returns a copy of v with strings longer than limit bytes trimmed, and how many were trimmed.
+func trimStrings(v any, limit int) (any, int) {
+	switch v := v.(type) {
+	case string:
+		if len(v) <= limit {
+			return v, 0
+		}
+		end := limit
+		for end > 0 && !utf8.RuneStart(v[end]) {
+			end--
+		}
+		return fmt.Sprintf("%s... (%d more bytes)", v[:end], len(v)-end), 1
+	case []any:
+		out := make([]any, len(v))
+		count := 0
+		for i, item := range v {
+			var n int
+			out[i], n = trimStrings(item, limit)
+			count += n
+		}
+		return out, count
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		count := 0
+		for k, field := range v {
+			var n int
+			out[k], n = trimStrings(field, limit)
+			count += n
+		}
+		return out, count
+	default:
+		return v, 0
+	}
+}
+
+// continuationHint tells the model how to fetch the items following the first kept items of the result.
+func continuationHint(cursorAfter func(n int) string, kept int) string {
+	if cursorAfter == nil {
+		return "Narrow the request, or fetch fewer items or fields, to see the rest."
+	}
+	return fmt.Sprintf("Pass cursor %q to fetch the following items.", cursorAfter(kept))
+}
+
+// truncateLines cuts text to at most maxBytes, at the end of a line when there is one.
+func truncateLines(text string, maxBytes int) (string, string) {
+	end := maxBytes
+	for end > 0 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	if i := strings.LastIndexByte(text[:end], '\n'); i >= 0 {
+		end = i + 1
+	}
+
+	totalLines := strings.Count(text, "\n")
+	if !strings.HasSuffix(text, "\n") {
+		totalLines++
+	}
+	return text[:end], fmt.Sprintf("Only the first %d of %d lines (%d of %d bytes) were returned. Narrow the request, for example to a single file, path or line range, to see the rest.",
+		strings.Count(text[:end], "\n"), totalLines, end, len(text))
+}
@@ -0,0 +1,286 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WithMaxResponseSize(t *testing.T) {
+	s := NewServer("test", WithMaxResponseSize(1000))
+	s.AddTool(mcp.NewTool("test_tool"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(strings.Repeat("line\n", 1000)), nil
+	})
+
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"test_tool"}}`))
+	require.IsType(t, mcp.JSONRPCResponse{}, response)
+	result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+	require.True(t, ok)
+	require.Len(t, result.Content, 2)
+	assert.Equal(t, strings.Repeat("line\n", 200), result.Content[0].(mcp.TextContent).Text)
+	assert.Equal(t, "The response was truncated to fit the 1000 byte response size limit. Only the first 200 of 1000 lines (1000 of 5000 bytes) were returned. Narrow the request, for example to a single file, path or line range, to see the rest.", result.Content[1].(mcp.TextContent).Text)
+}
+
+func Test_WithMaxResponseSize_Continuation(t *testing.T) {
+	items := make([]map[string]any, 50)
+	for i := range items {
+		items[i] = map[string]any{"number": i + 1, "title": fmt.Sprintf("Item %02d", i+1)}
+	}
+
+	s := NewServer("test", WithMaxResponseSize(500))
+	s.AddTool(mcp.NewTool("list_tool", WithPagination()), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		pagination, err := OptionalPaginationParams(request)
+		require.NoError(t, err)
+		collected, _, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]map[string]any, *github.Response, error) {
+			resp := &github.Response{Response: &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}}
+			return items[(opts.Page-1)*opts.PerPage : opts.Page*opts.PerPage], resp, nil
+		})
+		require.NoError(t, err)
+		return withNextCursor(MarshalledTextResult(collected), next), nil
+	})
+	s.AddTool(mcp.NewTool("get_tool"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return MarshalledTextResult(items), nil
+	})
+
+	call := func(name string) []mcp.Content {
+		response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"`+name+`","arguments":{"page":2,"perPage":25}}}`))
+		require.IsType(t, mcp.JSONRPCResponse{}, response)
+		result, ok := response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult)
+		require.True(t, ok)
+		require.Len(t, result.Content, 2)
+		return result.Content
+	}
+
+	// Only tools accepting a cursor are told one, which continues after the kept items of the page.
+	contents := call("list_tool")
+	var kept []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(contents[0].(mcp.TextContent).Text), &kept))
+	require.NotEmpty(t, kept)
+	assert.Equal(t, float64(26), kept[0]["number"])
+	assert.Contains(t, contents[1].(mcp.TextContent).Text, fmt.Sprintf("Pass cursor %q", pageCursor{Page: 2, PerPage: 25, Skip: len(kept)}.String()))
+
+	contents = call("get_tool")
+	assert.NotContains(t, contents[1].(mcp.TextContent).Text, "cursor")
+	assert.Contains(t, contents[1].(mcp.TextContent).Text, "Narrow the request")
+}
+
+// spansCursorAfter returns the continuation of items collected over spans.
+func spansCursorAfter(spans ...pageSpan) func(n int) string {
+	var cont continuation
+	setContinuation(context.WithValue(context.Background(), continuationKey{}, &cont), spans, "")
+	return cont.cursorAfter
+}
+
+func Test_LimitResultSize(t *testing.T) {
+	items := make([]map[string]any, 50)
+	for i := range items {
+		items[i] = map[string]any{"number": i + 1, "title": fmt.Sprintf("Item %02d", i+1)}
+	}
+	itemSize := len(`{"number":10,"title":"Item 10"},`)
+	firstPage := spansCursorAfter(pageSpan{cursor: pageCursor{Page: 1, PerPage: 50}, count: 50})
+
+	tests := []struct {
+		name             string
+		result           *mcp.CallToolResult
+		cursorAfter      func(n int) string
+		maxBytes         int
+		expectedText     func(t *testing.T, text string)
+		expectedHint     string
+		expectedContents int
+	}{
+		{
+			name:             "result within limit",
+			result:           MarshalledTextResult(items[:2]),
+			maxBytes:         1000,
+			expectedContents: 1,
+			expectedText: func(t *testing.T, text string) {
+				assert.JSONEq(t, `[{"number":1,"title":"Item 01"},{"number":2,"title":"Item 02"}]`, text)
+			},
+		},
+		{
+			name:             "list keeps whole items",
+			result:           MarshalledTextResult(items),
+			cursorAfter:      firstPage,
+			maxBytes:         10 * itemSize,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				var kept []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(text), &kept))
+				require.Len(t, kept, 10)
+				assert.Equal(t, "Item 10", kept[9]["title"])
+			},
+			expectedHint: `Only the first 10 of 50 items were returned. Pass cursor "` + pageCursor{Page: 1, PerPage: 50, Skip: 10}.String() + `" to fetch the following items.`,
+		},
+		{
+			name:             "cursor note of a cut list is replaced",
+			result:           withNextCursor(MarshalledTextResult(items), pageCursor{Page: 2, PerPage: 50}.String()),
+			cursorAfter:      firstPage,
+			maxBytes:         10 * itemSize,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				var kept []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(text), &kept))
+				require.Len(t, kept, 10)
+			},
+			expectedHint: `Pass cursor "` + pageCursor{Page: 1, PerPage: 50, Skip: 10}.String() + `" to fetch the following items.`,
+		},
+		{
+			name:   "continuation across pages",
+			result: MarshalledTextResult(items[12:24]),
+			// Items 13 to 20 were collected from the end of page 2, and items 21 to 24 from page 3.
+			cursorAfter: spansCursorAfter(
+				pageSpan{cursor: pageCursor{Page: 2, PerPage: 10, Skip: 2}, count: 8},
+				pageSpan{cursor: pageCursor{Page: 3, PerPage: 10}, count: 4},
+			),
+			maxBytes:         10 * itemSize,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				var kept []map[string]any
+				require.NoError(t, json.Unmarshal([]byte(text), &kept))
+				assert.Len(t, kept, 9)
+			},
+			// Items 13 to 21 were kept, so the following items start at the second item of page 3.
+			expectedHint: `Only the first 9 of 12 items were returned. Pass cursor "` + pageCursor{Page: 3, PerPage: 10, Skip: 1}.String() + `" to fetch the following items.`,
+		},
+		{
+			name: "continuation within a GraphQL connection",
+			result: MarshalledTextResult(map[string]any{
+				"total_count": 80,
+				"threads":     items,
+				"next_cursor": pageCursor{PerPage: 50, After: "Y3Vyc29yOjUw"}.String(),
+			}),
+			cursorAfter:      spansCursorAfter(pageSpan{cursor: pageCursor{PerPage: 50, After: "Y3Vyc29yOjA="}, count: 50}),
+			maxBytes:         10 * itemSize,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				var kept struct {
+					Threads    []map[string]any `json:"threads"`
+					NextCursor string           `json:"next_cursor"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(text), &kept))
+				require.NotEmpty(t, kept.Threads)
+				assert.Equal(t, pageCursor{PerPage: 50, Skip: len(kept.Threads), After: "Y3Vyc29yOjA="}.String(), kept.NextCursor)
+			},
+			expectedHint: "Pass cursor",
+		},
+		{
+			name: "list field of an object",
+			result: MarshalledTextResult(map[string]any{
+				"total_count": 50,
+				"items":       items,
+			}),
+			maxBytes:         10 * itemSize,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				var kept map[string]any
+				require.NoError(t, json.Unmarshal([]byte(text), &kept))
+				assert.Equal(t, float64(50), kept["total_count"])
+				assert.Len(t, kept["items"], 9)
+				assert.NotContains(t, kept, "next_cursor")
+			},
+			// Without a cursor, as the tool doesn't accept one.
+			expectedHint: `Only the first 9 of 50 items were returned. Narrow the request, or fetch fewer items or fields, to see the rest.`,
+		},
+		{
+			name: "long strings are trimmed",
+			result: MarshalledTextResult(map[string]any{
+				"title": "Big diff",
+				"patch": strings.Repeat("+", 5000),
+			}),
+			maxBytes:         3000,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				var kept map[string]string
+				require.NoError(t, json.Unmarshal([]byte(text), &kept))
+				assert.Equal(t, "Big diff", kept["title"])
+				assert.Equal(t, strings.Repeat("+", 2000)+"... (3000 more bytes)", kept["patch"])
+			},
+			expectedHint: "Strings longer than 2000 bytes were trimmed.",
+		},
+		{
+			name:             "text is cut at a line boundary",
+			result:           mcp.NewToolResultText("first line\nsecond line\nthird line"),
+			maxBytes:         20,
+			expectedContents: 2,
+			expectedText: func(t *testing.T, text string) {
+				assert.Equal(t, "first line\n", text)
+			},
+			expectedHint: "Only the first 1 of 3 lines (11 of 33 bytes) were returned.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limitResultSize(tc.result, tc.cursorAfter, tc.maxBytes)
+
+			require.Len(t, tc.result.Content, tc.expectedContents)
+			text, ok := tc.result.Content[0].(mcp.TextContent)
+			require.True(t, ok)
+			assert.LessOrEqual(t, len(text.Text), tc.maxBytes)
+			tc.expectedText(t, text.Text)
+			if tc.expectedHint != "" {
+				hint, ok := tc.result.Content[1].(mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, hint.Text, fmt.Sprintf("The response was truncated to fit the %d byte response size limit.", tc.maxBytes))
+				assert.Contains(t, hint.Text, tc.expectedHint)
+			}
+		})
+	}
+}
+
+func Test_LimitStructuredContentSize(t *testing.T) {
+	items := make([]map[string]any, 50)
+	for i := range items {
+		items[i] = map[string]any{"number": i + 1, "title": fmt.Sprintf("Item %02d", i+1)}
+	}
+	itemSize := len(`{"number":10,"title":"Item 10"},`)
+
+	t.Run("within limit", func(t *testing.T) {
+		result := structuredResult(mcp.NewToolResultText(""), listResult(items[:2], ""))
+		limitStructuredContentSize(result, createMCPRequest(nil), nil, 1000)
+		assert.Equal(t, listResult(items[:2], ""), result.StructuredContent)
+	})
+
+	t.Run("list keeps whole items and continues after them", func(t *testing.T) {
+		result := structuredResult(mcp.NewToolResultText(""), listResult(items, pageCursor{Page: 2, PerPage: 50}.String()))
+		cursorAfter := spansCursorAfter(pageSpan{cursor: pageCursor{Page: 1, PerPage: 50}, count: 50})
+		limitStructuredContentSize(result, createMCPRequest(nil), cursorAfter, 10*itemSize)
+
+		data, err := json.Marshal(result.StructuredContent)
+		require.NoError(t, err)
+		var kept ListResult[map[string]any]
+		require.NoError(t, json.Unmarshal(data, &kept))
+		// The cursor of the kept items takes the room of a ninth item.
+		assert.Len(t, kept.Items, 8)
+		assert.Equal(t, pageCursor{Page: 1, PerPage: 50, Skip: 8}.String(), kept.NextCursor)
+	})
+
+	t.Run("long strings are trimmed", func(t *testing.T) {
+		result := structuredResult(mcp.NewToolResultText(""), ActionResult{Message: strings.Repeat("+", 5000)})
+		limitStructuredContentSize(result, createMCPRequest(nil), nil, 3000)
+
+		data, err := json.Marshal(result.StructuredContent)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"message":"`+strings.Repeat("+", 2000)+`... (3000 more bytes)"}`, string(data))
+	})
+
+	t.Run("content that can't be truncated is an error", func(t *testing.T) {
+		result := structuredResult(mcp.NewToolResultText(""), ActionResult{Message: strings.Repeat("+", 5000)})
+		request := createMCPRequest(nil)
+		request.Params.Name = "test_tool"
+		limitStructuredContentSize(result, request, nil, 100)
+
+		require.True(t, result.IsError)
+		assert.Nil(t, result.StructuredContent)
+		assert.Contains(t, getTextResult(t, result).Text, "the result of test_tool exceeds the 100 byte response size limit")
+	})
+}
@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// findCommentableHunk returns the hunk of the diff that contains lines start through end of path on the head
+// side, or an error explaining why those lines cannot be commented on.
+func findCommentableHunk(files []diffFile, path string, start, end int) (*diffHunk, error) {
+	var file *diffFile
+	for i := range files {
+		if files[i].NewPath == path || (files[i].NewPath == "" && files[i].OldPath == path) {
+			file = &files[i]
+			break
+		}
+	}
+
+	switch {
+	case file == nil:
+		return nil, fmt.Errorf("%s is not changed by the pull request", path)
+	case file.NewPath == "":
+		return nil, fmt.Errorf("%s is deleted by the pull request, so there are no lines to suggest changes to", path)
+	case file.Binary:
+		return nil, fmt.Errorf("%s is a binary file", path)
+	case len(file.Hunks) == 0:
+		return nil, fmt.Errorf("%s has no changed lines in the pull request, only a rename or mode change", path)
+	}
+
+	ranges := make([]string, 0, len(file.Hunks))
+	for i, h := range file.Hunks {
+		if h.containsNewRange(start, end) {
+			return &file.Hunks[i], nil
+		}
+		if h.NewLines > 0 {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", h.NewStart, h.newEnd()))
+		}
+	}
+
+	return nil, fmt.Errorf("lines %d-%d of %s are not within a single hunk of the pull request diff, so they cannot be commented on. The commentable line ranges are: %s",
+		start, end, path, strings.Join(ranges, ", "))
+}
+
+// formatSuggestion returns a review comment body with the replacement text in a suggestion block, using a fence
+// longer than any run of backticks in the replacement so code blocks within it are preserved.
+func formatSuggestion(body, replacement string) string {
+	replacement = strings.TrimSuffix(replacement, "\n")
+
+	longest, run := 0, 0
+	for _, r := range replacement {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+
+	var b strings.Builder
+	if body != "" {
+		b.WriteString(body)
+		b.WriteString("\n\n")
+	}
+	b.WriteString(fence)
+	b.WriteString("suggestion\n")
+	if replacement != "" {
+		b.WriteString(replacement)
+		b.WriteString("\n")
+	}
+	b.WriteString(fence)
+	return b.String()
+}
+
+// AddSuggestionToPendingReview creates a tool to suggest a change to a range of lines in a pull request.
+func AddSuggestionToPendingReview(getClient GetClientFn, getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("add_suggestion_to_pending_review",
+			mcp.WithDescription(t("TOOL_ADD_SUGGESTION_TO_PENDING_REVIEW_DESCRIPTION", "Suggest replacing a range of lines of a file with new text, as a comment on the requester's latest pending pull request review. The lines are numbered as in the head version of the file and must all be part of a single hunk of the pull request diff. A pending review needs to already exist to call this (check with the user if not sure).")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SUGGESTION_TO_PENDING_REVIEW_USER_TITLE", "Add suggested change to the requester's latest pending pull request review"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithString("path",
+				mcp.Required(),
+				mcp.Description("The relative path to the file to suggest a change to"),
+			),
+			mcp.WithNumber("startLine",
+				mcp.Description("The first line to replace, in the head version of the file. Defaults to endLine, to replace a single line"),
+			),
+			mcp.WithNumber("endLine",
+				mcp.Required(),
+				mcp.Description("The last line to replace, in the head version of the file"),
+			),
+			mcp.WithString("suggestion",
+				mcp.Required(),
+				mcp.Description("The text to replace the lines with, without any ```suggestion fence. An empty string suggests deleting the lines"),
+			),
+			mcp.WithString("body",
+				mcp.Description("Text explaining the suggestion, shown above it"),
+			),
+			WithOutputSchema[ActionResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner      string
+				Repo       string
+				PullNumber int32
+				Path       string
+				StartLine  int32
+				EndLine    int32
+				Suggestion *string
+				Body       string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.Suggestion == nil {
+				return mcp.NewToolResultError("missing required parameter: suggestion"), nil
+			}
+			if params.EndLine < 1 {
+				return mcp.NewToolResultError("endLine must be a positive line number"), nil
+			}
+			if params.StartLine == 0 {
+				params.StartLine = params.EndLine
+			}
+			if params.StartLine < 1 || params.StartLine > params.EndLine {
+				return mcp.NewToolResultError("startLine must be a positive line number no greater than endLine"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			raw, resp, err := client.PullRequests.GetRaw(ctx, params.Owner, params.Repo, int(params.PullNumber), github.RawOptions{Type: github.Diff})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pull request diff: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to get pull request diff"), nil
+			}
+
+			files, err := parseUnifiedDiff(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pull request diff: %w", err)
+			}
+			if _, err := findCommentableHunk(files, params.Path, int(params.StartLine), int(params.EndLine)); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			gqlClient, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			reviewID, err := getViewerPendingReviewID(ctx, gqlClient, params.Owner, params.Repo, params.PullNumber)
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			input := githubv4.AddPullRequestReviewThreadInput{
+				Path:                githubv4.String(params.Path),
+				Body:                githubv4.String(formatSuggestion(params.Body, *params.Suggestion)),
+				SubjectType:         github.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
+				Line:                githubv4.NewInt(githubv4.Int(params.EndLine)),
+				Side:                github.Ptr(githubv4.DiffSideRight),
+				PullRequestReviewID: &reviewID,
+			}
+			if params.StartLine != params.EndLine {
+				input.StartLine = githubv4.NewInt(githubv4.Int(params.StartLine))
+				input.StartSide = github.Ptr(githubv4.DiffSideRight)
+			}
+
+			var addPullRequestReviewThreadMutation struct {
+				AddPullRequestReviewThread struct {
+					Thread struct {
+						ID githubv4.ID // We don't need this, but a selector is required or GQL complains.
+					}
+				} `graphql:"addPullRequestReviewThread(input: $input)"`
+			}
+
+			if err := gqlClient.Mutate(ctx, &addPullRequestReviewThreadMutation, input, nil); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return actionResult("suggested change successfully added to pending review"), nil
+		}
+}
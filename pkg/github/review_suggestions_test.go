@@ -0,0 +1,333 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FormatSuggestion(t *testing.T) {
+	assert.Equal(t, "```suggestion\nreturn nil\n```", formatSuggestion("", "return nil\n"))
+	assert.Equal(t, "Handle the error\n\n```suggestion\nif err != nil {\n\treturn err\n}\n```",
+		formatSuggestion("Handle the error", "if err != nil {\n\treturn err\n}"))
+	assert.Equal(t, "```suggestion\n```", formatSuggestion("", ""))
+	assert.Equal(t, "````suggestion\n```go\nfmt.Println()\n```\n````", formatSuggestion("", "```go\nfmt.Println()\n```"))
+}
+
+func Test_AddSuggestionToPendingReview(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	tool, _ := AddSuggestionToPendingReview(stubGetClientFn(github.NewClient(nil)), stubGetGQLClientFn(githubv4.NewClient(nil)), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_suggestion_to_pending_review", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "path")
+	assert.Contains(t, tool.InputSchema.Properties, "startLine")
+	assert.Contains(t, tool.InputSchema.Properties, "endLine")
+	assert.Contains(t, tool.InputSchema.Properties, "suggestion")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber", "path", "endLine", "suggestion"})
+
+	stubbedDiff := `diff --git a/server.go b/server.go
+index 1111111..2222222 100644
+--- a/server.go
++++ b/server.go
+@@ -10,5 +10,7 @@ func run() error {
+ 	client, err := newClient()
+-	_ = err
++	if err != nil {
++		return err
++	}
+ 	defer client.Close()
+ 	return client.Serve()
+ }
+diff --git a/removed.go b/removed.go
+deleted file mode 100644
+index 3333333..0000000
+--- a/removed.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package main
+`
+
+	diffClient := func() *http.Client {
+		return mock.NewMockedHTTPClient(
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsByOwnerByRepoByPullNumber,
+				expectPath(t, "/repos/owner/repo/pulls/42").andThen(
+					mockResponse(t, http.StatusOK, stubbedDiff),
+				),
+			),
+		)
+	}
+
+	pendingReview := getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+		author: "williammartin",
+		owner:  "owner",
+		repo:   "repo",
+		prNum:  42,
+
+		reviews: []getLatestPendingReviewQueryReview{
+			{
+				id:    "PR_kwDODKw3uc6WYN1T",
+				state: "PENDING",
+				url:   "https://github.com/owner/repo/pull/42",
+			},
+		},
+	})
+
+	addThreadMutation := struct {
+		AddPullRequestReviewThread struct {
+			Thread struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addPullRequestReviewThread(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name               string
+		restClient         *http.Client
+		gqlClient          *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+	}{
+		{
+			name:       "multi-line suggestion",
+			restClient: diffClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				pendingReview,
+				githubv4mock.NewMutationMatcher(
+					addThreadMutation,
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String("server.go"),
+						Body:                githubv4.String("Wrap the error for context\n\n```suggestion\n\tif err != nil {\n\t\treturn fmt.Errorf(\"creating client: %w\", err)\n\t}\n```"),
+						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
+						Line:                githubv4.NewInt(13),
+						Side:                githubv4mock.Ptr(githubv4.DiffSideRight),
+						StartLine:           githubv4.NewInt(11),
+						StartSide:           githubv4mock.Ptr(githubv4.DiffSideRight),
+						PullRequestReviewID: githubv4.NewID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"startLine":  float64(11),
+				"endLine":    float64(13),
+				"suggestion": "\tif err != nil {\n\t\treturn fmt.Errorf(\"creating client: %w\", err)\n\t}\n",
+				"body":       "Wrap the error for context",
+			},
+		},
+		{
+			name:       "single line suggestion on a context line",
+			restClient: diffClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				pendingReview,
+				githubv4mock.NewMutationMatcher(
+					addThreadMutation,
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String("server.go"),
+						Body:                githubv4.String("```suggestion\n```"),
+						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
+						Line:                githubv4.NewInt(14),
+						Side:                githubv4mock.Ptr(githubv4.DiffSideRight),
+						PullRequestReviewID: githubv4.NewID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{}),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"endLine":    float64(14),
+				"suggestion": "",
+			},
+		},
+		{
+			name:       "mutation not permitted",
+			restClient: diffClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				pendingReview,
+				githubv4mock.NewMutationMatcher(
+					addThreadMutation,
+					githubv4.AddPullRequestReviewThreadInput{
+						Path:                githubv4.String("server.go"),
+						Body:                githubv4.String("```suggestion\n```"),
+						SubjectType:         githubv4mock.Ptr(githubv4.PullRequestReviewThreadSubjectTypeLine),
+						Line:                githubv4.NewInt(14),
+						Side:                githubv4mock.Ptr(githubv4.DiffSideRight),
+						PullRequestReviewID: githubv4.NewID("PR_kwDODKw3uc6WYN1T"),
+					},
+					nil,
+					githubv4mock.ErrorResponse("Resource not accessible by integration"),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"endLine":    float64(14),
+				"suggestion": "",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "Error code: permission_denied.",
+		},
+		{
+			name:       "range outside of the diff",
+			restClient: diffClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"startLine":  float64(16),
+				"endLine":    float64(20),
+				"suggestion": "}",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "lines 16-20 of server.go are not within a single hunk of the pull request diff, so they cannot be commented on. The commentable line ranges are: 10-16",
+		},
+		{
+			name:       "file not in the diff",
+			restClient: diffClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "client.go",
+				"endLine":    float64(1),
+				"suggestion": "package client",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "client.go is not changed by the pull request",
+		},
+		{
+			name:       "deleted file",
+			restClient: diffClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "removed.go",
+				"endLine":    float64(1),
+				"suggestion": "package app",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "removed.go is deleted by the pull request",
+		},
+		{
+			name:       "start after end",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"startLine":  float64(13),
+				"endLine":    float64(11),
+				"suggestion": "",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "startLine must be a positive line number no greater than endLine",
+		},
+		{
+			name:       "missing suggestion",
+			restClient: mock.NewMockedHTTPClient(),
+			gqlClient:  githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"endLine":    float64(11),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: suggestion",
+		},
+		{
+			name:       "no pending review",
+			restClient: diffClient(),
+			gqlClient: githubv4mock.NewMockedHTTPClient(
+				viewerQuery("williammartin"),
+				getLatestPendingReviewQuery(getLatestPendingReviewQueryParams{
+					author: "williammartin",
+					owner:  "owner",
+					repo:   "repo",
+					prNum:  42,
+
+					reviews: []getLatestPendingReviewQueryReview{
+						{
+							id:    "PR_kwDODKw3uc6WYN1T",
+							state: "COMMENTED",
+							url:   "https://github.com/owner/repo/pull/42#pullrequestreview-1",
+						},
+					},
+				}),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"path":       "server.go",
+				"endLine":    float64(12),
+				"suggestion": "\tif err != nil {",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "The latest review, found at https://github.com/owner/repo/pull/42#pullrequestreview-1 is not pending",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup clients with mocks
+			restClient := github.NewClient(tc.restClient)
+			gqlClient := githubv4.NewClient(tc.gqlClient)
+			_, handler := AddSuggestionToPendingReview(stubGetClientFn(restClient), stubGetGQLClientFn(gqlClient), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			require.Equal(t, "suggested change successfully added to pending review", textContent.Text)
+		})
+	}
+}
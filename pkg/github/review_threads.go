@@ -0,0 +1,383 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+)
+
+// ReviewThreadComment is a comment in a pull request review thread.
+type ReviewThreadComment struct {
+	ID        string `json:"id"`
+	Author    string `json:"author,omitempty"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// ReviewThread is a pull request review thread along with its resolution state and comments.
+type ReviewThread struct {
+	ID         string `json:"id"`
+	IsResolved bool   `json:"is_resolved"`
+	// IsOutdated is set when the lines the thread was started on have since changed.
+	IsOutdated  bool   `json:"is_outdated"`
+	Path        string `json:"path"`
+	SubjectType string `json:"subject_type,omitempty"`
+	// Line and StartLine are unset for outdated threads and file level threads.
+	Line       int                   `json:"line,omitempty"`
+	StartLine  int                   `json:"start_line,omitempty"`
+	DiffSide   string                `json:"diff_side,omitempty"`
+	ResolvedBy string                `json:"resolved_by,omitempty"`
+	Comments   []ReviewThreadComment `json:"comments"`
+	// TotalComments may exceed len(Comments) for threads with more than 100 comments.
+	TotalComments int `json:"total_comments"`
+}
+
+// ReviewThreadsResult is a page of the review threads of a pull request.
+type ReviewThreadsResult struct {
+	// TotalCount is the number of threads of the pull request, resolved or not.
+	TotalCount int            `json:"total_count"`
+	Threads    []ReviewThread `json:"threads"`
+	// NextCursor is the cursor to the following threads, if any.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// reviewThreadNode is the selection of a review thread shared by the list query and the resolve mutations.
+type reviewThreadNode struct {
+	ID          githubv4.ID
+	IsResolved  githubv4.Boolean
+	IsOutdated  githubv4.Boolean
+	Path        githubv4.String
+	SubjectType githubv4.String
+	Line        *githubv4.Int
+	StartLine   *githubv4.Int
+	DiffSide    githubv4.String
+	ResolvedBy  *struct {
+		Login githubv4.String
+	}
+	Comments struct {
+		TotalCount githubv4.Int
+		Nodes      []struct {
+			ID     githubv4.ID
+			Author *struct {
+				Login githubv4.String
+			}
+			Body      githubv4.String
+			CreatedAt githubv4.DateTime
+			URL       githubv4.URI
+		}
+	} `graphql:"comments(first: 100)"`
+}
+
+func reviewThreadFromNode(node reviewThreadNode) ReviewThread {
+	thread := ReviewThread{
+		ID:            fmt.Sprint(node.ID),
+		IsResolved:    bool(node.IsResolved),
+		IsOutdated:    bool(node.IsOutdated),
+		Path:          string(node.Path),
+		SubjectType:   string(node.SubjectType),
+		DiffSide:      string(node.DiffSide),
+		Comments:      make([]ReviewThreadComment, 0, len(node.Comments.Nodes)),
+		TotalComments: int(node.Comments.TotalCount),
+	}
+	if node.Line != nil {
+		thread.Line = int(*node.Line)
+	}
+	if node.StartLine != nil {
+		thread.StartLine = int(*node.StartLine)
+	}
+	if node.ResolvedBy != nil {
+		thread.ResolvedBy = string(node.ResolvedBy.Login)
+	}
+	for _, c := range node.Comments.Nodes {
+		comment := ReviewThreadComment{
+			ID:        fmt.Sprint(c.ID),
+			Body:      string(c.Body),
+			CreatedAt: c.CreatedAt.Format(time.RFC3339),
+			URL:       c.URL.String(),
+		}
+		// The author is null for deleted accounts.
+		if c.Author != nil {
+			comment.Author = string(c.Author.Login)
+		}
+		thread.Comments = append(thread.Comments, comment)
+	}
+	return thread
+}
+
+// GetPullRequestReviewThreads creates a tool to list the review threads of a pull request.
+func GetPullRequestReviewThreads(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("get_pull_request_review_threads",
+			mcp.WithDescription(t("TOOL_GET_PULL_REQUEST_REVIEW_THREADS_DESCRIPTION", "Get the review threads of a pull request, with whether each is resolved or outdated, the file and line it is on, and its comments. Use the thread IDs with reply_to_pull_request_review_thread and resolve_pull_request_review_thread. Results are paginated with a cursor: pass the returned next_cursor as 'cursor' to fetch the next page.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_GET_PULL_REQUEST_REVIEW_THREADS_USER_TITLE", "Get pull request review threads"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("pullNumber",
+				mcp.Required(),
+				mcp.Description("Pull request number"),
+			),
+			mcp.WithBoolean("unresolvedOnly",
+				mcp.Description("Only return threads that are not resolved yet. total_count still counts all threads"),
+			),
+			WithCursorPagination(),
+			WithFields(),
+			WithOutputSchema[ReviewThreadsResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				Owner          string
+				Repo           string
+				PullNumber     int32
+				UnresolvedOnly bool
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var totalCount int
+			nodes, next, err := collectGQLPages(ctx, pagination, func(first int, after string) ([]reviewThreadNode, gqlPageInfo, error) {
+				var query struct {
+					Repository struct {
+						PullRequest struct {
+							ReviewThreads struct {
+								TotalCount githubv4.Int
+								PageInfo   struct {
+									HasNextPage githubv4.Boolean
+									EndCursor   githubv4.String
+								}
+								Nodes []reviewThreadNode
+							} `graphql:"reviewThreads(first: $first, after: $after)"`
+						} `graphql:"pullRequest(number: $prNum)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+
+				vars := map[string]any{
+					"owner": githubv4.String(params.Owner),
+					"repo":  githubv4.String(params.Repo),
+					"prNum": githubv4.Int(params.PullNumber),
+					"first": githubv4.Int(first),
+					"after": newGQLStringlike[githubv4.String](after),
+				}
+
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return nil, gqlPageInfo{}, err
+				}
+
+				threads := query.Repository.PullRequest.ReviewThreads
+				totalCount = int(threads.TotalCount)
+				nodes := threads.Nodes
+				if params.UnresolvedOnly {
+					nodes = slices.DeleteFunc(nodes, func(node reviewThreadNode) bool { return bool(node.IsResolved) })
+				}
+				return nodes, gqlPageInfo{
+					HasNextPage: bool(threads.PageInfo.HasNextPage),
+					EndCursor:   string(threads.PageInfo.EndCursor),
+				}, nil
+			})
+			if err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			result := ReviewThreadsResult{
+				TotalCount: totalCount,
+				Threads:    make([]ReviewThread, 0, len(nodes)),
+				NextCursor: next,
+			}
+			for _, node := range nodes {
+				result.Threads = append(result.Threads, reviewThreadFromNode(node))
+			}
+
+			return projectedTextResult(request, result), nil
+		}
+}
+
+// ReplyToPullRequestReviewThread creates a tool to reply to a pull request review thread.
+func ReplyToPullRequestReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("reply_to_pull_request_review_thread",
+			mcp.WithDescription(t("TOOL_REPLY_TO_PULL_REQUEST_REVIEW_THREAD_DESCRIPTION", "Reply to a pull request review thread. The reply is published immediately rather than added to a pending review.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REPLY_TO_PULL_REQUEST_REVIEW_THREAD_USER_TITLE", "Reply to pull request review thread"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			mcp.WithString("threadId",
+				mcp.Required(),
+				mcp.Description("The ID of the review thread, as returned by get_pull_request_review_threads"),
+			),
+			mcp.WithString("body",
+				mcp.Required(),
+				mcp.Description("The text of the reply"),
+			),
+			WithOutputSchema[ReviewThreadComment](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var params struct {
+				ThreadID string
+				Body     string
+			}
+			if err := mapstructure.Decode(request.Params.Arguments, &params); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if params.ThreadID == "" {
+				return mcp.NewToolResultError("missing required parameter: threadId"), nil
+			}
+			if params.Body == "" {
+				return mcp.NewToolResultError("missing required parameter: body"), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				AddPullRequestReviewThreadReply struct {
+					Comment struct {
+						ID        githubv4.ID
+						Body      githubv4.String
+						CreatedAt githubv4.DateTime
+						URL       githubv4.URI
+					}
+				} `graphql:"addPullRequestReviewThreadReply(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&mutation,
+				githubv4.AddPullRequestReviewThreadReplyInput{
+					PullRequestReviewThreadID: githubv4.ID(params.ThreadID),
+					Body:                      githubv4.String(params.Body),
+				},
+				nil,
+			); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			comment := mutation.AddPullRequestReviewThreadReply.Comment
+			return structuredTextResult(ReviewThreadComment{
+				ID:        fmt.Sprint(comment.ID),
+				Body:      string(comment.Body),
+				CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+				URL:       comment.URL.String(),
+			}), nil
+		}
+}
+
+// ResolvePullRequestReviewThread creates a tool to resolve a pull request review thread.
+func ResolvePullRequestReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("resolve_pull_request_review_thread",
+			mcp.WithDescription(t("TOOL_RESOLVE_PULL_REQUEST_REVIEW_THREAD_DESCRIPTION", "Mark a pull request review thread as resolved, typically once the feedback in it has been addressed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_RESOLVE_PULL_REQUEST_REVIEW_THREAD_USER_TITLE", "Resolve pull request review thread"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("threadId",
+				mcp.Required(),
+				mcp.Description("The ID of the review thread, as returned by get_pull_request_review_threads"),
+			),
+			WithOutputSchema[ReviewThread](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threadID, err := requiredParam[string](request, "threadId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				ResolveReviewThread struct {
+					Thread reviewThreadNode
+				} `graphql:"resolveReviewThread(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&mutation,
+				githubv4.ResolveReviewThreadInput{
+					ThreadID: githubv4.ID(threadID),
+				},
+				nil,
+			); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return structuredTextResult(reviewThreadFromNode(mutation.ResolveReviewThread.Thread)), nil
+		}
+}
+
+// UnresolvePullRequestReviewThread creates a tool to unresolve a pull request review thread.
+func UnresolvePullRequestReviewThread(getGQLClient GetGQLClientFn, t translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc) {
+	return mcp.NewTool("unresolve_pull_request_review_thread",
+			mcp.WithDescription(t("TOOL_UNRESOLVE_PULL_REQUEST_REVIEW_THREAD_DESCRIPTION", "Mark a resolved pull request review thread as unresolved again, for example when the fix turned out to be incomplete.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_UNRESOLVE_PULL_REQUEST_REVIEW_THREAD_USER_TITLE", "Unresolve pull request review thread"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			mcp.WithString("threadId",
+				mcp.Required(),
+				mcp.Description("The ID of the review thread, as returned by get_pull_request_review_threads"),
+			),
+			WithOutputSchema[ReviewThread](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			threadID, err := requiredParam[string](request, "threadId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getGQLClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var mutation struct {
+				UnresolveReviewThread struct {
+					Thread reviewThreadNode
+				} `graphql:"unresolveReviewThread(input: $input)"`
+			}
+
+			if err := client.Mutate(
+				ctx,
+				&mutation,
+				githubv4.UnresolveReviewThreadInput{
+					ThreadID: githubv4.ID(threadID),
+				},
+				nil,
+			); err != nil {
+				return toolErrorResult(err), nil
+			}
+
+			return structuredTextResult(reviewThreadFromNode(mutation.UnresolveReviewThread.Thread)), nil
+		}
+}
@@ -0,0 +1,525 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestReviewThreads(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := GetPullRequestReviewThreads(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "get_pull_request_review_threads", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "pullNumber")
+	assert.Contains(t, tool.InputSchema.Properties, "unresolvedOnly")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.Contains(t, tool.InputSchema.Properties, "cursor")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "pullNumber"})
+
+	threadsQuery := struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					TotalCount githubv4.Int
+					PageInfo   struct {
+						HasNextPage githubv4.Boolean
+						EndCursor   githubv4.String
+					}
+					Nodes []reviewThreadNode
+				} `graphql:"reviewThreads(first: $first, after: $after)"`
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+
+	threadsResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{
+				"reviewThreads": map[string]any{
+					"totalCount": 3,
+					"pageInfo": map[string]any{
+						"hasNextPage": true,
+						"endCursor":   "Y3Vyc29yOjI=",
+					},
+					"nodes": []any{
+						map[string]any{
+							"id":          "PRRT_1",
+							"isResolved":  false,
+							"isOutdated":  false,
+							"path":        "pkg/server.go",
+							"subjectType": "LINE",
+							"line":        42,
+							"startLine":   40,
+							"diffSide":    "RIGHT",
+							"resolvedBy":  nil,
+							"comments": map[string]any{
+								"totalCount": 2,
+								"nodes": []any{
+									map[string]any{
+										"id":        "PRRC_1",
+										"author":    map[string]any{"login": "reviewer"},
+										"body":      "This can panic on a nil client",
+										"createdAt": "2025-04-01T10:00:00Z",
+										"url":       "https://github.com/owner/repo/pull/42#discussion_r1",
+									},
+									map[string]any{
+										"id":        "PRRC_2",
+										"author":    nil,
+										"body":      "Agreed",
+										"createdAt": "2025-04-01T11:00:00Z",
+										"url":       "https://github.com/owner/repo/pull/42#discussion_r2",
+									},
+								},
+							},
+						},
+						map[string]any{
+							"id":          "PRRT_2",
+							"isResolved":  true,
+							"isOutdated":  true,
+							"path":        "README.md",
+							"subjectType": "FILE",
+							"line":        nil,
+							"startLine":   nil,
+							"diffSide":    "RIGHT",
+							"resolvedBy":  map[string]any{"login": "author"},
+							"comments": map[string]any{
+								"totalCount": 0,
+								"nodes":      []any{},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	lastPageResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"pullRequest": map[string]any{
+				"reviewThreads": map[string]any{
+					"totalCount": 3,
+					"pageInfo": map[string]any{
+						"hasNextPage": false,
+						"endCursor":   "Y3Vyc29yOjM=",
+					},
+					"nodes": []any{
+						map[string]any{
+							"id":          "PRRT_3",
+							"isResolved":  false,
+							"isOutdated":  false,
+							"path":        "pkg/client.go",
+							"subjectType": "LINE",
+							"line":        7,
+							"startLine":   nil,
+							"diffSide":    "LEFT",
+							"resolvedBy":  nil,
+							"comments": map[string]any{
+								"totalCount": 0,
+								"nodes":      []any{},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	unresolvedThread := ReviewThread{
+		ID:          "PRRT_1",
+		Path:        "pkg/server.go",
+		SubjectType: "LINE",
+		Line:        42,
+		StartLine:   40,
+		DiffSide:    "RIGHT",
+		Comments: []ReviewThreadComment{
+			{ID: "PRRC_1", Author: "reviewer", Body: "This can panic on a nil client", CreatedAt: "2025-04-01T10:00:00Z", URL: "https://github.com/owner/repo/pull/42#discussion_r1"},
+			{ID: "PRRC_2", Body: "Agreed", CreatedAt: "2025-04-01T11:00:00Z", URL: "https://github.com/owner/repo/pull/42#discussion_r2"},
+		},
+		TotalComments: 2,
+	}
+	resolvedThread := ReviewThread{
+		ID:          "PRRT_2",
+		IsResolved:  true,
+		IsOutdated:  true,
+		Path:        "README.md",
+		SubjectType: "FILE",
+		DiffSide:    "RIGHT",
+		ResolvedBy:  "author",
+		Comments:    []ReviewThreadComment{},
+	}
+	lastThread := ReviewThread{
+		ID:          "PRRT_3",
+		Path:        "pkg/client.go",
+		SubjectType: "LINE",
+		Line:        7,
+		DiffSide:    "LEFT",
+		Comments:    []ReviewThreadComment{},
+	}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedResult     ReviewThreadsResult
+	}{
+		{
+			name: "all threads",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					threadsQuery,
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"repo":  githubv4.String("repo"),
+						"prNum": githubv4.Int(42),
+						"first": githubv4.Int(2),
+						"after": (*githubv4.String)(nil),
+					},
+					threadsResponse,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"perPage":    float64(2),
+			},
+			expectedResult: ReviewThreadsResult{
+				TotalCount: 3,
+				Threads:    []ReviewThread{unresolvedThread, resolvedThread},
+				NextCursor: pageCursor{PerPage: 2, After: "Y3Vyc29yOjI="}.String(),
+			},
+		},
+		{
+			name: "unresolved threads only",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					threadsQuery,
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"repo":  githubv4.String("repo"),
+						"prNum": githubv4.Int(42),
+						"first": githubv4.Int(2),
+						"after": githubv4mock.Ptr(githubv4.String("Y3Vyc29yOjA=")),
+					},
+					threadsResponse,
+				),
+				githubv4mock.NewQueryMatcher(
+					threadsQuery,
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"repo":  githubv4.String("repo"),
+						"prNum": githubv4.Int(42),
+						"first": githubv4.Int(1),
+						"after": githubv4mock.Ptr(githubv4.String("Y3Vyc29yOjI=")),
+					},
+					lastPageResponse,
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":          "owner",
+				"repo":           "repo",
+				"pullNumber":     float64(42),
+				"unresolvedOnly": true,
+				"cursor":         pageCursor{PerPage: 2, After: "Y3Vyc29yOjA="}.String(),
+			},
+			expectedResult: ReviewThreadsResult{
+				TotalCount: 3,
+				Threads:    []ReviewThread{unresolvedThread, lastThread},
+			},
+		},
+		{
+			name: "pull request not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					threadsQuery,
+					map[string]any{
+						"owner": githubv4.String("owner"),
+						"repo":  githubv4.String("repo"),
+						"prNum": githubv4.Int(999),
+						"first": githubv4.Int(30),
+						"after": (*githubv4.String)(nil),
+					},
+					githubv4mock.ErrorResponse("Could not resolve to a PullRequest with the number of 999."),
+				),
+			),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "Could not resolve to a PullRequest",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := GetPullRequestReviewThreads(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var returnedResult ReviewThreadsResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
+
+func Test_ReplyToPullRequestReviewThread(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definition once
+	mockClient := githubv4.NewClient(nil)
+	tool, _ := ReplyToPullRequestReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "reply_to_pull_request_review_thread", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "threadId")
+	assert.Contains(t, tool.InputSchema.Properties, "body")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"threadId", "body"})
+
+	replyMutation := struct {
+		AddPullRequestReviewThreadReply struct {
+			Comment struct {
+				ID        githubv4.ID
+				Body      githubv4.String
+				CreatedAt githubv4.DateTime
+				URL       githubv4.URI
+			}
+		} `graphql:"addPullRequestReviewThreadReply(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name               string
+		mockedClient       *http.Client
+		requestArgs        map[string]any
+		expectToolError    bool
+		expectedToolErrMsg string
+		expectedComment    ReviewThreadComment
+	}{
+		{
+			name: "reply to thread",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(
+					replyMutation,
+					githubv4.AddPullRequestReviewThreadReplyInput{
+						PullRequestReviewThreadID: githubv4.ID("PRRT_1"),
+						Body:                      githubv4.String("Fixed in the latest commit"),
+					},
+					nil,
+					githubv4mock.DataResponse(map[string]any{
+						"addPullRequestReviewThreadReply": map[string]any{
+							"comment": map[string]any{
+								"id":        "PRRC_3",
+								"body":      "Fixed in the latest commit",
+								"createdAt": "2025-04-02T09:00:00Z",
+								"url":       "https://github.com/owner/repo/pull/42#discussion_r3",
+							},
+						},
+					}),
+				),
+			),
+			requestArgs: map[string]any{
+				"threadId": "PRRT_1",
+				"body":     "Fixed in the latest commit",
+			},
+			expectedComment: ReviewThreadComment{
+				ID:        "PRRC_3",
+				Body:      "Fixed in the latest commit",
+				CreatedAt: "2025-04-02T09:00:00Z",
+				URL:       "https://github.com/owner/repo/pull/42#discussion_r3",
+			},
+		},
+		{
+			name:         "missing body",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"threadId": "PRRT_1",
+			},
+			expectToolError:    true,
+			expectedToolErrMsg: "missing required parameter: body",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup client with mock
+			client := githubv4.NewClient(tc.mockedClient)
+			_, handler := ReplyToPullRequestReviewThread(stubGetGQLClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedToolErrMsg)
+				return
+			}
+
+			var returnedComment ReviewThreadComment
+			err = json.Unmarshal([]byte(textContent.Text), &returnedComment)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedComment, returnedComment)
+		})
+	}
+}
+
+func Test_ResolvePullRequestReviewThread(t *testing.T) {
+	t.Parallel()
+
+	// Verify tool definitions once
+	mockClient := githubv4.NewClient(nil)
+	resolveTool, _ := ResolvePullRequestReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+	unresolveTool, _ := UnresolvePullRequestReviewThread(stubGetGQLClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "resolve_pull_request_review_thread", resolveTool.Name)
+	assert.Equal(t, "unresolve_pull_request_review_thread", unresolveTool.Name)
+	assert.Contains(t, resolveTool.InputSchema.Properties, "threadId")
+	assert.Contains(t, unresolveTool.InputSchema.Properties, "threadId")
+	assert.ElementsMatch(t, resolveTool.InputSchema.Required, []string{"threadId"})
+	assert.ElementsMatch(t, unresolveTool.InputSchema.Required, []string{"threadId"})
+
+	threadResponse := func(resolved bool) map[string]any {
+		thread := map[string]any{
+			"id":          "PRRT_1",
+			"isResolved":  resolved,
+			"isOutdated":  false,
+			"path":        "pkg/server.go",
+			"subjectType": "LINE",
+			"line":        42,
+			"startLine":   nil,
+			"diffSide":    "RIGHT",
+			"resolvedBy":  nil,
+			"comments": map[string]any{
+				"totalCount": 0,
+				"nodes":      []any{},
+			},
+		}
+		if resolved {
+			thread["resolvedBy"] = map[string]any{"login": "author"}
+		}
+		return thread
+	}
+
+	t.Run("resolve", func(t *testing.T) {
+		t.Parallel()
+
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewMutationMatcher(
+				struct {
+					ResolveReviewThread struct {
+						Thread reviewThreadNode
+					} `graphql:"resolveReviewThread(input: $input)"`
+				}{},
+				githubv4.ResolveReviewThreadInput{
+					ThreadID: githubv4.ID("PRRT_1"),
+				},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"resolveReviewThread": map[string]any{"thread": threadResponse(true)},
+				}),
+			),
+		)
+
+		_, handler := ResolvePullRequestReviewThread(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"threadId": "PRRT_1"}))
+		require.NoError(t, err)
+
+		var returnedThread ReviewThread
+		err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedThread)
+		require.NoError(t, err)
+		assert.True(t, returnedThread.IsResolved)
+		assert.Equal(t, "author", returnedThread.ResolvedBy)
+	})
+
+	t.Run("unresolve", func(t *testing.T) {
+		t.Parallel()
+
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewMutationMatcher(
+				struct {
+					UnresolveReviewThread struct {
+						Thread reviewThreadNode
+					} `graphql:"unresolveReviewThread(input: $input)"`
+				}{},
+				githubv4.UnresolveReviewThreadInput{
+					ThreadID: githubv4.ID("PRRT_1"),
+				},
+				nil,
+				githubv4mock.DataResponse(map[string]any{
+					"unresolveReviewThread": map[string]any{"thread": threadResponse(false)},
+				}),
+			),
+		)
+
+		_, handler := UnresolvePullRequestReviewThread(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"threadId": "PRRT_1"}))
+		require.NoError(t, err)
+
+		var returnedThread ReviewThread
+		err = json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedThread)
+		require.NoError(t, err)
+		assert.False(t, returnedThread.IsResolved)
+		assert.Empty(t, returnedThread.ResolvedBy)
+	})
+
+	t.Run("unknown thread", func(t *testing.T) {
+		t.Parallel()
+
+		mockedClient := githubv4mock.NewMockedHTTPClient(
+			githubv4mock.NewMutationMatcher(
+				struct {
+					ResolveReviewThread struct {
+						Thread reviewThreadNode
+					} `graphql:"resolveReviewThread(input: $input)"`
+				}{},
+				githubv4.ResolveReviewThreadInput{
+					ThreadID: githubv4.ID("PRRT_missing"),
+				},
+				nil,
+				githubv4mock.ErrorResponse("Could not resolve to a node with the global id of 'PRRT_missing'"),
+			),
+		)
+
+		_, handler := ResolvePullRequestReviewThread(stubGetGQLClientFn(githubv4.NewClient(mockedClient)), translations.NullTranslationHelper)
+		result, err := handler(context.Background(), createMCPRequest(map[string]any{"threadId": "PRRT_missing"}))
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "Could not resolve to a node")
+	})
+}
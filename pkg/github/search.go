@@ -2,9 +2,8 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
+	"net/http"
 
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v72/github"
@@ -19,13 +18,18 @@ type CleanedCodeSearchResult struct {
 	CodeResults       []*CleanedCodeResult `json:"items,omitempty"`
 }
 
-// CleanedCodeResult represents a cleaned version of CodeResult without URL fields
+// CleanedCodeResult represents a cleaned version of CodeResult keeping only html_url
 type CleanedCodeResult struct {
 	Name        *string             `json:"name,omitempty"`
 	Path        *string             `json:"path,omitempty"`
 	SHA         *string             `json:"sha,omitempty"`
+	HTMLURL     *string             `json:"html_url,omitempty"`
 	Repository  *CleanedRepository  `json:"repository,omitempty"`
 	TextMatches []*CleanedTextMatch `json:"text_matches,omitempty"`
+	// Context is the lines around the matches, when requested. It replaces the text matches.
+	Context []*CodeContext `json:"context,omitempty"`
+	// ContextError is why the lines around the matches couldn't be returned.
+	ContextError string `json:"context_error,omitempty"`
 }
 
 // CleanedTextMatch represents a cleaned version of TextMatch without URL fields
@@ -64,13 +68,14 @@ func cleanCodeResult(result *github.CodeResult) *CleanedCodeResult {
 	}
 
 	cleaned := &CleanedCodeResult{
-		Name: result.Name,
-		Path: result.Path,
-		SHA:  result.SHA,
+		Name:    result.Name,
+		Path:    result.Path,
+		SHA:     result.SHA,
+		HTMLURL: result.HTMLURL,
 	}
 
 	if result.Repository != nil {
-		cleaned.Repository = cleanRepositoryForSearch(result.Repository)
+		cleaned.Repository = cleanRepository(result.Repository)
 	}
 
 	if result.TextMatches != nil {
@@ -102,6 +107,82 @@ func cleanTextMatch(textMatch *github.TextMatch) *CleanedTextMatch {
 	return cleaned
 }
 
+// CleanedCommitsSearchResult represents a cleaned version of CommitsSearchResult
+type CleanedCommitsSearchResult struct {
+	Total             *int                   `json:"total_count,omitempty"`
+	IncompleteResults *bool                  `json:"incomplete_results,omitempty"`
+	Commits           []*CleanedCommitResult `json:"items,omitempty"`
+}
+
+// CleanedCommitResult represents a cleaned version of CommitResult keeping only html_url
+type CleanedCommitResult struct {
+	SHA        *string              `json:"sha,omitempty"`
+	Message    *string              `json:"message,omitempty"`
+	Author     *CleanedCommitAuthor `json:"author,omitempty"`
+	Committer  *CleanedCommitAuthor `json:"committer,omitempty"`
+	HTMLURL    *string              `json:"html_url,omitempty"`
+	Repository *CleanedRepository   `json:"repository,omitempty"`
+}
+
+// CleanedCommitAuthor represents the author or committer of a commit, with their login if GitHub knows them
+type CleanedCommitAuthor struct {
+	Name  *string           `json:"name,omitempty"`
+	Email *string           `json:"email,omitempty"`
+	Login *string           `json:"login,omitempty"`
+	Date  *github.Timestamp `json:"date,omitempty"`
+}
+
+// cleanCommitResult removes URL fields from CommitResult, flattening the git commit into it
+func cleanCommitResult(result *github.CommitResult) *CleanedCommitResult {
+	if result == nil {
+		return nil
+	}
+
+	cleaned := &CleanedCommitResult{
+		SHA:        result.SHA,
+		HTMLURL:    result.HTMLURL,
+		Repository: cleanRepository(result.Repository),
+	}
+	if result.Commit != nil {
+		cleaned.Message = result.Commit.Message
+		cleaned.Author = cleanCommitAuthor(result.Commit.Author, result.Author)
+		cleaned.Committer = cleanCommitAuthor(result.Commit.Committer, result.Committer)
+	}
+
+	return cleaned
+}
+
+// cleanCommitAuthor combines the git author or committer of a commit with the GitHub user it belongs to
+func cleanCommitAuthor(author *github.CommitAuthor, user *github.User) *CleanedCommitAuthor {
+	if author == nil {
+		return nil
+	}
+
+	cleaned := &CleanedCommitAuthor{
+		Name:  author.Name,
+		Email: author.Email,
+		Date:  author.Date,
+	}
+	if user != nil {
+		cleaned.Login = user.Login
+	}
+
+	return cleaned
+}
+
+// cleanCommitResults cleans a slice of CommitResult
+func cleanCommitResults(results []*github.CommitResult) []*CleanedCommitResult {
+	if results == nil {
+		return nil
+	}
+
+	cleaned := make([]*CleanedCommitResult, len(results))
+	for i, result := range results {
+		cleaned[i] = cleanCommitResult(result)
+	}
+	return cleaned
+}
+
 // SearchRepositories creates a tool to search for GitHub repositories.
 func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
 	return mcp.NewTool("search_repositories",
@@ -115,6 +196,8 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				mcp.Description("Search query"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SearchResult[*CleanedRepository]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := requiredParam[string](request, "query")
@@ -126,37 +209,35 @@ func SearchRepositories(getClient GetClientFn, t translations.TranslationHelperF
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			opts := &github.SearchOptions{
-				ListOptions: github.ListOptions{
-					Page:    pagination.page,
-					PerPage: pagination.perPage,
-				},
-			}
+			opts := &github.SearchOptions{}
 
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			result, resp, err := client.Search.Repositories(ctx, query, opts)
+			var result *github.RepositoriesSearchResult
+			repositories, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.Repository, *github.Response, error) {
+				opts.ListOptions = listOpts
+				page, resp, err := client.Search.Repositories(ctx, query, opts)
+				if page == nil {
+					return nil, resp, err
+				}
+				result = page
+				return page.Repositories, resp, err
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to search repositories: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search repositories: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to search repositories"), nil
 			}
 
-			r, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
+			result.Repositories = repositories
 
-			return mcp.NewToolResultText(string(r)), nil
+			structured := searchResult(result.GetTotal(), result.GetIncompleteResults(), cleanRepositories(repositories), next)
+			return projectedListResult(request, result, structured, next), nil
 		}
 }
 
@@ -179,7 +260,14 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				mcp.Description("Sort order"),
 				mcp.Enum("asc", "desc"),
 			),
+			mcp.WithNumber("context_lines",
+				mcp.Description(fmt.Sprintf("Return this many lines around each match, with their line numbers, from the files at the version that was indexed, instead of match fragments. Saves fetching each file, max %d. Only the first %d results of a call get context", maxCodeContextLines, maxCodeContextResults)),
+				mcp.Min(0),
+				mcp.Max(maxCodeContextLines),
+			),
 			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SearchResult[*CleanedCodeResult]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := requiredParam[string](request, "q")
@@ -194,6 +282,13 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			contextLines, err := OptionalIntParam(request, "context_lines")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if contextLines < 0 || contextLines > maxCodeContextLines {
+				return mcp.NewToolResultError(fmt.Sprintf("context_lines must be between 0 and %d", maxCodeContextLines)), nil
+			}
 			pagination, err := OptionalPaginationParams(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
@@ -202,10 +297,8 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 			opts := &github.SearchOptions{
 				Sort:  sort,
 				Order: order,
-				ListOptions: github.ListOptions{
-					PerPage: pagination.perPage,
-					Page:    pagination.page,
-				},
+				// Matches are located in the files from their text match fragments.
+				TextMatch: contextLines > 0,
 			}
 
 			client, err := getClient(ctx)
@@ -213,28 +306,234 @@ func SearchCode(getClient GetClientFn, t translations.TranslationHelperFunc) (to
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			result, resp, err := client.Search.Code(ctx, query, opts)
+			var result *github.CodeSearchResult
+			codeResults, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.CodeResult, *github.Response, error) {
+				opts.ListOptions = listOpts
+				page, resp, err := client.Search.Code(ctx, query, opts)
+				if page == nil {
+					return nil, resp, err
+				}
+				result = page
+				return page.CodeResults, resp, err
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to search code: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search code: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to search code"), nil
 			}
 
+			result.CodeResults = codeResults
+
 			cleanedResult := cleanCodeSearchResult(result)
+			if contextLines > 0 {
+				addCodeContexts(ctx, client, codeResults, cleanedResult.CodeResults, contextLines)
+			}
 
-			r, err := json.Marshal(cleanedResult)
+			structured := searchResult(result.GetTotal(), result.GetIncompleteResults(), cleanedResult.CodeResults, next)
+			return projectedListResult(request, cleanedResult, structured, next), nil
+		}
+}
+
+// SearchCommits creates a tool to search for commits with typed filters.
+func SearchCommits(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_commits",
+			mcp.WithDescription(t("TOOL_SEARCH_COMMITS_DESCRIPTION", "Search for commits on the default branches of GitHub repositories. Filters are combined into a search query, so no search syntax is needed; at least one of query or the filters is required.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_COMMITS_USER_TITLE", "Search commits"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Description("Keywords to match in the commit message"),
+			),
+			mcp.WithString("owner",
+				mcp.Description("Only match commits in repositories of this user or organization"),
+			),
+			mcp.WithString("repo",
+				mcp.Description("Only match commits in this repository of owner"),
+			),
+			mcp.WithString("author",
+				mcp.Description("Login of the author, or @me"),
+			),
+			mcp.WithString("committer",
+				mcp.Description("Login of the committer, or @me"),
+			),
+			mcp.WithString("author_email",
+				mcp.Description("Email of the author"),
+			),
+			mcp.WithString("committer_email",
+				mcp.Description("Email of the committer"),
+			),
+			mcp.WithString("hash",
+				mcp.Description("Full or abbreviated SHA of the commit"),
+			),
+			mcp.WithString("parent",
+				mcp.Description("Full or abbreviated SHA of a parent of the commit"),
+			),
+			mcp.WithBoolean("merge",
+				mcp.Description("Only match merge commits when true, or other commits when false"),
+			),
+			WithDateRange("author_date", "authored"),
+			WithDateRange("committer_date", "committed"),
+			mcp.WithString("sort",
+				mcp.Description("Sort field, defaults to best match"),
+				mcp.Enum("author-date", "committer-date"),
+			),
+			mcp.WithString("order",
+				mcp.Description("Sort order"),
+				mcp.Enum("asc", "desc"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SearchResult[*CleanedCommitResult]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			query, err := newSearchQuery(request).
+				keywords("query").
+				repository().
+				login("author", "author").
+				login("committer", "committer").
+				value("author_email", "author-email").
+				value("committer_email", "committer-email").
+				sha("hash", "hash").
+				sha("parent", "parent").
+				boolean("merge", "merge").
+				dateRange("author_date", "author-date").
+				dateRange("committer_date", "committer-date").
+				build()
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			sort, err := OptionalParam[string](request, "sort")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			order, err := OptionalParam[string](request, "order")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var result *github.CommitsSearchResult
+			commits, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.CommitResult, *github.Response, error) {
+				opts.ListOptions = listOpts
+				page, resp, err := client.Search.Commits(ctx, query, opts)
+				if page == nil {
+					return nil, resp, err
+				}
+				result = page
+				return page.Commits, resp, err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to search commits: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to search commits"), nil
+			}
+
+			cleanedResult := &CleanedCommitsSearchResult{
+				Total:             result.Total,
+				IncompleteResults: result.IncompleteResults,
+				Commits:           cleanCommitResults(commits),
+			}
+
+			structured := searchResult(result.GetTotal(), result.GetIncompleteResults(), cleanedResult.Commits, next)
+			return projectedListResult(request, cleanedResult, structured, next), nil
+		}
+}
+
+// SearchTopics creates a tool to search for repository topics.
+func SearchTopics(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("search_topics",
+			mcp.WithDescription(t("TOOL_SEARCH_TOPICS_DESCRIPTION", "Search for the topics GitHub repositories are classified with, to find related repositories with search_repositories.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SEARCH_TOPICS_USER_TITLE", "Search topics"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("query",
+				mcp.Required(),
+				mcp.Description("Keywords to match in the topic name, aliases and description"),
+			),
+			mcp.WithBoolean("featured",
+				mcp.Description("Only match topics featured on github.com/topics"),
+			),
+			mcp.WithBoolean("curated",
+				mcp.Description("Only match topics with extra information, such as a description"),
+			),
+			mcp.WithNumber("min_repositories",
+				mcp.Description("Only match topics of at least this many repositories"),
+			),
+			WithDateRange("created", "created"),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SearchResult[*github.TopicResult]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if _, err := requiredParam[string](request, "query"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			query, err := newSearchQuery(request).
+				keywords("query").
+				flag("featured", "is:featured").
+				flag("curated", "is:curated").
+				minimum("min_repositories", "repositories").
+				dateRange("created", "created").
+				build()
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			opts := &github.SearchOptions{}
 
-			return mcp.NewToolResultText(string(r)), nil
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var result *github.TopicsSearchResult
+			topics, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.TopicResult, *github.Response, error) {
+				opts.ListOptions = listOpts
+				page, resp, err := client.Search.Topics(ctx, query, opts)
+				if page == nil {
+					return nil, resp, err
+				}
+				result = page
+				return page.Topics, resp, err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to search topics: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to search topics"), nil
+			}
+
+			result.Topics = topics
+
+			structured := searchResult(result.GetTotal(), result.GetIncompleteResults(), topics, next)
+			return projectedListResult(request, result, structured, next), nil
 		}
 }
 
@@ -272,6 +571,8 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				mcp.Enum("asc", "desc"),
 			),
 			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SearchResult[MinimalUser]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			query, err := requiredParam[string](request, "q")
@@ -294,10 +595,6 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 			opts := &github.SearchOptions{
 				Sort:  sort,
 				Order: order,
-				ListOptions: github.ListOptions{
-					PerPage: pagination.perPage,
-					Page:    pagination.page,
-				},
 			}
 
 			client, err := getClient(ctx)
@@ -305,20 +602,27 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
-			result, resp, err := client.Search.Users(ctx, "type:user "+query, opts)
+			var result *github.UsersSearchResult
+			users, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.User, *github.Response, error) {
+				opts.ListOptions = listOpts
+				page, resp, err := client.Search.Users(ctx, "type:user "+query, opts)
+				if page == nil {
+					return nil, resp, err
+				}
+				result = page
+				return page.Users, resp, err
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to search users: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != 200 {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to search users: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to search users"), nil
 			}
 
+			result.Users = users
+
 			minimalUsers := make([]MinimalUser, 0, len(result.Users))
 			for _, user := range result.Users {
 				mu := MinimalUser{
@@ -337,10 +641,7 @@ func SearchUsers(getClient GetClientFn, t translations.TranslationHelperFunc) (t
 				Items:             minimalUsers,
 			}
 
-			r, err := json.Marshal(minimalResp)
-			if err != nil {
-				return nil, fmt.Errorf("failed to marshal response: %w", err)
-			}
-			return mcp.NewToolResultText(string(r)), nil
+			structured := searchResult(minimalResp.TotalCount, minimalResp.IncompleteResults, minimalResp.Items, next)
+			return projectedListResult(request, minimalResp, structured, next), nil
 		}
 }
@@ -0,0 +1,270 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// loginPattern matches user and organization logins, bots and @me, the authenticated user.
+	loginPattern = regexp.MustCompile(`^(@me|[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})(?:\[bot\])?)$`)
+	// repoNamePattern matches repository names.
+	repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+	// shaPattern matches full and abbreviated commit SHAs.
+	shaPattern = regexp.MustCompile(`^[0-9a-fA-F]{7,40}$`)
+)
+
+// searchQuery composes a search query from the keywords and typed filters of a tool, validating filter values
+// and quoting them where needed, so models don't have to write qualifiers themselves. The first invalid
+// argument is kept as the error of the query.
+type searchQuery struct {
+	request mcp.CallToolRequest
+	terms   []string
+	// base is the number of qualifiers the query started with.
+	base int
+	err  error
+}
+
+// newSearchQuery returns a query for the arguments of request, starting with the given qualifiers.
+func newSearchQuery(request mcp.CallToolRequest, qualifiers ...string) *searchQuery {
+	return &searchQuery{request: request, terms: qualifiers, base: len(qualifiers)}
+}
+
+// param returns the string argument p, recording an error if it has the wrong type.
+func (q *searchQuery) param(p string) string {
+	if q.err != nil {
+		return ""
+	}
+	v, err := OptionalParam[string](q.request, p)
+	if err != nil {
+		q.err = err
+	}
+	return strings.TrimSpace(v)
+}
+
+// keywords adds the free text argument p as is.
+func (q *searchQuery) keywords(p string) *searchQuery {
+	if v := q.param(p); v != "" {
+		q.terms = append(q.terms, v)
+	}
+	return q
+}
+
+// login adds the login argument p as qualifier.
+func (q *searchQuery) login(p, qualifier string) *searchQuery {
+	v := q.param(p)
+	if v == "" {
+		return q
+	}
+	if !loginPattern.MatchString(v) {
+		q.err = fmt.Errorf("invalid %s: %q is not a GitHub login", p, v)
+		return q
+	}
+	q.terms = append(q.terms, qualifier+":"+v)
+	return q
+}
+
+// repository adds the owner and repo arguments as a repo qualifier, or the owner alone as a user qualifier,
+// which matches repositories of organizations too.
+func (q *searchQuery) repository() *searchQuery {
+	owner, repo := q.param("owner"), q.param("repo")
+	switch {
+	case q.err != nil:
+	case repo != "" && owner == "":
+		q.err = errors.New("repo requires owner")
+	case owner != "" && !loginPattern.MatchString(owner):
+		q.err = fmt.Errorf("invalid owner: %q is not a GitHub login", owner)
+	case repo != "" && !repoNamePattern.MatchString(repo):
+		q.err = fmt.Errorf("invalid repo: %q is not a repository name", repo)
+	case repo != "":
+		q.terms = append(q.terms, "repo:"+owner+"/"+repo)
+	case owner != "":
+		q.terms = append(q.terms, "user:"+owner)
+	}
+	return q
+}
+
+// value adds the argument p as qualifier, quoted when it contains spaces.
+func (q *searchQuery) value(p, qualifier string) *searchQuery {
+	if v := q.param(p); v != "" {
+		q.add(p, qualifier, v)
+	}
+	return q
+}
+
+// values adds each value of the string array argument p as qualifier, which all have to match.
+func (q *searchQuery) values(p, qualifier string) *searchQuery {
+	if q.err != nil {
+		return q
+	}
+	values, err := OptionalStringArrayParam(q.request, p)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			q.add(p, qualifier, v)
+		}
+	}
+	return q
+}
+
+// add adds qualifier with value v of argument p, quoting it when needed.
+func (q *searchQuery) add(p, qualifier, v string) {
+	if q.err != nil {
+		return
+	}
+	if strings.ContainsAny(v, "\"\n") {
+		q.err = fmt.Errorf("invalid %s: %q may not contain quotes or line breaks", p, v)
+		return
+	}
+	if strings.ContainsAny(v, " \t:()") {
+		v = `"` + v + `"`
+	}
+	q.terms = append(q.terms, qualifier+":"+v)
+}
+
+// sha adds the commit SHA argument p as qualifier.
+func (q *searchQuery) sha(p, qualifier string) *searchQuery {
+	v := q.param(p)
+	if v == "" {
+		return q
+	}
+	if !shaPattern.MatchString(v) {
+		q.err = fmt.Errorf("invalid %s: %q is not a commit SHA", p, v)
+		return q
+	}
+	q.terms = append(q.terms, qualifier+":"+v)
+	return q
+}
+
+// enum adds the qualifier of the value of argument p, one of the keys of qualifiers.
+func (q *searchQuery) enum(p string, qualifiers map[string]string) *searchQuery {
+	v := q.param(p)
+	if v == "" {
+		return q
+	}
+	qualifier, ok := qualifiers[v]
+	if !ok {
+		q.err = fmt.Errorf("invalid %s: %q", p, v)
+		return q
+	}
+	q.terms = append(q.terms, qualifier)
+	return q
+}
+
+// boolean adds the boolean argument p as qualifier, set to true or false.
+func (q *searchQuery) boolean(p, qualifier string) *searchQuery {
+	if q.err != nil {
+		return q
+	}
+	v, ok, err := OptionalParamOK[bool](q.request, p)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	if ok {
+		q.terms = append(q.terms, fmt.Sprintf("%s:%t", qualifier, v))
+	}
+	return q
+}
+
+// flag adds qualifier when the boolean argument p is true.
+func (q *searchQuery) flag(p, qualifier string) *searchQuery {
+	if q.err != nil {
+		return q
+	}
+	v, err := OptionalParam[bool](q.request, p)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	if v {
+		q.terms = append(q.terms, qualifier)
+	}
+	return q
+}
+
+// minimum adds the integer argument p as a lower bound of qualifier.
+func (q *searchQuery) minimum(p, qualifier string) *searchQuery {
+	if q.err != nil {
+		return q
+	}
+	v, ok, err := OptionalParamOK[float64](q.request, p)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	if !ok {
+		return q
+	}
+	if v < 0 || v != float64(int(v)) {
+		q.err = fmt.Errorf("invalid %s: %v is not a positive integer", p, v)
+		return q
+	}
+	q.terms = append(q.terms, fmt.Sprintf("%s:>=%d", qualifier, int(v)))
+	return q
+}
+
+// dateRange adds the <p>_after and <p>_before arguments as a range of qualifier. Both are inclusive, and
+// are dates or timestamps.
+func (q *searchQuery) dateRange(p, qualifier string) *searchQuery {
+	after, before := q.param(p+"_after"), q.param(p+"_before")
+	if q.err != nil {
+		return q
+	}
+	for _, bound := range []struct{ name, value string }{{p + "_after", after}, {p + "_before", before}} {
+		if bound.value == "" {
+			continue
+		}
+		if _, err := parseISOTimestamp(bound.value); err != nil {
+			q.err = fmt.Errorf("invalid %s: %w", bound.name, err)
+			return q
+		}
+	}
+	switch {
+	case after != "" && before != "":
+		a, _ := parseISOTimestamp(after)
+		b, _ := parseISOTimestamp(before)
+		if a.After(b) {
+			q.err = fmt.Errorf("%s_after %s is after %s_before %s", p, after, p, before)
+			return q
+		}
+		q.terms = append(q.terms, fmt.Sprintf("%s:%s..%s", qualifier, after, before))
+	case after != "":
+		q.terms = append(q.terms, fmt.Sprintf("%s:>=%s", qualifier, after))
+	case before != "":
+		q.terms = append(q.terms, fmt.Sprintf("%s:<=%s", qualifier, before))
+	}
+	return q
+}
+
+// build returns the query, or the first invalid argument. Queries without keywords or filters are invalid,
+// as they would match everything.
+func (q *searchQuery) build() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	if len(q.terms) == q.base {
+		return "", errors.New("at least one of query or the filters is required")
+	}
+	return strings.Join(q.terms, " "), nil
+}
+
+// WithDateRange returns a ToolOption adding the <name>_after and <name>_before arguments of a date range
+// filter, as read by searchQuery.dateRange.
+func WithDateRange(name, description string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString(name+"_after",
+			mcp.Description(fmt.Sprintf("Only match items %s on or after this date, as YYYY-MM-DD or an ISO 8601 timestamp", description)),
+		)(tool)
+		mcp.WithString(name+"_before",
+			mcp.Description(fmt.Sprintf("Only match items %s on or before this date, as YYYY-MM-DD or an ISO 8601 timestamp", description)),
+		)(tool)
+	}
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SearchQuery(t *testing.T) {
+	build := func(args map[string]any) (string, error) {
+		return newSearchQuery(createMCPRequest(args), "is:pr").
+			keywords("query").
+			repository().
+			login("author", "author").
+			enum("state", map[string]string{"open": "is:open", "merged": "is:merged"}).
+			boolean("draft", "draft").
+			values("labels", "label").
+			value("head", "head").
+			sha("hash", "hash").
+			minimum("min_comments", "comments").
+			dateRange("created", "created").
+			build()
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "keywords and repository",
+			args:     map[string]any{"query": " fix crash ", "owner": "octo-org", "repo": "octo.repo"},
+			expected: "is:pr fix crash repo:octo-org/octo.repo",
+		},
+		{
+			name:     "owner only",
+			args:     map[string]any{"owner": "octo-org"},
+			expected: "is:pr user:octo-org",
+		},
+		{
+			name: "filters",
+			args: map[string]any{
+				"author":       "dependabot[bot]",
+				"state":        "merged",
+				"draft":        false,
+				"labels":       []any{"bug", "good first issue"},
+				"head":         "feature/login",
+				"hash":         "abc1234",
+				"min_comments": float64(3),
+			},
+			expected: `is:pr author:dependabot[bot] is:merged draft:false label:bug label:"good first issue" head:feature/login hash:abc1234 comments:>=3`,
+		},
+		{
+			name:     "date range",
+			args:     map[string]any{"author": "@me", "created_after": "2025-01-01", "created_before": "2025-03-31T12:00:00Z"},
+			expected: "is:pr author:@me created:2025-01-01..2025-03-31T12:00:00Z",
+		},
+		{
+			name:     "open ended date range",
+			args:     map[string]any{"created_before": "2025-01-01"},
+			expected: "is:pr created:<=2025-01-01",
+		},
+		{
+			name:          "no filters",
+			args:          map[string]any{},
+			expectedError: "at least one of query or the filters is required",
+		},
+		{
+			name:          "repo without owner",
+			args:          map[string]any{"repo": "repo"},
+			expectedError: "repo requires owner",
+		},
+		{
+			name:          "invalid login",
+			args:          map[string]any{"author": "author:octocat"},
+			expectedError: `invalid author: "author:octocat" is not a GitHub login`,
+		},
+		{
+			name:          "invalid state",
+			args:          map[string]any{"state": "draft"},
+			expectedError: `invalid state: "draft"`,
+		},
+		{
+			name:          "quoted label",
+			args:          map[string]any{"labels": []any{`say "hi"`}},
+			expectedError: `invalid labels: "say \"hi\"" may not contain quotes or line breaks`,
+		},
+		{
+			name:          "invalid sha",
+			args:          map[string]any{"hash": "main"},
+			expectedError: `invalid hash: "main" is not a commit SHA`,
+		},
+		{
+			name:          "invalid minimum",
+			args:          map[string]any{"min_comments": float64(-1)},
+			expectedError: "invalid min_comments: -1 is not a positive integer",
+		},
+		{
+			name:          "invalid date",
+			args:          map[string]any{"created_after": "last week"},
+			expectedError: "invalid created_after: invalid ISO 8601 timestamp: last week",
+		},
+		{
+			name:          "reversed date range",
+			args:          map[string]any{"created_after": "2025-02-01", "created_before": "2025-01-01"},
+			expectedError: "created_after 2025-02-01 is after created_before 2025-01-01",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := build(tc.args)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
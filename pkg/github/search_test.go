@@ -461,3 +461,168 @@ func Test_SearchUsers(t *testing.T) {
 		})
 	}
 }
+
+func Test_SearchCommits(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchCommits(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "search_commits", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "author")
+	assert.Contains(t, tool.InputSchema.Properties, "author_date_after")
+	assert.Contains(t, tool.InputSchema.Properties, "committer_date_before")
+	assert.Empty(t, tool.InputSchema.Required)
+
+	mockSearchResult := &github.CommitsSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Commits: []*github.CommitResult{
+			{
+				SHA:     github.Ptr("abc123"),
+				HTMLURL: github.Ptr("https://github.com/owner/repo/commit/abc123"),
+				URL:     github.Ptr("https://api.github.com/repos/owner/repo/commits/abc123"),
+				Commit: &github.Commit{
+					Message: github.Ptr("Fix crash on startup"),
+					Author:  &github.CommitAuthor{Name: github.Ptr("Octo Cat"), Email: github.Ptr("octocat@github.com")},
+				},
+				Author:     &github.User{Login: github.Ptr("octocat")},
+				Repository: &github.Repository{FullName: github.Ptr("owner/repo")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "filters composed into query",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetSearchCommits,
+					expectQueryParams(t, map[string]string{
+						"q":        "crash repo:owner/repo author:octocat merge:false author-date:>=2025-01-01",
+						"sort":     "author-date",
+						"order":    "desc",
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockSearchResult),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"query":             "crash",
+				"owner":             "owner",
+				"repo":              "repo",
+				"author":            "octocat",
+				"merge":             false,
+				"author_date_after": "2025-01-01",
+				"sort":              "author-date",
+				"order":             "desc",
+			},
+		},
+		{
+			name:           "no filters",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{},
+			expectError:    true,
+			expectedErrMsg: "at least one of query or the filters is required",
+		},
+		{
+			name:           "invalid hash",
+			mockedClient:   mock.NewMockedHTTPClient(),
+			requestArgs:    map[string]interface{}{"hash": "HEAD~1"},
+			expectError:    true,
+			expectedErrMsg: `invalid hash: "HEAD~1" is not a commit SHA`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SearchCommits(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getTextResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returnedResult CleanedCommitsSearchResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+			assert.Equal(t, 1, *returnedResult.Total)
+			require.Len(t, returnedResult.Commits, 1)
+			commit := returnedResult.Commits[0]
+			assert.Equal(t, "abc123", *commit.SHA)
+			assert.Equal(t, "Fix crash on startup", *commit.Message)
+			assert.Equal(t, "Octo Cat", *commit.Author.Name)
+			assert.Equal(t, "octocat", *commit.Author.Login)
+			assert.Equal(t, "owner/repo", *commit.Repository.FullName)
+		})
+	}
+}
+
+func Test_SearchTopics(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SearchTopics(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "search_topics", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "query")
+	assert.Contains(t, tool.InputSchema.Properties, "featured")
+	assert.Contains(t, tool.InputSchema.Properties, "min_repositories")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"query"})
+
+	mockSearchResult := &github.TopicsSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Topics: []*github.TopicResult{
+			{Name: github.Ptr("golang"), DisplayName: github.Ptr("Go"), Featured: github.Ptr(true)},
+		},
+	}
+
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(
+			mock.GetSearchTopics,
+			expectQueryParams(t, map[string]string{
+				"q":        "go is:featured repositories:>=100",
+				"page":     "1",
+				"per_page": "30",
+			}).andThen(
+				mockResponse(t, http.StatusOK, mockSearchResult),
+			),
+		),
+	))
+	_, handler := SearchTopics(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"query":            "go",
+		"featured":         true,
+		"curated":          false,
+		"min_repositories": float64(100),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returnedResult github.TopicsSearchResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedResult))
+	require.Len(t, returnedResult.Topics, 1)
+	assert.Equal(t, "golang", *returnedResult.Topics[0].Name)
+
+	// The query is required
+	result, err = handler(context.Background(), createMCPRequest(map[string]interface{}{"featured": true}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "missing required parameter: query")
+}
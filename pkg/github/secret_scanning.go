@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -33,6 +32,9 @@ func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHel
 				mcp.Required(),
 				mcp.Description("The number of the alert."),
 			),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[CleanedSecretScanningAlert](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -48,6 +50,11 @@ func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHel
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
 			client, err := getClient(ctx)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
@@ -60,19 +67,24 @@ func GetSecretScanningAlert(getClient GetClientFn, t translations.TranslationHel
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to get alert: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to get alert"), nil
+			}
+
+			structured := cleanSecretScanningAlert(alert)
+			if markdown {
+				return structuredResult(mcp.NewToolResultText(renderSecretScanningAlert(alert)), structured), nil
 			}
 
-			r, err := json.Marshal(alert)
+			projected, err := projectResult(request, compactOr(ctx, alert, cleanSecretScanningAlert))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			r, err := json.Marshal(projected)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal alert: %w", err)
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			return structuredResult(mcp.NewToolResultText(string(r)), structured), nil
 		}
 }
 
@@ -103,6 +115,10 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 				mcp.Description("Filter by resolution"),
 				mcp.Enum("false_positive", "wont_fix", "revoked", "pattern_edited", "pattern_deleted", "used_in_tests"),
 			),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedSecretScanningAlert]](),
 		),
 		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			owner, err := requiredParam[string](request, "owner")
@@ -113,15 +129,72 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			state, err := OptionalParam[string](request, "state")
+			opts, pagination, err := secretScanningAlertListOptions(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			secretType, err := OptionalParam[string](request, "secret_type")
+
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.SecretScanningAlert, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list alerts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list alerts"), nil
+			}
+
+			structured := listResult(cleanSecretScanningAlerts(alerts), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderSecretScanningAlerts(alerts)), next), structured), nil
+			}
+
+			return projectedListResult(request, compactOr(ctx, alerts, cleanSecretScanningAlerts), structured, next), nil
+		}
+}
+
+// ListOrgSecretScanningAlerts creates a tool to list secret scanning alerts across all repositories in an organization.
+func ListOrgSecretScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_org_secret_scanning_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ORG_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts across all repositories in a GitHub organization.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ORG_SECRET_SCANNING_ALERTS_USER_TITLE", "List organization secret scanning alerts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The name of the organization."),
+			),
+			withSecretScanningAlertFilters(),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedSecretScanningAlert]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
-			resolution, err := OptionalParam[string](request, "resolution")
+			opts, pagination, err := secretScanningAlertListOptions(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			markdown, err := markdownOutput(ctx, request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -130,25 +203,137 @@ func ListSecretScanningAlerts(getClient GetClientFn, t translations.TranslationH
 			if err != nil {
 				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
-			alerts, resp, err := client.SecretScanning.ListAlertsForRepo(ctx, owner, repo, &github.SecretScanningAlertListOptions{State: state, SecretType: secretType, Resolution: resolution})
+			alerts, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.SecretScanningAlert, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.SecretScanning.ListAlertsForOrg(ctx, org, opts)
+			})
 			if err != nil {
-				return nil, fmt.Errorf("failed to list alerts: %w", err)
+				return nil, fmt.Errorf("failed to list organization alerts: %w", err)
 			}
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode != http.StatusOK {
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					return nil, fmt.Errorf("failed to read response body: %w", err)
-				}
-				return mcp.NewToolResultError(fmt.Sprintf("failed to list alerts: %s", string(body))), nil
+				return responseErrorResult(resp, "failed to list organization alerts"), nil
+			}
+
+			structured := listResult(cleanSecretScanningAlerts(alerts), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderSecretScanningAlerts(alerts)), next), structured), nil
 			}
 
-			r, err := json.Marshal(alerts)
+			return projectedListResult(request, compactOr(ctx, alerts, cleanSecretScanningAlerts), structured, next), nil
+		}
+}
+
+// ListEnterpriseSecretScanningAlerts creates a tool to list secret scanning alerts across all organizations in an enterprise.
+func ListEnterpriseSecretScanningAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool(
+			"list_enterprise_secret_scanning_alerts",
+			mcp.WithDescription(t("TOOL_LIST_ENTERPRISE_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts across all repositories in a GitHub enterprise. Requires enterprise admin or security manager access.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ENTERPRISE_SECRET_SCANNING_ALERTS_USER_TITLE", "List enterprise secret scanning alerts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("enterprise",
+				mcp.Required(),
+				mcp.Description("The slug of the enterprise."),
+			),
+			withSecretScanningAlertFilters(),
+			WithPagination(),
+			WithFields(),
+			WithOutputFormatParam(),
+			WithOutputSchema[ListResult[*CleanedSecretScanningAlert]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			enterprise, err := requiredParam[string](request, "enterprise")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			opts, pagination, err := secretScanningAlertListOptions(request)
 			if err != nil {
-				return nil, fmt.Errorf("failed to marshal alerts: %w", err)
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			return mcp.NewToolResultText(string(r)), nil
+			markdown, err := markdownOutput(ctx, request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			alerts, resp, next, err := collectPages(ctx, pagination, func(listOpts github.ListOptions) ([]*github.SecretScanningAlert, *github.Response, error) {
+				opts.ListOptions = listOpts
+				return client.SecretScanning.ListAlertsForEnterprise(ctx, enterprise, opts)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list enterprise alerts: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list enterprise alerts"), nil
+			}
+
+			structured := listResult(cleanSecretScanningAlerts(alerts), next)
+			if markdown {
+				return structuredResult(withNextCursor(mcp.NewToolResultText(renderSecretScanningAlerts(alerts)), next), structured), nil
+			}
+
+			return projectedListResult(request, compactOr(ctx, alerts, cleanSecretScanningAlerts), structured, next), nil
 		}
 }
+
+// withSecretScanningAlertFilters adds the filter parameters shared by the organization and
+// enterprise secret scanning alert tools.
+func withSecretScanningAlertFilters() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("state",
+			mcp.Description("Filter by state"),
+			mcp.Enum("open", "resolved"),
+		)(tool)
+		mcp.WithString("secret_type",
+			mcp.Description("A comma-separated list of secret types to return. All default secret patterns are returned. To return generic patterns, pass the token name(s) in the parameter."),
+		)(tool)
+		mcp.WithString("resolution",
+			mcp.Description("Filter by resolution"),
+			mcp.Enum("false_positive", "wont_fix", "revoked", "pattern_edited", "pattern_deleted", "used_in_tests"),
+		)(tool)
+		mcp.WithString("validity",
+			mcp.Description("Filter by validity of the detected secret"),
+			mcp.Enum("active", "inactive", "unknown"),
+		)(tool)
+	}
+}
+
+// secretScanningAlertListOptions reads the parameters added by withSecretScanningAlertFilters and WithPagination.
+func secretScanningAlertListOptions(request mcp.CallToolRequest) (*github.SecretScanningAlertListOptions, PaginationParams, error) {
+	state, err := OptionalParam[string](request, "state")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	secretType, err := OptionalParam[string](request, "secret_type")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	resolution, err := OptionalParam[string](request, "resolution")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	validity, err := OptionalParam[string](request, "validity")
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+	pagination, err := OptionalPaginationParams(request)
+	if err != nil {
+		return nil, PaginationParams{}, err
+	}
+
+	return &github.SecretScanningAlertListOptions{
+		State:      state,
+		SecretType: secretType,
+		Resolution: resolution,
+		Validity:   validity,
+	}, pagination, nil
+}
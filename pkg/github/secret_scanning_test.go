@@ -156,7 +156,9 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 				mock.WithRequestMatchHandler(
 					mock.GetReposSecretScanningAlertsByOwnerByRepo,
 					expectQueryParams(t, map[string]string{
-						"state": "resolved",
+						"state":    "resolved",
+						"page":     "1",
+						"per_page": "30",
 					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{&resolvedAlert}),
 					),
@@ -175,7 +177,10 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 			mockedClient: mock.NewMockedHTTPClient(
 				mock.WithRequestMatchHandler(
 					mock.GetReposSecretScanningAlertsByOwnerByRepo,
-					expectQueryParams(t, map[string]string{}).andThen(
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "30",
+					}).andThen(
 						mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{&resolvedAlert, &openAlert}),
 					),
 				),
@@ -241,3 +246,226 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_ListOrgSecretScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListOrgSecretScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_org_secret_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "secret_type")
+	assert.Contains(t, tool.InputSchema.Properties, "resolution")
+	assert.Contains(t, tool.InputSchema.Properties, "validity")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	mockAlerts := []*github.SecretScanningAlert{
+		{
+			Number:     github.Ptr(2),
+			State:      github.Ptr("open"),
+			SecretType: github.Ptr("github_personal_access_token"),
+			Repository: &github.Repository{FullName: github.Ptr("org/repo")},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedAlerts []*github.SecretScanningAlert
+		expectedErrMsg string
+	}{
+		{
+			name: "successful organization alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					expect(t, expectations{
+						path: "/orgs/org/secret-scanning/alerts",
+						queryParams: map[string]string{
+							"state":    "open",
+							"validity": "active",
+							"page":     "1",
+							"per_page": "30",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"state":    "open",
+				"validity": "active",
+			},
+			expectError:    false,
+			expectedAlerts: mockAlerts,
+		},
+		{
+			name: "organization alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnauthorized)
+						_, _ = w.Write([]byte(`{"message": "Unauthorized access"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListOrgSecretScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedAlerts []*github.SecretScanningAlert
+			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
+			assert.NoError(t, err)
+			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
+			for i, alert := range returnedAlerts {
+				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
+				assert.Equal(t, *tc.expectedAlerts[i].SecretType, *alert.SecretType)
+			}
+		})
+	}
+}
+
+func Test_ListEnterpriseSecretScanningAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListEnterpriseSecretScanningAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_enterprise_secret_scanning_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "enterprise")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"enterprise"})
+
+	mockAlerts := []*github.SecretScanningAlert{
+		{
+			Number:     github.Ptr(9),
+			State:      github.Ptr("resolved"),
+			Resolution: github.Ptr("revoked"),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedAlerts []*github.SecretScanningAlert
+		expectedErrMsg string
+	}{
+		{
+			name: "successful enterprise alerts listing",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetEnterprisesSecretScanningAlertsByEnterprise,
+					expect(t, expectations{
+						path: "/enterprises/acme/secret-scanning/alerts",
+						queryParams: map[string]string{
+							"state":      "resolved",
+							"resolution": "revoked",
+							"page":       "1",
+							"per_page":   "30",
+						},
+					}).andThen(
+						mockResponse(t, http.StatusOK, mockAlerts),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+				"state":      "resolved",
+				"resolution": "revoked",
+			},
+			expectError:    false,
+			expectedAlerts: mockAlerts,
+		},
+		{
+			name: "enterprise alerts listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetEnterprisesSecretScanningAlertsByEnterprise,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"enterprise": "acme",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list enterprise alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListEnterpriseSecretScanningAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			// Unmarshal and verify the result
+			var returnedAlerts []*github.SecretScanningAlert
+			err = json.Unmarshal([]byte(textContent.Text), &returnedAlerts)
+			assert.NoError(t, err)
+			assert.Len(t, returnedAlerts, len(tc.expectedAlerts))
+			for i, alert := range returnedAlerts {
+				assert.Equal(t, *tc.expectedAlerts[i].Number, *alert.Number)
+				assert.Equal(t, *tc.expectedAlerts[i].Resolution, *alert.Resolution)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SecurityAlertSummary is the result of summarizing the security alerts of an organization.
+type SecurityAlertSummary struct {
+	Org   string `json:"org"`
+	State string `json:"state,omitempty"`
+	Total int    `json:"total"`
+	// BySeverity counts code scanning alerts by severity. Secret scanning alerts have no severity
+	// and are counted under the "secret" key.
+	BySeverity   map[string]int            `json:"by_severity"`
+	Repositories []*RepositoryAlertSummary `json:"repositories"`
+	// Truncated is set when the page limit was reached before all alerts were fetched,
+	// in which case the counts are a lower bound.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// RepositoryAlertSummary holds the alert counts for a single repository.
+type RepositoryAlertSummary struct {
+	Repository string         `json:"repository"`
+	Total      int            `json:"total"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// secretScanningSeverity is the severity bucket used for secret scanning alerts, which have no severity of their own.
+const secretScanningSeverity = "secret"
+
+// codeScanningAlertSeverity returns the security severity of an alert when the rule has one (critical, high, medium, low),
+// falling back to the rule severity (error, warning, note) for non-security rules.
+func codeScanningAlertSeverity(alert *github.Alert) string {
+	if level := alert.GetRule().GetSecuritySeverityLevel(); level != "" {
+		return level
+	}
+	if severity := alert.GetRule().GetSeverity(); severity != "" {
+		return severity
+	}
+	return "unknown"
+}
+
+type securityAlertCounter struct {
+	summary *SecurityAlertSummary
+	repos   map[string]*RepositoryAlertSummary
+}
+
+func newSecurityAlertCounter(org, state string) *securityAlertCounter {
+	return &securityAlertCounter{
+		summary: &SecurityAlertSummary{
+			Org:        org,
+			State:      state,
+			BySeverity: map[string]int{},
+		},
+		repos: map[string]*RepositoryAlertSummary{},
+	}
+}
+
+func (c *securityAlertCounter) add(repo *github.Repository, severity string) {
+	name := repo.GetFullName()
+	if name == "" {
+		name = repo.GetName()
+	}
+
+	r, ok := c.repos[name]
+	if !ok {
+		r = &RepositoryAlertSummary{Repository: name, BySeverity: map[string]int{}}
+		c.repos[name] = r
+	}
+	r.Total++
+	r.BySeverity[severity]++
+	c.summary.Total++
+	c.summary.BySeverity[severity]++
+}
+
+// result returns the summary with repositories ordered by the number of alerts, most first.
+func (c *securityAlertCounter) result() *SecurityAlertSummary {
+	c.summary.Repositories = make([]*RepositoryAlertSummary, 0, len(c.repos))
+	for _, r := range c.repos {
+		c.summary.Repositories = append(c.summary.Repositories, r)
+	}
+	sort.Slice(c.summary.Repositories, func(i, j int) bool {
+		a, b := c.summary.Repositories[i], c.summary.Repositories[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Repository < b.Repository
+	})
+	return c.summary
+}
+
+// SummarizeOrgSecurityAlerts creates a tool to count the code scanning and secret scanning alerts of an
+// organization, grouped by repository and severity.
+func SummarizeOrgSecurityAlerts(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("summarize_org_security_alerts",
+			mcp.WithDescription(t("TOOL_SUMMARIZE_ORG_SECURITY_ALERTS_DESCRIPTION", "Count the code scanning and secret scanning alerts of a GitHub organization, grouped by repository and severity. Use this to answer questions such as which repositories have the most critical open alerts, then use the list tools to fetch the alerts themselves.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_SUMMARIZE_ORG_SECURITY_ALERTS_USER_TITLE", "Summarize organization security alerts"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("org",
+				mcp.Required(),
+				mcp.Description("The name of the organization."),
+			),
+			mcp.WithString("state",
+				mcp.Description("Only count alerts in this state. Defaults to open"),
+				mcp.DefaultString("open"),
+				mcp.Enum("open", "closed", "dismissed", "fixed", "resolved"),
+			),
+			mcp.WithString("severity",
+				mcp.Description("Only count code scanning alerts with this severity. Secret scanning alerts are excluded when set"),
+				mcp.Enum("critical", "high", "medium", "low", "warning", "note", "error"),
+			),
+			mcp.WithArray("alertTypes",
+				mcp.Description("The kinds of alerts to count. Defaults to all"),
+				mcp.Items(
+					map[string]any{
+						"type": "string",
+						"enum": []string{"code_scanning", "secret_scanning"},
+					},
+				),
+			),
+			mcp.WithNumber("maxPages",
+				mcp.Description("Maximum number of pages of 100 alerts to fetch per alert type (default 10, max 50)"),
+				mcp.Min(1),
+				mcp.Max(50),
+			),
+			WithOutputSchema[SecurityAlertSummary](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			org, err := requiredParam[string](request, "org")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			state, err := OptionalParam[string](request, "state")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if state == "" {
+				state = "open"
+			}
+			severity, err := OptionalParam[string](request, "severity")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			alertTypes, err := OptionalStringArrayParam(request, "alertTypes")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxPages, err := OptionalIntParamWithDefault(request, "maxPages", 10)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if maxPages > 50 {
+				maxPages = 50
+			}
+
+			includeCodeScanning, includeSecretScanning := len(alertTypes) == 0, len(alertTypes) == 0
+			for _, alertType := range alertTypes {
+				switch alertType {
+				case "code_scanning":
+					includeCodeScanning = true
+				case "secret_scanning":
+					includeSecretScanning = true
+				default:
+					return mcp.NewToolResultError(fmt.Sprintf("unknown alert type: %s", alertType)), nil
+				}
+			}
+
+			// Secret scanning alerts are only ever open or resolved, and have no severity.
+			if severity != "" || (state != "open" && state != "resolved") {
+				includeSecretScanning = false
+			}
+			// Likewise, "resolved" only applies to secret scanning.
+			if state == "resolved" {
+				includeCodeScanning = false
+			}
+			if !includeCodeScanning && !includeSecretScanning {
+				return mcp.NewToolResultError(fmt.Sprintf("no alert types can be counted with state %q and severity %q", state, severity)), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			counter := newSecurityAlertCounter(org, state)
+
+			if includeCodeScanning {
+				opts := &github.AlertListOptions{
+					State:       state,
+					Severity:    severity,
+					ListOptions: github.ListOptions{PerPage: 100},
+				}
+				for page := 1; ; page++ {
+					alerts, resp, err := client.CodeScanning.ListAlertsForOrg(ctx, org, opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list organization code scanning alerts: %w", err)
+					}
+					if resp.StatusCode != http.StatusOK {
+						result := responseErrorResult(resp, "failed to list organization code scanning alerts")
+						_ = resp.Body.Close()
+						return result, nil
+					}
+					_ = resp.Body.Close()
+
+					for _, alert := range alerts {
+						counter.add(alert.GetRepository(), codeScanningAlertSeverity(alert))
+					}
+
+					if !nextAlertPage(resp, &opts.ListOptions, &opts.ListCursorOptions) {
+						break
+					}
+					if page == maxPages {
+						counter.summary.Truncated = true
+						break
+					}
+				}
+			}
+
+			if includeSecretScanning {
+				opts := &github.SecretScanningAlertListOptions{
+					State:       state,
+					ListOptions: github.ListOptions{PerPage: 100},
+				}
+				for page := 1; ; page++ {
+					alerts, resp, err := client.SecretScanning.ListAlertsForOrg(ctx, org, opts)
+					if err != nil {
+						return nil, fmt.Errorf("failed to list organization secret scanning alerts: %w", err)
+					}
+					if resp.StatusCode != http.StatusOK {
+						result := responseErrorResult(resp, "failed to list organization secret scanning alerts")
+						_ = resp.Body.Close()
+						return result, nil
+					}
+					_ = resp.Body.Close()
+
+					for _, alert := range alerts {
+						counter.add(alert.GetRepository(), secretScanningSeverity)
+					}
+
+					if !nextAlertPage(resp, &opts.ListOptions, &opts.ListCursorOptions) {
+						break
+					}
+					if page == maxPages {
+						counter.summary.Truncated = true
+						break
+					}
+				}
+			}
+
+			r, err := json.Marshal(counter.result())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal alert summary: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), counter.result()), nil
+		}
+}
+
+// nextAlertPage advances the list options to the next page of alerts, returning false when there are no more.
+// Organization alert endpoints paginate either by page number or by cursor, depending on the GitHub host.
+func nextAlertPage(resp *github.Response, listOpts *github.ListOptions, cursorOpts *github.ListCursorOptions) bool {
+	switch {
+	case resp.NextPage != 0:
+		listOpts.Page = resp.NextPage
+		return true
+	case resp.After != "":
+		listOpts.Page = 0
+		cursorOpts.After = resp.After
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SummarizeOrgSecurityAlerts(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := SummarizeOrgSecurityAlerts(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "summarize_org_security_alerts", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "org")
+	assert.Contains(t, tool.InputSchema.Properties, "state")
+	assert.Contains(t, tool.InputSchema.Properties, "severity")
+	assert.Contains(t, tool.InputSchema.Properties, "alertTypes")
+	assert.Contains(t, tool.InputSchema.Properties, "maxPages")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"org"})
+
+	repoA := &github.Repository{FullName: github.Ptr("org/repo-a")}
+	repoB := &github.Repository{FullName: github.Ptr("org/repo-b")}
+
+	codeScanningPage1 := []*github.Alert{
+		{Number: github.Ptr(1), Repository: repoA, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("critical")}},
+		{Number: github.Ptr(2), Repository: repoA, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("high")}},
+	}
+	codeScanningPage2 := []*github.Alert{
+		{Number: github.Ptr(3), Repository: repoB, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("critical")}},
+		{Number: github.Ptr(4), Repository: repoA, Rule: &github.Rule{Severity: github.Ptr("warning")}},
+	}
+	criticalPage1 := []*github.Alert{
+		{Number: github.Ptr(1), Repository: repoA, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("critical")}},
+	}
+	criticalPage2 := []*github.Alert{
+		{Number: github.Ptr(3), Repository: repoB, Rule: &github.Rule{SecuritySeverityLevel: github.Ptr("critical")}},
+	}
+	secretScanningAlerts := []*github.SecretScanningAlert{
+		{Number: github.Ptr(5), Repository: repoB},
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedSummary *SecurityAlertSummary
+		expectedErrMsg  string
+	}{
+		{
+			name: "counts code and secret scanning alerts across pages",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					codeScanningPage1,
+					codeScanningPage2,
+				),
+				mock.WithRequestMatch(
+					mock.GetOrgsSecretScanningAlertsByOrg,
+					secretScanningAlerts,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org": "org",
+			},
+			expectedSummary: &SecurityAlertSummary{
+				Org:   "org",
+				State: "open",
+				Total: 5,
+				BySeverity: map[string]int{
+					"critical": 2,
+					"high":     1,
+					"warning":  1,
+					"secret":   1,
+				},
+				Repositories: []*RepositoryAlertSummary{
+					{Repository: "org/repo-a", Total: 3, BySeverity: map[string]int{"critical": 1, "high": 1, "warning": 1}},
+					{Repository: "org/repo-b", Total: 2, BySeverity: map[string]int{"critical": 1, "secret": 1}},
+				},
+			},
+		},
+		{
+			name: "severity filter skips secret scanning and marks truncation",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchPages(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					criticalPage1,
+					criticalPage2,
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":      "org",
+				"severity": "critical",
+				"maxPages": float64(1),
+			},
+			expectedSummary: &SecurityAlertSummary{
+				Org:   "org",
+				State: "open",
+				Total: 1,
+				BySeverity: map[string]int{
+					"critical": 1,
+				},
+				Repositories: []*RepositoryAlertSummary{
+					{Repository: "org/repo-a", Total: 1, BySeverity: map[string]int{"critical": 1}},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name:         "no alert types match the filters",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"org":        "org",
+				"state":      "fixed",
+				"alertTypes": []interface{}{"secret_scanning"},
+			},
+			expectToolError: true,
+			expectedErrMsg:  "no alert types can be counted",
+		},
+		{
+			name: "listing fails",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsCodeScanningAlertsByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"message": "Resource not accessible"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"org":        "org",
+				"alertTypes": []interface{}{"code_scanning"},
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list organization code scanning alerts",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := SummarizeOrgSecurityAlerts(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			// Parse the result and get the text content if no error
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			// Unmarshal and verify the result
+			var returnedSummary SecurityAlertSummary
+			err = json.Unmarshal([]byte(textContent.Text), &returnedSummary)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSummary, &returnedSummary)
+		})
+	}
+}
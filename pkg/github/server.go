@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/go-github/v72/github"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,6 +19,7 @@ func NewServer(version string, opts ...server.ServerOption) *server.MCPServer {
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
 		server.WithLogging(),
+		server.WithToolHandlerMiddleware(withToolErrors),
 	}
 	opts = append(defaultOpts, opts...)
 
@@ -174,8 +176,10 @@ func OptionalStringArrayParam(r mcp.CallToolRequest, p string) ([]string, error)
 	}
 }
 
-// WithPagination returns a ToolOption that adds "page" and "perPage" parameters to the tool.
+// WithPagination returns a ToolOption that adds "page", "perPage", "maxItems" and "cursor" parameters to the tool.
 // The "page" parameter is optional, min 1. The "perPage" parameter is optional, min 1, max 100.
+// The "maxItems" parameter is optional, min 1, max 1000, and collects items across pages.
+// The "cursor" parameter is optional, and continues from where a previous call stopped.
 func WithPagination() mcp.ToolOption {
 	return func(tool *mcp.Tool) {
 		mcp.WithNumber("page",
@@ -188,32 +192,156 @@ func WithPagination() mcp.ToolOption {
 			mcp.Min(1),
 			mcp.Max(100),
 		)(tool)
+
+		mcp.WithNumber("maxItems",
+			mcp.Description("Collect up to this many items across pages in a single call (min 1, max 1000), instead of returning a single page"),
+			mcp.Min(1),
+			mcp.Max(maxPaginatedItems),
+		)(tool)
+
+		mcp.WithString("cursor",
+			mcp.Description("Cursor returned by a previous call, to fetch the items following those it returned. Replaces page"),
+		)(tool)
+	}
+}
+
+// WithPagePagination adds only the "page" and "perPage" parameters, for tools paginating a list within a
+// single result rather than returning the list itself.
+func WithPagePagination() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber("page",
+			mcp.Description("Page number for pagination (min 1)"),
+			mcp.Min(1),
+		)(tool)
+
+		mcp.WithNumber("perPage",
+			mcp.Description("Results per page for pagination (min 1, max 100)"),
+			mcp.Min(1),
+			mcp.Max(100),
+		)(tool)
+	}
+}
+
+// WithCursorPagination adds the "perPage", "maxItems" and "cursor" parameters, for tools listing a GraphQL
+// connection, which can only continue from a cursor rather than from a page number.
+func WithCursorPagination() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithNumber("perPage",
+			mcp.Description("Results per page (min 1, max 100)"),
+			mcp.Min(1),
+			mcp.Max(100),
+		)(tool)
+
+		mcp.WithNumber("maxItems",
+			mcp.Description("Collect up to this many items across pages in a single call (min 1, max 1000), instead of returning a single page"),
+			mcp.Min(1),
+			mcp.Max(maxPaginatedItems),
+		)(tool)
+
+		mcp.WithString("cursor",
+			mcp.Description("Cursor returned by a previous call, to fetch the items following those it returned"),
+		)(tool)
 	}
 }
 
 type PaginationParams struct {
 	page    int
 	perPage int
+	// maxItems is the number of items to collect across pages, or zero to return a single page.
+	maxItems int
+	// skip is the number of items of the first page returned by a previous call.
+	skip int
+	// after is the GraphQL cursor to continue from.
+	after string
 }
 
-// OptionalPaginationParams returns the "page" and "perPage" parameters from the request,
-// or their default values if not present, "page" default is 1, "perPage" default is 30.
+// OptionalPaginationParams returns the "page", "perPage", "maxItems" and "cursor" parameters from the request,
+// or their default values if not present, "page" default is 1, "perPage" default is 30, or 100 when collecting
+// items across pages with "maxItems". A cursor replaces the "page" and "perPage" parameters, and carries the
+// GraphQL connection cursor of tools using WithCursorPagination.
 // In future, we may want to make the default values configurable, or even have this
 // function returned from `withPagination`, where the defaults are provided alongside
 // the min/max values.
 func OptionalPaginationParams(r mcp.CallToolRequest) (PaginationParams, error) {
+	maxItems, err := OptionalIntParam(r, "maxItems")
+	if err != nil {
+		return PaginationParams{}, err
+	}
+	if maxItems < 0 {
+		return PaginationParams{}, fmt.Errorf("maxItems must be at least 1")
+	}
+	maxItems = min(maxItems, maxPaginatedItems)
+
+	defaultPerPage := 30
+	if maxItems > 0 {
+		defaultPerPage = 100
+	}
 	page, err := OptionalIntParamWithDefault(r, "page", 1)
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	perPage, err := OptionalIntParamWithDefault(r, "perPage", 30)
+	perPage, err := OptionalIntParamWithDefault(r, "perPage", defaultPerPage)
 	if err != nil {
 		return PaginationParams{}, err
 	}
-	return PaginationParams{
-		page:    page,
-		perPage: perPage,
-	}, nil
+	pagination := PaginationParams{
+		page:     page,
+		perPage:  perPage,
+		maxItems: maxItems,
+	}
+
+	cursor, err := OptionalParam[string](r, "cursor")
+	if err != nil {
+		return PaginationParams{}, err
+	}
+	if cursor != "" {
+		if _, ok := r.GetArguments()["page"]; ok {
+			return PaginationParams{}, fmt.Errorf("only one of page and cursor can be set")
+		}
+		c, err := decodePageCursor(cursor)
+		if err != nil {
+			return PaginationParams{}, err
+		}
+		pagination.page, pagination.skip, pagination.after = max(c.Page, 1), c.Skip, c.After
+		if c.PerPage > 0 {
+			pagination.perPage = c.PerPage
+		}
+	}
+	return pagination, nil
+}
+
+// WithFields returns a ToolOption that adds an optional "fields" parameter to the tool, selecting
+// the fields of the result to return by their dot-paths.
+func WithFields() mcp.ToolOption {
+	return mcp.WithString("fields",
+		mcp.Description("Comma-separated dot-paths of the fields to return, e.g. 'number,title,user.login,labels.name'. Paths apply to each item of lists. All fields are returned when not set. Structured content always has all fields"),
+	)
+}
+
+// WithOutputFormatParam returns a ToolOption that adds an optional "outputFormat" parameter to the tool,
+// overriding the output format of the server for a call.
+func WithOutputFormatParam() mcp.ToolOption {
+	return mcp.WithString("outputFormat",
+		mcp.Description("Format of the result: 'json', or 'markdown' for concise tables and summaries to show to people. Defaults to the server's output format"),
+		mcp.Enum(string(OutputFormatJSON), string(OutputFormatMarkdown)),
+	)
+}
+
+// OptionalFieldsParam returns the dot-paths of the "fields" parameter from the request,
+// or nil if not present.
+func OptionalFieldsParam(r mcp.CallToolRequest) ([]string, error) {
+	fields, err := OptionalParam[string](r, "fields")
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, path := range strings.Split(fields, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
 }
 
 func MarshalledTextResult(v any) *mcp.CallToolResult {
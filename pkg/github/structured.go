@@ -0,0 +1,168 @@
+package github
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// outputSchemaDepth is how many levels of nested objects output schemas describe. Deeper objects are
+// only declared as objects, which keeps the schemas of the large, and often recursive, API types small.
+const outputSchemaDepth = 2
+
+var (
+	timestampType = reflect.TypeOf(github.Timestamp{})
+	timeType      = reflect.TypeOf(time.Time{})
+)
+
+// ListResult is the structured content of tools returning a list, as structured content must be an object.
+type ListResult[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor is the cursor to the following items, if any.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// listResult returns items as the structured content of a list, with the cursor to the following items.
+func listResult[T any](items []T, next string) ListResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return ListResult[T]{Items: items, NextCursor: next}
+}
+
+// SearchResult is the structured content of search tools.
+type SearchResult[T any] struct {
+	TotalCount        int  `json:"total_count"`
+	IncompleteResults bool `json:"incomplete_results"`
+	Items             []T  `json:"items"`
+	// NextCursor is the cursor to the following items, if any.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// searchResult returns the items found by a search as structured content, with the cursor to the following items.
+func searchResult[T any](totalCount int, incompleteResults bool, items []T, next string) SearchResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return SearchResult[T]{TotalCount: totalCount, IncompleteResults: incompleteResults, Items: items, NextCursor: next}
+}
+
+// ActionResult is the structured content of tools performing an action that returns no object, such as
+// deleting one.
+type ActionResult struct {
+	Message string `json:"message"`
+}
+
+// actionResult returns the result of an action, with message both as text and as structured content.
+func actionResult(message string) *mcp.CallToolResult {
+	return structuredResult(mcp.NewToolResultText(message), ActionResult{Message: message})
+}
+
+// structuredResult sets v as the structured content of result, alongside its text. The type of v is the
+// one declared by WithOutputSchema for the tool, which stays the same whatever the output mode, format or
+// selected fields of the text.
+func structuredResult(result *mcp.CallToolResult, v any) *mcp.CallToolResult {
+	result.StructuredContent = v
+	return result
+}
+
+// structuredTextResult returns v both as JSON text and as structured content.
+func structuredTextResult(v any) *mcp.CallToolResult {
+	result := MarshalledTextResult(v)
+	if result.IsError {
+		return result
+	}
+	return structuredResult(result, v)
+}
+
+// WithOutputSchema returns a ToolOption declaring the output schema of the tool, derived from T, the type
+// of its structured content. T must be a struct or a map, as structured content is a JSON object.
+func WithOutputSchema[T any]() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		schema, err := json.Marshal(outputSchema(reflect.TypeOf((*T)(nil)).Elem(), outputSchemaDepth, map[reflect.Type]bool{}))
+		if err != nil {
+			panic(err)
+		}
+		tool.RawOutputSchema = schema
+	}
+}
+
+// outputSchema returns the JSON schema of the JSON encoding of t, describing depth levels of nested objects.
+// Types already being described, from recursive types, are only declared as objects.
+func outputSchema(t reflect.Type, depth int, described map[reflect.Type]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timestampType || t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// Types encoding themselves, such as json.RawMessage, can be anything.
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Bytes are encoded as base64 strings.
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": outputSchema(t.Elem(), depth, described)}
+	case reflect.Map:
+		if depth == 0 {
+			return map[string]any{"type": "object"}
+		}
+		return map[string]any{"type": "object", "additionalProperties": outputSchema(t.Elem(), depth-1, described)}
+	case reflect.Struct:
+		if depth == 0 || described[t] {
+			return map[string]any{"type": "object"}
+		}
+		described[t] = true
+		defer delete(described, t)
+
+		properties := make(map[string]any)
+		var required []string
+		for _, f := range structJSONFields(t) {
+			property := outputSchema(f.typ, depth-1, described)
+			if !f.omitEmpty {
+				required = append(required, f.name)
+				if nullable(f.typ) {
+					if typ, ok := property["type"].(string); ok {
+						property["type"] = []string{typ, "null"}
+					}
+				}
+			}
+			properties[f.name] = property
+		}
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		// Interfaces can hold anything.
+		return map[string]any{}
+	}
+}
+
+// nullable reports whether the zero value of t is encoded as null.
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ToolOutputSchemas(t *testing.T) {
+	tsg := DefaultToolsetGroup(false, stubGetClientFn(nil), stubGetGQLClientFn(nil), translations.NullTranslationHelper)
+
+	var names []string
+	for name := range tsg.Toolsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, serverTool := range tsg.Toolsets[name].GetAvailableTools() {
+			tool := serverTool.Tool
+			t.Run(tool.Name, func(t *testing.T) {
+				require.NotEmpty(t, tool.RawOutputSchema, "tool %s declares no output schema", tool.Name)
+				var schema map[string]any
+				require.NoError(t, json.Unmarshal(tool.RawOutputSchema, &schema))
+				assert.Equal(t, "object", schema["type"])
+
+				require.NoError(t, toolsnaps.Test(tool.Name, tool))
+			})
+		}
+	}
+}
+
+type testRecursiveNode struct {
+	Name     *string              `json:"name,omitempty"`
+	Children []*testRecursiveNode `json:"children"`
+}
+
+type testSchemaEmbedded struct {
+	ID    int    `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+type testSchemaResult struct {
+	testSchemaEmbedded
+	Title     string             `json:"title"`
+	CreatedAt *github.Timestamp  `json:"created_at,omitempty"`
+	Labels    []string           `json:"labels"`
+	Counts    map[string]int     `json:"counts,omitempty"`
+	Raw       json.RawMessage    `json:"raw,omitempty"`
+	Node      *testRecursiveNode `json:"node,omitempty"`
+	Ignored   string             `json:"-"`
+}
+
+func Test_OutputSchema(t *testing.T) {
+	tool := mcp.NewTool("test_tool", WithOutputSchema[testSchemaResult]())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(tool.RawOutputSchema, &schema))
+	expected := `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"title": {"type": "string"},
+			"created_at": {"type": "string", "format": "date-time"},
+			"labels": {"type": ["array", "null"], "items": {"type": "string"}},
+			"counts": {"type": "object", "additionalProperties": {"type": "integer"}},
+			"raw": {},
+			"node": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"children": {"type": ["array", "null"], "items": {"type": "object"}}
+				},
+				"required": ["children"]
+			}
+		},
+		"required": ["title", "labels", "id"]
+	}`
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(data))
+}
+
+func Test_StructuredContent(t *testing.T) {
+	mockIssue := &github.Issue{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Test issue"),
+		State:   github.Ptr("open"),
+		User:    &github.User{Login: github.Ptr("octocat")},
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+	}
+	client := github.NewClient(mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepoByIssueNumber, mockIssue, mockIssue),
+		mock.WithRequestMatch(mock.GetReposLabelsByOwnerByRepo, []*github.Label{{Name: github.Ptr("bug")}}),
+		mock.WithRequestMatchHandler(mock.DeleteReposLabelsByOwnerByRepoByName, mockResponse(t, 204, nil)),
+	))
+
+	tests := []struct {
+		name     string
+		tool     func(GetClientFn, translations.TranslationHelperFunc) (mcp.Tool, server.ToolHandlerFunc)
+		args     map[string]any
+		expected string
+	}{
+		{
+			name:     "object",
+			tool:     GetIssue,
+			args:     map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42), "fields": "number"},
+			expected: `{"number":42,"title":"Test issue","state":"open","user":{"login":"octocat"},"html_url":"https://github.com/owner/repo/issues/42"}`,
+		},
+		{
+			name:     "object in markdown",
+			tool:     GetIssue,
+			args:     map[string]any{"owner": "owner", "repo": "repo", "issue_number": float64(42), "outputFormat": "markdown"},
+			expected: `{"number":42,"title":"Test issue","state":"open","user":{"login":"octocat"},"html_url":"https://github.com/owner/repo/issues/42"}`,
+		},
+		{
+			name:     "list",
+			tool:     ListLabels,
+			args:     map[string]any{"owner": "owner", "repo": "repo"},
+			expected: `{"items":[{"name":"bug"}]}`,
+		},
+		{
+			name:     "action",
+			tool:     DeleteLabel,
+			args:     map[string]any{"owner": "owner", "repo": "repo", "name": "bug"},
+			expected: `{"message":"label bug deleted from owner/repo"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, handler := tc.tool(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			result, err := handler(context.Background(), createMCPRequest(tc.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			require.NotNil(t, result.StructuredContent)
+
+			data, err := json.Marshal(result.StructuredContent)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(data))
+
+			var schema, structured map[string]any
+			require.NoError(t, json.Unmarshal(tool.RawOutputSchema, &schema))
+			require.NoError(t, json.Unmarshal(data, &structured))
+			assert.NoError(t, conformsToSchema(schema, structured, "$"))
+		})
+	}
+}
+
+// conformsToSchema checks v against the subset of JSON schema that output schemas use.
+func conformsToSchema(schema map[string]any, v any, path string) error {
+	var types []string
+	switch typ := schema["type"].(type) {
+	case string:
+		types = []string{typ}
+	case []any:
+		for _, t := range typ {
+			types = append(types, t.(string))
+		}
+	default:
+		return nil
+	}
+
+	matches := func(typ string) bool {
+		switch typ {
+		case "null":
+			return v == nil
+		case "object":
+			_, ok := v.(map[string]any)
+			return ok
+		case "array":
+			_, ok := v.([]any)
+			return ok
+		case "string":
+			_, ok := v.(string)
+			return ok
+		case "boolean":
+			_, ok := v.(bool)
+			return ok
+		case "integer":
+			n, ok := v.(float64)
+			return ok && n == float64(int64(n))
+		case "number":
+			_, ok := v.(float64)
+			return ok
+		}
+		return false
+	}
+	matched := false
+	for _, typ := range types {
+		matched = matched || matches(typ)
+	}
+	if !matched {
+		return fmt.Errorf("%s: %v is not of type %v", path, v, types)
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := v[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, name)
+			}
+		}
+		for name, field := range v {
+			if properties == nil {
+				continue
+			}
+			property, ok := properties[name].(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s: unexpected property %s", path, name)
+			}
+			if err := conformsToSchema(property, field, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range v {
+			if err := conformsToSchema(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
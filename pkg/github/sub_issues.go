@@ -0,0 +1,455 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// SubIssuesSummary is the completion progress of the sub-issues of an issue.
+type SubIssuesSummary struct {
+	Total            int `json:"total"`
+	Completed        int `json:"completed"`
+	PercentCompleted int `json:"percent_completed"`
+}
+
+// SubIssuesResult is a page of the sub-issues of an issue, along with the progress across all of them.
+type SubIssuesResult struct {
+	Summary   *SubIssuesSummary `json:"summary,omitempty"`
+	SubIssues []*github.Issue   `json:"sub_issues"`
+	NextPage  int               `json:"next_page,omitempty"`
+	// NextCursor is the cursor to the following sub-issues, if any.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// go-github does not wrap the sub-issue endpoints yet, so the requests below are built by hand.
+
+type subIssueRequest struct {
+	SubIssueID    int64  `json:"sub_issue_id"`
+	ReplaceParent *bool  `json:"replace_parent,omitempty"`
+	AfterID       *int64 `json:"after_id,omitempty"`
+	BeforeID      *int64 `json:"before_id,omitempty"`
+}
+
+// addSubIssue makes the issue with the given ID a sub-issue of the parent issue, returning the parent.
+func addSubIssue(ctx context.Context, client *github.Client, owner, repo string, parentNumber int, subIssueID int64, replaceParent bool) (*github.Issue, *github.Response, error) {
+	body := &subIssueRequest{SubIssueID: subIssueID}
+	if replaceParent {
+		body.ReplaceParent = github.Ptr(true)
+	}
+
+	u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues", owner, repo, parentNumber)
+	req, err := client.NewRequest(http.MethodPost, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parent := new(github.Issue)
+	resp, err := client.Do(ctx, req, parent)
+	if err != nil {
+		return nil, resp, err
+	}
+	return parent, resp, nil
+}
+
+// getIssueID resolves an issue number to the issue ID expected by the sub-issue endpoints.
+func getIssueID(ctx context.Context, client *github.Client, owner, repo string, number int) (int64, error) {
+	issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return issue.GetID(), nil
+}
+
+// withSubIssueParams adds the parameters identifying a parent issue and one of its sub-issues.
+func withSubIssueParams() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("owner",
+			mcp.Required(),
+			mcp.Description("Repository owner"),
+		)(tool)
+		mcp.WithString("repo",
+			mcp.Required(),
+			mcp.Description("Repository name"),
+		)(tool)
+		mcp.WithNumber("issue_number",
+			mcp.Required(),
+			mcp.Description("The number of the parent issue"),
+		)(tool)
+		mcp.WithNumber("sub_issue_number",
+			mcp.Required(),
+			mcp.Description("The number of the sub-issue, in the same repository"),
+		)(tool)
+	}
+}
+
+// ListSubIssues creates a tool to list the sub-issues of an issue.
+func ListSubIssues(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_sub_issues",
+			mcp.WithDescription(t("TOOL_LIST_SUB_ISSUES_DESCRIPTION", "List the sub-issues of an issue in a GitHub repository in priority order, along with how many of them are completed.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_SUB_ISSUES_USER_TITLE", "List sub-issues"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("Repository owner"),
+			),
+			mcp.WithString("repo",
+				mcp.Required(),
+				mcp.Description("Repository name"),
+			),
+			mcp.WithNumber("issue_number",
+				mcp.Required(),
+				mcp.Description("The number of the parent issue"),
+			),
+			WithPagination(),
+			WithFields(),
+			WithOutputSchema[SubIssuesResult](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			pagination, err := OptionalPaginationParams(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			subIssues, resp, next, err := collectPages(ctx, pagination, func(opts github.ListOptions) ([]*github.Issue, *github.Response, error) {
+				u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues?page=%d&per_page=%d", owner, repo, issueNumber, opts.Page, opts.PerPage)
+				req, err := client.NewRequest(http.MethodGet, u, nil)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to create request: %w", err)
+				}
+
+				var subIssues []*github.Issue
+				resp, err := client.Do(ctx, req, &subIssues)
+				return subIssues, resp, err
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list sub-issues: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list sub-issues"), nil
+			}
+
+			// The progress across all sub-issues, not just this page, is only available on the parent issue.
+			req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, issueNumber), nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			var parent struct {
+				SubIssuesSummary *SubIssuesSummary `json:"sub_issues_summary"`
+			}
+			parentResp, err := client.Do(ctx, req, &parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent issue: %w", err)
+			}
+			defer func() { _ = parentResp.Body.Close() }()
+
+			result := SubIssuesResult{
+				Summary:    parent.SubIssuesSummary,
+				SubIssues:  subIssues,
+				NextPage:   nextPageOf(next),
+				NextCursor: next,
+			}
+			if result.SubIssues == nil {
+				result.SubIssues = []*github.Issue{}
+			}
+
+			return projectedTextResult(request, result), nil
+		}
+}
+
+// AddSubIssue creates a tool to add an existing issue as a sub-issue of another.
+func AddSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("add_sub_issue",
+			mcp.WithDescription(t("TOOL_ADD_SUB_ISSUE_DESCRIPTION", "Add an existing issue as a sub-issue of another issue in a GitHub repository. The sub-issue is added last in priority order.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_ADD_SUB_ISSUE_USER_TITLE", "Add sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withSubIssueParams(),
+			mcp.WithBoolean("replace_parent",
+				mcp.Description("Move the sub-issue from its current parent, if it already has one"),
+			),
+			WithOutputSchema[CleanedIssue](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueNumber, err := RequiredInt(request, "sub_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			replaceParent, err := OptionalParam[bool](request, "replace_parent")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			subIssueID, err := getIssueID(ctx, client, owner, repo, subIssueNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			parent, resp, err := addSubIssue(ctx, client, owner, repo, issueNumber, subIssueID, replaceParent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to add sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusCreated {
+				return responseErrorResult(resp, "failed to add sub-issue"), nil
+			}
+
+			r, err := json.Marshal(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanIssue(parent)), nil
+		}
+}
+
+// RemoveSubIssue creates a tool to remove a sub-issue from its parent.
+func RemoveSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("remove_sub_issue",
+			mcp.WithDescription(t("TOOL_REMOVE_SUB_ISSUE_DESCRIPTION", "Remove a sub-issue from its parent issue in a GitHub repository. The sub-issue itself is not closed or deleted.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_REMOVE_SUB_ISSUE_USER_TITLE", "Remove sub-issue"),
+				ReadOnlyHint: toBoolPtr(false),
+			}),
+			withSubIssueParams(),
+			WithOutputSchema[CleanedIssue](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueNumber, err := RequiredInt(request, "sub_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			subIssueID, err := getIssueID(ctx, client, owner, repo, subIssueNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issue", owner, repo, issueNumber)
+			req, err := client.NewRequest(http.MethodDelete, u, &subIssueRequest{SubIssueID: subIssueID})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			parent := new(github.Issue)
+			resp, err := client.Do(ctx, req, parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to remove sub-issue"), nil
+			}
+
+			r, err := json.Marshal(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanIssue(parent)), nil
+		}
+}
+
+// ReprioritizeSubIssue creates a tool to move a sub-issue within the priority order of its parent.
+func ReprioritizeSubIssue(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("reprioritize_sub_issue",
+			mcp.WithDescription(t("TOOL_REPRIORITIZE_SUB_ISSUE_DESCRIPTION", "Move a sub-issue within the priority order of its parent issue, placing it directly after or before another sub-issue. Exactly one of after_number or before_number must be given.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:          t("TOOL_REPRIORITIZE_SUB_ISSUE_USER_TITLE", "Reprioritize sub-issue"),
+				ReadOnlyHint:   toBoolPtr(false),
+				IdempotentHint: toBoolPtr(true),
+			}),
+			withSubIssueParams(),
+			mcp.WithNumber("after_number",
+				mcp.Description("The number of the sub-issue to place it after"),
+			),
+			mcp.WithNumber("before_number",
+				mcp.Description("The number of the sub-issue to place it before"),
+			),
+			WithOutputSchema[CleanedIssue](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			repo, err := requiredParam[string](request, "repo")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			issueNumber, err := RequiredInt(request, "issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			subIssueNumber, err := RequiredInt(request, "sub_issue_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			afterNumber, err := OptionalIntParam(request, "after_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			beforeNumber, err := OptionalIntParam(request, "before_number")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if (afterNumber == 0) == (beforeNumber == 0) {
+				return mcp.NewToolResultError("exactly one of after_number or before_number must be provided"), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			subIssueID, err := getIssueID(ctx, client, owner, repo, subIssueNumber)
+			if err != nil {
+				return nil, err
+			}
+
+			body := &subIssueRequest{SubIssueID: subIssueID}
+			if afterNumber != 0 {
+				afterID, err := getIssueID(ctx, client, owner, repo, afterNumber)
+				if err != nil {
+					return nil, err
+				}
+				body.AfterID = &afterID
+			} else {
+				beforeID, err := getIssueID(ctx, client, owner, repo, beforeNumber)
+				if err != nil {
+					return nil, err
+				}
+				body.BeforeID = &beforeID
+			}
+
+			u := fmt.Sprintf("repos/%s/%s/issues/%d/sub_issues/priority", owner, repo, issueNumber)
+			req, err := client.NewRequest(http.MethodPatch, u, body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create request: %w", err)
+			}
+
+			parent := new(github.Issue)
+			resp, err := client.Do(ctx, req, parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to reprioritize sub-issue: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to reprioritize sub-issue"), nil
+			}
+
+			r, err := json.Marshal(parent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), cleanIssue(parent)), nil
+		}
+}
+
+// ListIssueTypes creates a tool to list the issue types of an organization.
+func ListIssueTypes(getClient GetClientFn, t translations.TranslationHelperFunc) (tool mcp.Tool, handler server.ToolHandlerFunc) {
+	return mcp.NewTool("list_issue_types",
+			mcp.WithDescription(t("TOOL_LIST_ISSUE_TYPES_DESCRIPTION", "List the issue types defined by a GitHub organization, such as Bug, Feature or Task. Use the returned names for the type parameter of create_issue and update_issue.")),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:        t("TOOL_LIST_ISSUE_TYPES_USER_TITLE", "List issue types"),
+				ReadOnlyHint: toBoolPtr(true),
+			}),
+			mcp.WithString("owner",
+				mcp.Required(),
+				mcp.Description("The organization that owns the repositories"),
+			),
+			WithOutputSchema[ListResult[*github.IssueType]](),
+		),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			owner, err := requiredParam[string](request, "owner")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			client, err := getClient(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			issueTypes, resp, err := client.Organizations.ListIssueTypes(ctx, owner)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issue types: %w", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				return responseErrorResult(resp, "failed to list issue types"), nil
+			}
+
+			r, err := json.Marshal(issueTypes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return structuredResult(mcp.NewToolResultText(string(r)), listResult(issueTypes, "")), nil
+		}
+}
@@ -0,0 +1,548 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v72/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssueIDs serves issue lookups by number, giving every issue the ID 1000 + number.
+func mockIssueIDs() mock.MockBackendOption {
+	return mock.WithRequestMatchHandler(
+		mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var number int
+			_, _ = fmt.Sscanf(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], "%d", &number)
+			w.WriteHeader(http.StatusOK)
+			b, _ := json.Marshal(&github.Issue{
+				ID:     github.Ptr(int64(1000 + number)),
+				Number: github.Ptr(number),
+			})
+			_, _ = w.Write(b)
+		}),
+	)
+}
+
+func Test_ListSubIssues(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListSubIssues(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_sub_issues", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.Contains(t, tool.InputSchema.Properties, "repo")
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "page")
+	assert.Contains(t, tool.InputSchema.Properties, "perPage")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number"})
+
+	mockSubIssues := []*github.Issue{
+		{Number: github.Ptr(11), Title: github.Ptr("Design"), State: github.Ptr("closed")},
+		{Number: github.Ptr(12), Title: github.Ptr("Implementation"), State: github.Ptr("open")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]interface{}
+		expectError    bool
+		expectedResult SubIssuesResult
+		expectedErrMsg string
+	}{
+		{
+			name: "sub-issues with progress",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectQueryParams(t, map[string]string{
+						"page":     "1",
+						"per_page": "2",
+					}).andThen(
+						func(w http.ResponseWriter, _ *http.Request) {
+							w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/issues/10/sub_issues?page=2&per_page=2>; rel="next"`)
+							w.WriteHeader(http.StatusOK)
+							b, _ := json.Marshal(mockSubIssues)
+							_, _ = w.Write(b)
+						},
+					),
+				),
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					expectPath(t, "/repos/owner/repo/issues/10").andThen(
+						mockResponse(t, http.StatusOK, map[string]any{
+							"number": 10,
+							"sub_issues_summary": map[string]any{
+								"total":             3,
+								"completed":         1,
+								"percent_completed": 33,
+							},
+						}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(10),
+				"perPage":      float64(2),
+			},
+			expectedResult: SubIssuesResult{
+				Summary:    &SubIssuesSummary{Total: 3, Completed: 1, PercentCompleted: 33},
+				SubIssues:  mockSubIssues,
+				NextPage:   2,
+				NextCursor: pageCursor{Page: 2, PerPage: 2}.String(),
+			},
+		},
+		{
+			name: "issue without sub-issues",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatch(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					[]*github.Issue{},
+				),
+				mock.WithRequestMatch(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					&github.Issue{Number: github.Ptr(10)},
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(10),
+			},
+			expectedResult: SubIssuesResult{
+				SubIssues: []*github.Issue{},
+			},
+		},
+		{
+			name: "issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to list sub-issues",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListSubIssues(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedResult SubIssuesResult
+			err = json.Unmarshal([]byte(textContent.Text), &returnedResult)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, returnedResult)
+		})
+	}
+}
+
+func Test_AddSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := AddSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "add_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "sub_issue_number")
+	assert.Contains(t, tool.InputSchema.Properties, "replace_parent")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	mockParent := &github.Issue{
+		Number: github.Ptr(10),
+		Title:  github.Ptr("Epic"),
+	}
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectError     bool
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "add sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockIssueIDs(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id": float64(1012),
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockParent),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+			},
+		},
+		{
+			name: "move sub-issue from another parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockIssueIDs(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id":   float64(1012),
+						"replace_parent": true,
+					}).andThen(
+						mockResponse(t, http.StatusCreated, mockParent),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+				"replace_parent":   true,
+			},
+		},
+		{
+			name: "sub-issue not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(999),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get issue #999",
+		},
+		{
+			name: "issue already has a parent",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockIssueIDs(),
+				mock.WithRequestMatchHandler(
+					mock.PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusUnprocessableEntity)
+						_, _ = w.Write([]byte(`{"message": "Validation Failed"}`))
+					}),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to add sub-issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := AddSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Create call request
+			request := createMCPRequest(tc.requestArgs)
+
+			// Call handler
+			result, err := handler(context.Background(), request)
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedParent github.Issue
+			err = json.Unmarshal([]byte(textContent.Text), &returnedParent)
+			require.NoError(t, err)
+			assert.Equal(t, *mockParent.Number, *returnedParent.Number)
+			assert.Equal(t, *mockParent.Title, *returnedParent.Title)
+		})
+	}
+}
+
+func Test_RemoveSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := RemoveSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "remove_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	mockedClient := mock.NewMockedHTTPClient(
+		mockIssueIDs(),
+		mock.WithRequestMatchHandler(
+			mock.DeleteReposIssuesSubIssueByOwnerByRepoByIssueNumber,
+			expectRequestBody(t, map[string]any{
+				"sub_issue_id": float64(1012),
+			}).andThen(
+				mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(10)}),
+			),
+		),
+	)
+
+	client := github.NewClient(mockedClient)
+	_, handler := RemoveSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+	result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+		"owner":            "owner",
+		"repo":             "repo",
+		"issue_number":     float64(10),
+		"sub_issue_number": float64(12),
+	}))
+	require.NoError(t, err)
+	textContent := getTextResult(t, result)
+
+	var returnedParent github.Issue
+	err = json.Unmarshal([]byte(textContent.Text), &returnedParent)
+	require.NoError(t, err)
+	assert.Equal(t, 10, returnedParent.GetNumber())
+}
+
+func Test_ReprioritizeSubIssue(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ReprioritizeSubIssue(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "reprioritize_sub_issue", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "after_number")
+	assert.Contains(t, tool.InputSchema.Properties, "before_number")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner", "repo", "issue_number", "sub_issue_number"})
+
+	tests := []struct {
+		name            string
+		mockedClient    *http.Client
+		requestArgs     map[string]interface{}
+		expectToolError bool
+		expectedErrMsg  string
+	}{
+		{
+			name: "place after another sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockIssueIDs(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id": float64(1012),
+						"after_id":     float64(1014),
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(10)}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+				"after_number":     float64(14),
+			},
+		},
+		{
+			name: "place before another sub-issue",
+			mockedClient: mock.NewMockedHTTPClient(
+				mockIssueIDs(),
+				mock.WithRequestMatchHandler(
+					mock.PatchReposIssuesSubIssuesPriorityByOwnerByRepoByIssueNumber,
+					expectRequestBody(t, map[string]any{
+						"sub_issue_id": float64(1012),
+						"before_id":    float64(1011),
+					}).andThen(
+						mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(10)}),
+					),
+				),
+			),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+				"before_number":    float64(11),
+			},
+		},
+		{
+			name:         "neither after nor before",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "exactly one of after_number or before_number must be provided",
+		},
+		{
+			name:         "both after and before",
+			mockedClient: mock.NewMockedHTTPClient(),
+			requestArgs: map[string]interface{}{
+				"owner":            "owner",
+				"repo":             "repo",
+				"issue_number":     float64(10),
+				"sub_issue_number": float64(12),
+				"after_number":     float64(14),
+				"before_number":    float64(11),
+			},
+			expectToolError: true,
+			expectedErrMsg:  "exactly one of after_number or before_number must be provided",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ReprioritizeSubIssue(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(tc.requestArgs))
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			if tc.expectToolError {
+				require.True(t, result.IsError)
+				assert.Contains(t, textContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returnedParent github.Issue
+			err = json.Unmarshal([]byte(textContent.Text), &returnedParent)
+			require.NoError(t, err)
+			assert.Equal(t, 10, returnedParent.GetNumber())
+		})
+	}
+}
+
+func Test_ListIssueTypes(t *testing.T) {
+	// Verify tool definition once
+	mockClient := github.NewClient(nil)
+	tool, _ := ListIssueTypes(stubGetClientFn(mockClient), translations.NullTranslationHelper)
+
+	assert.Equal(t, "list_issue_types", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "owner")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"owner"})
+
+	mockIssueTypes := []*github.IssueType{
+		{ID: github.Ptr(int64(1)), Name: github.Ptr("Bug"), Description: github.Ptr("An unexpected problem or behavior")},
+		{ID: github.Ptr(int64(2)), Name: github.Ptr("Task"), Description: github.Ptr("A specific piece of work")},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list issue types",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsIssueTypesByOrg,
+					expectPath(t, "/orgs/octo-org/issue-types").andThen(
+						mockResponse(t, http.StatusOK, mockIssueTypes),
+					),
+				),
+			),
+		},
+		{
+			name: "organization not found",
+			mockedClient: mock.NewMockedHTTPClient(
+				mock.WithRequestMatchHandler(
+					mock.GetOrgsIssueTypesByOrg,
+					http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					}),
+				),
+			),
+			expectError:    true,
+			expectedErrMsg: "failed to list issue types",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup client with mock
+			client := github.NewClient(tc.mockedClient)
+			_, handler := ListIssueTypes(stubGetClientFn(client), translations.NullTranslationHelper)
+
+			// Call handler
+			result, err := handler(context.Background(), createMCPRequest(map[string]interface{}{
+				"owner": "octo-org",
+			}))
+
+			// Verify results
+			if tc.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			textContent := getTextResult(t, result)
+
+			var returnedIssueTypes []*github.IssueType
+			err = json.Unmarshal([]byte(textContent.Text), &returnedIssueTypes)
+			require.NoError(t, err)
+			assert.Equal(t, mockIssueTypes, returnedIssueTypes)
+		})
+	}
+}
@@ -24,12 +24,20 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(SearchRepositories(getClient, t)),
 			toolsets.NewServerTool(GetFileContents(getClient, t)),
+			toolsets.NewServerTool(GetFileBlame(getGQLClient, t)),
+			toolsets.NewServerTool(GetFileHistory(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(ListCommits(getClient, t)),
 			toolsets.NewServerTool(SearchCode(getClient, t)),
+			toolsets.NewServerTool(SearchCommits(getClient, t)),
+			toolsets.NewServerTool(SearchTopics(getClient, t)),
+			toolsets.NewServerTool(GrepRepository(getClient, t)),
 			toolsets.NewServerTool(GetCommit(getClient, t)),
+			toolsets.NewServerTool(CompareRefs(getClient, t)),
 			toolsets.NewServerTool(ListBranches(getClient, t)),
 			toolsets.NewServerTool(ListTags(getClient, t)),
 			toolsets.NewServerTool(GetTag(getClient, t)),
+			toolsets.NewServerTool(GetGitTree(getClient, t)),
+			toolsets.NewServerTool(GetGitBlob(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateOrUpdateFile(getClient, t)),
@@ -38,6 +46,9 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(CreateBranch(getClient, t)),
 			toolsets.NewServerTool(PushFiles(getClient, t)),
 			toolsets.NewServerTool(DeleteFile(getClient, t)),
+			toolsets.NewServerTool(CreateGitRef(getClient, t)),
+			toolsets.NewServerTool(UpdateGitRef(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(DeleteGitRef(getClient, getGQLClient, t)),
 		)
 	issues := toolsets.NewToolset("issues", "GitHub Issues related tools").
 		AddReadTools(
@@ -45,12 +56,20 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(SearchIssues(getClient, t)),
 			toolsets.NewServerTool(ListIssues(getClient, t)),
 			toolsets.NewServerTool(GetIssueComments(getClient, t)),
+			toolsets.NewServerTool(GetIssueTimeline(getClient, getGQLClient, t)),
+			toolsets.NewServerTool(ListSubIssues(getClient, t)),
+			toolsets.NewServerTool(ListIssueTypes(getClient, t)),
 		).
 		AddWriteTools(
 			toolsets.NewServerTool(CreateIssue(getClient, t)),
 			toolsets.NewServerTool(AddIssueComment(getClient, t)),
 			toolsets.NewServerTool(UpdateIssue(getClient, t)),
 			toolsets.NewServerTool(AssignCopilotToIssue(getGQLClient, t)),
+			toolsets.NewServerTool(AddReaction(getClient, t)),
+			toolsets.NewServerTool(RemoveReaction(getClient, t)),
+			toolsets.NewServerTool(AddSubIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveSubIssue(getClient, t)),
+			toolsets.NewServerTool(ReprioritizeSubIssue(getClient, t)),
 		)
 	users := toolsets.NewToolset("users", "GitHub User related tools").
 		AddReadTools(
@@ -60,10 +79,14 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 		AddReadTools(
 			toolsets.NewServerTool(GetPullRequest(getClient, t)),
 			toolsets.NewServerTool(ListPullRequests(getClient, t)),
+			toolsets.NewServerTool(SearchPullRequests(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestFiles(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestStatus(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestChecks(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestMergeReadiness(getGQLClient, t)),
 			toolsets.NewServerTool(GetPullRequestComments(getClient, t)),
 			toolsets.NewServerTool(GetPullRequestReviews(getClient, t)),
+			toolsets.NewServerTool(GetPullRequestReviewThreads(getGQLClient, t)),
 			toolsets.NewServerTool(GetPullRequestDiff(getClient, t)),
 		).
 		AddWriteTools(
@@ -77,18 +100,35 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(CreateAndSubmitPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(CreatePendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(AddPullRequestReviewCommentToPendingReview(getGQLClient, t)),
+			toolsets.NewServerTool(AddSuggestionToPendingReview(getClient, getGQLClient, t)),
 			toolsets.NewServerTool(SubmitPendingPullRequestReview(getGQLClient, t)),
 			toolsets.NewServerTool(DeletePendingPullRequestReview(getGQLClient, t)),
+
+			// Review threads
+			toolsets.NewServerTool(ReplyToPullRequestReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(ResolvePullRequestReviewThread(getGQLClient, t)),
+			toolsets.NewServerTool(UnresolvePullRequestReviewThread(getGQLClient, t)),
+
+			// Merge queue and auto-merge
+			toolsets.NewServerTool(EnqueuePullRequest(getGQLClient, t)),
+			toolsets.NewServerTool(DequeuePullRequest(getGQLClient, t)),
+			toolsets.NewServerTool(EnablePullRequestAutoMerge(getGQLClient, t)),
+			toolsets.NewServerTool(DisablePullRequestAutoMerge(getGQLClient, t)),
 		)
 	codeSecurity := toolsets.NewToolset("code_security", "Code security related tools, such as GitHub Code Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetCodeScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListEnterpriseCodeScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(SummarizeOrgSecurityAlerts(getClient, t)),
 		)
 	secretProtection := toolsets.NewToolset("secret_protection", "Secret protection related tools, such as GitHub Secret Scanning").
 		AddReadTools(
 			toolsets.NewServerTool(GetSecretScanningAlert(getClient, t)),
 			toolsets.NewServerTool(ListSecretScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListOrgSecretScanningAlerts(getClient, t)),
+			toolsets.NewServerTool(ListEnterpriseSecretScanningAlerts(getClient, t)),
 		)
 
 	notifications := toolsets.NewToolset("notifications", "GitHub Notifications related tools").
@@ -117,6 +157,41 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 			toolsets.NewServerTool(DeleteRelease(getClient, t)),
 		)
 
+	labels := toolsets.NewToolset("labels", "GitHub Labels and Milestones related tools").
+		AddReadTools(
+			toolsets.NewServerTool(ListLabels(getClient, t)),
+			toolsets.NewServerTool(GetLabel(getClient, t)),
+			toolsets.NewServerTool(SearchLabels(getClient, t)),
+			toolsets.NewServerTool(ListMilestones(getClient, t)),
+			toolsets.NewServerTool(GetMilestone(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(CreateLabel(getClient, t)),
+			toolsets.NewServerTool(UpdateLabel(getClient, t)),
+			toolsets.NewServerTool(DeleteLabel(getClient, t)),
+			toolsets.NewServerTool(AddLabelsToIssue(getClient, t)),
+			toolsets.NewServerTool(RemoveLabelFromIssue(getClient, t)),
+			toolsets.NewServerTool(CreateMilestone(getClient, t)),
+			toolsets.NewServerTool(UpdateMilestone(getClient, t)),
+			toolsets.NewServerTool(DeleteMilestone(getClient, t)),
+		)
+
+	orgs := toolsets.NewToolset("orgs", "GitHub Organization related tools, such as teams and membership").
+		AddReadTools(
+			toolsets.NewServerTool(ListUserOrganizations(getClient, t)),
+			toolsets.NewServerTool(ListOrgTeams(getClient, t)),
+			toolsets.NewServerTool(ListChildTeams(getClient, t)),
+			toolsets.NewServerTool(ListTeamMembers(getClient, t)),
+			toolsets.NewServerTool(ListTeamRepositories(getClient, t)),
+			toolsets.NewServerTool(ListOrgMembers(getGQLClient, t)),
+			toolsets.NewServerTool(ListOutsideCollaborators(getClient, t)),
+		).
+		AddWriteTools(
+			toolsets.NewServerTool(AddTeamMember(getClient, t)),
+			toolsets.NewServerTool(RemoveTeamMember(getClient, t)),
+			toolsets.NewServerTool(SetTeamRepositoryPermission(getClient, t)),
+		)
+
 	// Keep experiments alive so the system doesn't error out when it's always enabled
 	experiments := toolsets.NewToolset("experiments", "Experimental features that are not considered stable yet")
 
@@ -129,6 +204,8 @@ func DefaultToolsetGroup(readOnly bool, getClient GetClientFn, getGQLClient GetG
 	tsg.AddToolset(secretProtection)
 	tsg.AddToolset(notifications)
 	tsg.AddToolset(releases)
+	tsg.AddToolset(labels)
+	tsg.AddToolset(orgs)
 	tsg.AddToolset(experiments)
 
 	return tsg